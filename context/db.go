@@ -71,7 +71,8 @@ func InitDb() {
 	orm.RegisterModel(new(query.Codebase), new(query.ActionLog), new(query.CodebaseBranch), new(query.ThirdPartyService),
 		new(query.CDPipeline), new(query.JobProvisioning), new(query.Stage), new(query.QualityGate), new(query.ApplicationsToPromote),
 		new(query.CodebaseDockerStream), new(query.GitServer), new(query.JenkinsSlave),
-		new(query.EDPComponent), new(query.JiraServer), new(query.PerfServer))
+		new(query.EDPComponent), new(query.JiraServer), new(query.PerfServer),
+		new(query.CDPipelineTemplate), new(query.QualityGateDecision), new(query.FreezeWindow))
 }
 
 func checkErr(err error) {
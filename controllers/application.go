@@ -24,6 +24,7 @@ import (
 	"edp-admin-console/models/query"
 	"edp-admin-console/service"
 	cbs "edp-admin-console/service/codebasebranch"
+	"edp-admin-console/service/export"
 	jiraservice "edp-admin-console/service/jira-server"
 	"edp-admin-console/service/logger"
 	"edp-admin-console/service/perfboard"
@@ -67,7 +68,8 @@ const (
 func (c *ApplicationController) GetApplicationsOverviewPage() {
 	flash := beego.ReadFromRequest(&c.Controller)
 	applications, err := c.CodebaseService.GetCodebasesByCriteria(query.CodebaseCriteria{
-		Type: query.App,
+		Type:   query.App,
+		Labels: getLabelFilter(&c.Controller),
 	})
 	applications = addCodebaseInProgressIfAny(applications, c.GetString(paramWaitingForCodebase))
 	if err != nil {
@@ -87,6 +89,7 @@ func (c *ApplicationController) GetApplicationsOverviewPage() {
 	c.Data["HasRights"] = auth.IsAdmin(contextRoles)
 	c.Data["Codebases"] = applications
 	c.Data["Type"] = query.App
+	c.Data["ExportColumns"] = export.CodebaseColumns()
 	c.Data["VersioningTypes"] = c.VersioningTypes
 	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
 	c.Data["BasePath"] = context.BasePath
@@ -232,6 +235,10 @@ func (c *ApplicationController) checkError(err error, flash *beego.FlashData, na
 		flash.Error("Application %v with %v project path already exists.", name, *url)
 		flash.Store(&c.Controller)
 		c.Redirect(fmt.Sprintf("%s/admin/edp/application/create", context.BasePath), 302)
+	case *edperror.NonValidPerfServerError, *edperror.NonValidCredentialsSecretError, *edperror.NonValidGitServerError:
+		flash.Error(err.Error())
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/application/create", context.BasePath), 302)
 	default:
 		log.Error("couldn't create codebase", zap.Error(err))
 		c.Abort("500")
@@ -303,14 +310,22 @@ func (c *ApplicationController) extractApplicationRequestData() command.CreateCo
 
 		isRepoPrivate, _ := c.GetBool("isRepoPrivate", false)
 		if isRepoPrivate {
-			codebase.Repository.Login = c.GetString("repoLogin")
-			codebase.Repository.Password = c.GetString("repoPassword")
+			if secret := c.GetString("repoSecret"); secret != "" {
+				codebase.Repository.SecretName = secret
+			} else {
+				codebase.Repository.Login = c.GetString("repoLogin")
+				codebase.Repository.Password = c.GetString("repoPassword")
+			}
 		}
 	}
 
 	vcsLogin := c.GetString("vcsLogin")
 	vcsPassword := c.GetString("vcsPassword")
-	if vcsLogin != "" && vcsPassword != "" {
+	if vcsSecret := c.GetString("vcsSecret"); vcsSecret != "" {
+		codebase.Vcs = &command.Vcs{
+			SecretName: vcsSecret,
+		}
+	} else if vcsLogin != "" && vcsPassword != "" {
 		codebase.Vcs = &command.Vcs{
 			Login:    vcsLogin,
 			Password: vcsPassword,
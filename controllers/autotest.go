@@ -8,6 +8,7 @@ import (
 	"edp-admin-console/models/query"
 	"edp-admin-console/service"
 	cbs "edp-admin-console/service/codebasebranch"
+	"edp-admin-console/service/export"
 	jiraservice "edp-admin-console/service/jira-server"
 	"edp-admin-console/service/perfboard"
 	"edp-admin-console/util"
@@ -80,6 +81,10 @@ func (c *AutotestsController) checkError(err error, flash *beego.FlashData, name
 		flash.Error("Autotest %v with %v project path already exists.", name, *url)
 		flash.Store(&c.Controller)
 		c.Redirect(fmt.Sprintf("%s/admin/edp/autotest/create", context.BasePath), 302)
+	case *edperror.NonValidPerfServerError, *edperror.NonValidCredentialsSecretError, *edperror.NonValidGitServerError:
+		flash.Error(err.Error())
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/autotest/create", context.BasePath), 302)
 	default:
 		log.Error("couldn't create codebase", zap.Error(err))
 		c.Abort("500")
@@ -92,11 +97,12 @@ func logAutotestsRequestData(autotests command.CreateCodebase) {
 		autotests.Name, autotests.Strategy, autotests.Lang, autotests.BuildTool, *autotests.TestReportFramework))
 
 	if autotests.Repository != nil {
-		result.WriteString(fmt.Sprintf(", repositoryUrl=%s, repositoryLogin=%s", autotests.Repository.Url, autotests.Repository.Login))
+		result.WriteString(fmt.Sprintf(", repositoryUrl=%s, repositoryLogin=%s, repositorySecret=%s",
+			autotests.Repository.Url, autotests.Repository.Login, autotests.Repository.SecretName))
 	}
 
 	if autotests.Vcs != nil {
-		result.WriteString(fmt.Sprintf(", vcsLogin=%s", autotests.Vcs.Login))
+		result.WriteString(fmt.Sprintf(", vcsLogin=%s, vcsSecret=%s", autotests.Vcs.Login, autotests.Vcs.SecretName))
 	}
 
 	log.Info(result.String())
@@ -163,8 +169,12 @@ func (c *AutotestsController) extractAutotestsRequestData() command.CreateCodeba
 
 		isRepoPrivate, _ := c.GetBool("isRepoPrivate", false)
 		if isRepoPrivate {
-			codebase.Repository.Login = c.GetString("repoLogin")
-			codebase.Repository.Password = c.GetString("repoPassword")
+			if secret := c.GetString("repoSecret"); secret != "" {
+				codebase.Repository.SecretName = secret
+			} else {
+				codebase.Repository.Login = c.GetString("repoLogin")
+				codebase.Repository.Password = c.GetString("repoPassword")
+			}
 		}
 	}
 
@@ -175,7 +185,11 @@ func (c *AutotestsController) extractAutotestsRequestData() command.CreateCodeba
 
 	vcsLogin := c.GetString("vcsLogin")
 	vcsPassword := c.GetString("vcsPassword")
-	if vcsLogin != "" && vcsPassword != "" {
+	if vcsSecret := c.GetString("vcsSecret"); vcsSecret != "" {
+		codebase.Vcs = &command.Vcs{
+			SecretName: vcsSecret,
+		}
+	} else if vcsLogin != "" && vcsPassword != "" {
 		codebase.Vcs = &command.Vcs{
 			Login:    vcsLogin,
 			Password: vcsPassword,
@@ -205,11 +219,13 @@ func validateAutotestsRequestData(autotests command.CreateCodebase) *validation2
 	if autotests.Repository != nil {
 		_, err = valid.Valid(autotests.Repository)
 
-		isAvailable := util.IsGitRepoAvailable(autotests.Repository.Url, autotests.Repository.Login, autotests.Repository.Password)
+		if autotests.Repository.SecretName == "" {
+			isAvailable := util.IsGitRepoAvailable(autotests.Repository.Url, autotests.Repository.Login, autotests.Repository.Password)
 
-		if !isAvailable {
-			err := &validation.Error{Key: "repository", Message: "Repository doesn't exist or invalid login and password."}
-			valid.Errors = append(valid.Errors, err)
+			if !isAvailable {
+				err := &validation.Error{Key: "repository", Message: "Repository doesn't exist or invalid login and password."}
+				valid.Errors = append(valid.Errors, err)
+			}
 		}
 	}
 
@@ -220,6 +236,11 @@ func validateAutotestsRequestData(autotests command.CreateCodebase) *validation2
 
 	if autotests.Vcs != nil {
 		_, err = valid.Valid(autotests.Vcs)
+
+		if autotests.Vcs.SecretName == "" && (autotests.Vcs.Login == "" || autotests.Vcs.Password == "") {
+			err := &validation.Error{Key: "vcs", Message: "either secret name or login and password should be specified for VCS"}
+			valid.Errors = append(valid.Errors, err)
+		}
 	}
 
 	if autotests.Perf != nil {
@@ -316,7 +337,8 @@ func (c *AutotestsController) GetCreateAutotestsPage() {
 func (c *AutotestsController) GetAutotestsOverviewPage() {
 	flash := beego.ReadFromRequest(&c.Controller)
 	codebases, err := c.CodebaseService.GetCodebasesByCriteria(query.CodebaseCriteria{
-		Type: query.Autotests,
+		Type:   query.Autotests,
+		Labels: getLabelFilter(&c.Controller),
 	})
 	codebases = addCodebaseInProgressIfAny(codebases, c.GetString(paramWaitingForCodebase))
 	if err != nil {
@@ -335,6 +357,7 @@ func (c *AutotestsController) GetAutotestsOverviewPage() {
 	c.Data["Username"] = c.Ctx.Input.Session("username")
 	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
 	c.Data["Type"] = query.Autotests
+	c.Data["ExportColumns"] = export.CodebaseColumns()
 	c.Data["BasePath"] = context.BasePath
 	c.Data["VersioningTypes"] = c.VersioningTypes
 	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
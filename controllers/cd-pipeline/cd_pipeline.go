@@ -27,8 +27,13 @@ import (
 	"edp-admin-console/service/cd_pipeline"
 	cbs "edp-admin-console/service/codebasebranch"
 	ec "edp-admin-console/service/edp-component"
+	"edp-admin-console/service/export"
+	fws "edp-admin-console/service/freeze-window"
+	"edp-admin-console/service/label"
 	"edp-admin-console/service/logger"
+	pts "edp-admin-console/service/pipeline-template"
 	"edp-admin-console/service/platform"
+	qgs "edp-admin-console/service/quality-gate"
 	"edp-admin-console/util"
 	"edp-admin-console/util/auth"
 	"edp-admin-console/util/consts"
@@ -39,6 +44,8 @@ import (
 	"net/http"
 	"regexp"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/astaxie/beego"
 	edppipelinesv1alpha1 "github.com/epmd-edp/cd-pipeline-operator/v2/pkg/apis/edp/v1alpha1"
@@ -56,11 +63,16 @@ type CDPipelineController struct {
 	ThirdPartyService service.ThirdPartyService
 	EDPComponent      ec.EDPComponentService
 	JobProvisioning   service.JobProvisioning
+	PipelineTemplate  pts.PipelineTemplateService
+	QualityGate       qgs.QualityGateService
+	FreezeWindow      fws.FreezeWindowService
+	Label             label.LabelService
 }
 
 const (
 	paramWaitingForCdPipeline = "waitingforcdpipeline"
 	scope                     = "cd"
+	freezeWindowTimeLayout    = "2006-01-02T15:04"
 )
 
 func (c *CDPipelineController) GetContinuousDeliveryPage() {
@@ -81,7 +93,9 @@ func (c *CDPipelineController) GetContinuousDeliveryPage() {
 		return
 	}
 
-	cdPipelines, err := c.PipelineService.GetAllPipelines(query.CDPipelineCriteria{})
+	cdPipelines, err := c.PipelineService.GetAllPipelines(query.CDPipelineCriteria{
+		Labels: c.getLabelFilter(),
+	})
 	if err != nil {
 		c.Abort("500")
 		return
@@ -102,6 +116,7 @@ func (c *CDPipelineController) GetContinuousDeliveryPage() {
 	contextRoles := c.GetSession("realm_roles").([]string)
 	c.Data["ActiveApplicationsAndBranches"] = len(applications) > 0 && len(branches) > 0
 	c.Data["CDPipelines"] = cdPipelines
+	c.Data["ExportColumns"] = export.CDPipelineColumns()
 	c.Data["Applications"] = applications
 	c.Data["EDPVersion"] = context.EDPVersion
 	c.Data["Username"] = c.Ctx.Input.Session("username")
@@ -162,9 +177,17 @@ func (c *CDPipelineController) GetCreateCDPipelinePage() {
 		return
 	}
 
+	templates, err := c.PipelineTemplate.GetTemplates()
+	if err != nil {
+		log.Error("an error has occurred while getting cd pipeline templates", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
 	autotests = filterAutotestsWithActiveBranches(autotests)
 
 	c.Data["Services"] = services
+	c.Data["Templates"] = templates
 	c.Data["Apps"] = apps
 	c.Data["GroovyLibs"] = groovyLibs
 	c.Data["EDPVersion"] = context.EDPVersion
@@ -242,11 +265,34 @@ func (c *CDPipelineController) GetEditCDPipelinePage() {
 		return
 	}
 
+	services, err := c.ThirdPartyService.GetAllServices()
+	if err != nil {
+		log.Error("an error has occurred while getting services list", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
 	if flash.Data["error"] != "" {
 		c.Data["Error"] = flash.Data["error"]
 	}
 
+	stageServices, err := c.ThirdPartyService.GetStageServices(pipelineName)
+	if err != nil {
+		log.Error("an error has occurred while getting services of stages", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	selectedServices := map[string]map[string]bool{}
+	for stage, names := range stageServices {
+		selectedServices[stage] = map[string]bool{}
+		for _, n := range names {
+			selectedServices[stage][n] = true
+		}
+	}
+
 	c.Data["CDPipeline"] = cdPipeline
+	c.Data["Services"] = services
+	c.Data["SelectedServices"] = selectedServices
 	c.Data["Apps"] = applications
 	c.Data["GroovyLibs"] = groovyLibs
 	c.Data["Type"] = "delivery"
@@ -275,6 +321,7 @@ func (c *CDPipelineController) UpdateCDPipeline() {
 		Applications:         c.convertApplicationWithBranchesData(appNameCheckboxes),
 		ApplicationToApprove: c.getApplicationsToPromoteFromRequest(appNameCheckboxes),
 		Stages:               stages,
+		StageServices:        retrieveStageServicesFromRequest(c),
 	}
 
 	errMsg := validation.ValidateCDPipelineUpdateRequestData(pipelineUpdateCommand)
@@ -285,11 +332,19 @@ func (c *CDPipelineController) UpdateCDPipeline() {
 		c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%s/update", context.BasePath, pipelineName), 302)
 		return
 	}
+
+	labels, err := label.ParseLabels(c.GetString("labels"))
+	if err != nil {
+		flash.Error(err.Error())
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%s/update", context.BasePath, pipelineName), http.StatusFound)
+		return
+	}
 	log.Debug("Request data is received to update CD pipeline",
 		zap.String("pipeline", pipelineName),
 		zap.Any("applications", pipelineUpdateCommand.Applications),
 		zap.Any("stages", pipelineUpdateCommand.Stages),
-		zap.Any("services", pipelineUpdateCommand.ThirdPartyServices))
+		zap.Any("stageServices", pipelineUpdateCommand.StageServices))
 
 	err = c.PipelineService.UpdatePipeline(pipelineUpdateCommand)
 	if err != nil {
@@ -305,12 +360,23 @@ func (c *CDPipelineController) UpdateCDPipeline() {
 			flash.Store(&c.Controller)
 			c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%s/update", context.BasePath, pipelineName), http.StatusBadRequest)
 			return
+		case *edperror.StageFrozenError, *edperror.NonValidThirdPartyServiceError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%s/update", context.BasePath, pipelineName), http.StatusFound)
+			return
 		default:
 			c.Abort("500")
 			return
 		}
 	}
 
+	if err := c.Label.UpdateCDPipelineLabels(pipelineName, labels); err != nil {
+		log.Error("couldn't update cd pipeline labels", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
 	c.Data["EDPVersion"] = context.EDPVersion
 	c.Data["Username"] = c.Ctx.Input.Session("username")
 	c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/overview#cdPipelineEditSuccessModal", context.BasePath), 302)
@@ -366,6 +432,11 @@ func (c *CDPipelineController) CreateCDPipeline() {
 			flash.Store(&c.Controller)
 			c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/create", context.BasePath), http.StatusBadRequest)
 			return
+		case *edperror.NonValidThirdPartyServiceError:
+			flash.Error(pipelineErr.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/create", context.BasePath), http.StatusFound)
+			return
 		default:
 			c.Abort("500")
 			return
@@ -408,6 +479,18 @@ func (c *CDPipelineController) GetCDPipelineOverviewPage() {
 		return
 	}
 
+	if err := c.QualityGate.FillDecisions(cdPipeline.Stage); err != nil {
+		log.Error("an error has occurred while getting quality gate decisions", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	if err := c.FreezeWindow.FillFreezeWindows(cdPipeline.Stage); err != nil {
+		log.Error("an error has occurred while getting freeze windows", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
 	flash := beego.ReadFromRequest(&c.Controller)
 	if flash.Data["success"] != "" {
 		c.Data["Success"] = true
@@ -417,6 +500,7 @@ func (c *CDPipelineController) GetCDPipelineOverviewPage() {
 	}
 	contextRoles := c.GetSession("realm_roles").([]string)
 	c.Data["CDPipeline"] = cdPipeline
+	c.Data["HasManualGates"] = hasManualGates(cdPipeline.Stage)
 	c.Data["EDPVersion"] = context.EDPVersion
 	c.Data["Username"] = c.Ctx.Input.Session("username")
 	c.Data["Type"] = "delivery"
@@ -428,6 +512,123 @@ func (c *CDPipelineController) GetCDPipelineOverviewPage() {
 	c.TplName = "cd_pipeline_overview.html"
 }
 
+func hasManualGates(stages []*query.Stage) bool {
+	for _, s := range stages {
+		for _, g := range s.QualityGates {
+			if g.QualityGateType == "manual" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *CDPipelineController) MakeQualityGateDecision() {
+	pn := c.GetString(":pipelineName")
+	sn := c.GetString(":stageName")
+	step := c.GetString(":step")
+	dc := command.QualityGateDecisionCommand{
+		Decision: c.GetString("decision"),
+		Comment:  c.GetString("comment"),
+	}
+	dc.Username, _ = c.Ctx.Input.Session("username").(string)
+	log.Debug("request to make decision on quality gate has been received",
+		zap.String("pipeline", pn),
+		zap.String("stage", sn),
+		zap.String("step", step),
+		zap.String("decision", dc.Decision))
+
+	if errMsg := validation.ValidateQualityGateDecisionRequest(dc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%v/overview?stage=%v&step=%v#gateDecisionError", context.BasePath, pn, sn, step), 302)
+		return
+	}
+
+	if _, err := c.QualityGate.MakeDecision(pn, sn, step, dc); err != nil {
+		switch err.(type) {
+		case *edperror.QualityGateDoesNotExistError, *edperror.StageFrozenError:
+			log.Error(err.Error())
+			flash := beego.NewFlash()
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%v/overview?stage=%v&step=%v#gateDecisionError", context.BasePath, pn, sn, step), 302)
+			return
+		}
+		log.Error("an error has occurred while making decision on quality gate", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%v/overview?stage=%v&step=%v#gateDecisionSuccess", context.BasePath, pn, sn, step), 302)
+}
+
+func (c *CDPipelineController) CreateFreezeWindow() {
+	flash := beego.NewFlash()
+	pn := c.GetString(":pipelineName")
+	sn := c.GetString("stage")
+	wc := command.FreezeWindowCommand{
+		Reason:     c.GetString("reason"),
+		Recurrence: c.GetString("recurrence"),
+	}
+	wc.Username, _ = c.Ctx.Input.Session("username").(string)
+	wc.StartTime, _ = time.ParseInLocation(freezeWindowTimeLayout, c.GetString("startTime"), time.Local)
+	wc.EndTime, _ = time.ParseInLocation(freezeWindowTimeLayout, c.GetString("endTime"), time.Local)
+	log.Debug("request to create freeze window has been received",
+		zap.String("pipeline", pn),
+		zap.String("stage", sn),
+		zap.Any("window", wc))
+
+	if errMsg := validation.ValidateFreezeWindowRequest(wc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		flash.Error("freeze window should have a reason and a valid recurrence")
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%v/overview?stage=%v#freezeWindowError", context.BasePath, pn, sn), 302)
+		return
+	}
+
+	if _, err := c.FreezeWindow.CreateFreezeWindow(pn, sn, wc); err != nil {
+		switch err.(type) {
+		case *edperror.CDStageDoesNotExistError, *edperror.NonValidFreezeWindowError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%v/overview?stage=%v#freezeWindowError", context.BasePath, pn, sn), 302)
+			return
+		}
+		log.Error("an error has occurred while creating freeze window", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%v/overview?stage=%v#freezeWindowCreated", context.BasePath, pn, sn), 302)
+}
+
+func (c *CDPipelineController) DeleteFreezeWindow() {
+	pn := c.GetString(":pipelineName")
+	sn := c.GetString("stage")
+	id, err := c.GetInt("id")
+	if err != nil {
+		c.Abort("400")
+		return
+	}
+	log.Debug("request to delete freeze window has been received",
+		zap.String("pipeline", pn),
+		zap.String("stage", sn),
+		zap.Int("id", id))
+
+	if err := c.FreezeWindow.DeleteFreezeWindow(pn, sn, id); err != nil {
+		switch err.(type) {
+		case *edperror.CDStageDoesNotExistError, *edperror.FreezeWindowDoesNotExistError:
+			flash := beego.NewFlash()
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%v/overview?stage=%v#freezeWindowError", context.BasePath, pn, sn), 302)
+			return
+		}
+		log.Error("an error has occurred while deleting freeze window", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/%v/overview?stage=%v#freezeWindowDeleted", context.BasePath, pn, sn), 302)
+}
+
 func retrieveStagesFromRequest(this *CDPipelineController, stageCount int) []command.CDStageCommand {
 	var stages []command.CDStageCommand
 
@@ -451,6 +652,7 @@ func retrieveStagesFromRequest(this *CDPipelineController, stageCount int) []com
 			Source:          stgSrc,
 			Order:           index + stageCount,
 			JobProvisioning: this.GetString(stageName + "-jobProvisioning"),
+			Services:        retrieveStageServices(this.GetString(stageName + "-services")),
 		}
 
 		for _, stepName := range this.GetStrings(stageName + "-stageStepName") {
@@ -480,6 +682,28 @@ func retrieveStagesFromRequest(this *CDPipelineController, stageCount int) []com
 	return stages
 }
 
+func retrieveStageServices(value string) []string {
+	var services []string
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			services = append(services, s)
+		}
+	}
+	return services
+}
+
+func retrieveStageServicesFromRequest(this *CDPipelineController) map[string][]string {
+	res := map[string][]string{}
+	for _, stageName := range this.GetStrings("existingStageName") {
+		services := this.GetStrings(stageName + "-existingStageServices")
+		if services == nil {
+			services = []string{}
+		}
+		res[stageName] = services
+	}
+	return res
+}
+
 func (c *CDPipelineController) convertApplicationWithBranchesData(appNameCheckboxes []string) []models.CDPipelineApplicationCommand {
 	var applicationWithBranches []models.CDPipelineApplicationCommand
 	for _, appName := range appNameCheckboxes {
@@ -521,6 +745,21 @@ func (c *CDPipelineController) getApplicationsToPromoteFromRequest(appNameCheckb
 	return applicationsToPromote
 }
 
+func (c *CDPipelineController) getLabelFilter() map[string]string {
+	f := c.GetString("label")
+	if f == "" {
+		return nil
+	}
+	c.Data["LabelFilter"] = f
+
+	labels, err := label.ParseLabels(f)
+	if err != nil {
+		c.Data["LabelError"] = err.Error()
+		return nil
+	}
+	return labels
+}
+
 func (c *CDPipelineController) createOneJenkinsLink(cdPipeline *query.CDPipeline) error {
 	edc, err := c.EDPComponent.GetEDPComponent(consts.Jenkins)
 	if err != nil {
@@ -765,4 +1004,113 @@ func (c *CDPipelineController) getCDPipelineStageCount(pipelineName string) (*in
 		return nil, fmt.Errorf("an error has occurred while getting the number of stages of cd-pipeline")
 	}
 	return existedStageNumber, nil
-}
\ No newline at end of file
+}
+
+func (c *CDPipelineController) GetCloneCDPipelinePage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	pipelineName := c.GetString(":name")
+
+	cdPipeline, err := c.PipelineService.GetCDPipelineByName(pipelineName)
+	if err != nil {
+		log.Error("an error has occurred while getting cd pipeline", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	if cdPipeline == nil {
+		c.Abort("404")
+		return
+	}
+
+	applications, err := c.CodebaseService.GetCodebasesByCriteria(query.CodebaseCriteria{
+		BranchStatus: query.Active,
+		Status:       query.Active,
+		Type:         query.App,
+	})
+	if err != nil {
+		log.Error("an error has occurred while getting applications list", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	c.Data["CDPipeline"] = cdPipeline
+	c.Data["Apps"] = applications
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["Type"] = "delivery"
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "clone_cd_pipeline.html"
+}
+
+func (c *CDPipelineController) CloneCDPipeline() {
+	flash := beego.NewFlash()
+	source := c.GetString(":name")
+	cc := command.CloneCDPipelineCommand{
+		Name: c.GetString("pipelineName"),
+	}
+	cc.Username, _ = c.Ctx.Input.Session("username").(string)
+	for _, appName := range c.GetStrings("app") {
+		cc.Applications = append(cc.Applications, command.CDPipelineApplicationBranch{
+			ApplicationName: appName,
+			BranchName:      c.GetString(appName + "-branch"),
+		})
+	}
+	log.Debug("request data is received to clone CD pipeline",
+		zap.String("source", source),
+		zap.String("pipeline", cc.Name),
+		zap.Any("applications", cc.Applications))
+
+	cloneUrl := fmt.Sprintf("%s/admin/edp/cd-pipeline/%s/clone", context.BasePath, source)
+	pc, err := c.PipelineService.CreateCloneCommand(source, cc)
+	if err != nil {
+		switch err.(type) {
+		case *edperror.CDPipelineDoesNotExistError:
+			c.Abort("404")
+			return
+		case *edperror.NonValidApplicationMappingError, *edperror.NonValidRelatedBranchError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(cloneUrl, http.StatusFound)
+			return
+		default:
+			log.Error("an error has occurred while building clone command", zap.Error(err))
+			c.Abort("500")
+			return
+		}
+	}
+
+	if errMsg := validation.ValidateCDPipelineRequest(*pc); errMsg != nil {
+		log.Error("Request data is not valid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&c.Controller)
+		c.Redirect(cloneUrl, http.StatusFound)
+		return
+	}
+
+	if _, err := c.PipelineService.CreatePipeline(*pc); err != nil {
+		switch err.(type) {
+		case *edperror.CDPipelineExistsError:
+			flash.Error(fmt.Sprintf("cd pipeline %v is already exists", pc.Name))
+			flash.Store(&c.Controller)
+			c.Redirect(cloneUrl, http.StatusFound)
+			return
+		case *edperror.NonValidRelatedBranchError:
+			flash.Error(fmt.Sprintf("one or more applications have non valid branches: %v", pc.Applications))
+			flash.Store(&c.Controller)
+			c.Redirect(cloneUrl, http.StatusFound)
+			return
+		default:
+			log.Error("an error has occurred while cloning cd pipeline", zap.Error(err))
+			c.Abort("500")
+			return
+		}
+	}
+
+	c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline/overview?%s=%s#cdPipelineSuccessModal", context.BasePath, paramWaitingForCdPipeline, pc.Name), 302)
+}
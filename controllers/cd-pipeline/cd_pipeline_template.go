@@ -0,0 +1,126 @@
+package pipeline
+
+import (
+	"edp-admin-console/context"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/service/cd_pipeline"
+	pts "edp-admin-console/service/pipeline-template"
+	"edp-admin-console/util/auth"
+	"fmt"
+	"html/template"
+	"net/http"
+	"regexp"
+
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+)
+
+type CDPipelineTemplateController struct {
+	beego.Controller
+	PipelineService         cd_pipeline.CDPipelineService
+	PipelineTemplateService pts.PipelineTemplateService
+}
+
+const templateNameRegex = "^[a-z0-9]([-a-z0-9]*[a-z0-9])$"
+
+func (c *CDPipelineTemplateController) GetTemplatesPage() {
+	templates, err := c.PipelineTemplateService.GetTemplates()
+	if err != nil {
+		log.Error("an error has occurred while getting cd pipeline templates", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	pipelines, err := c.PipelineService.GetAllPipelines(query.CDPipelineCriteria{})
+	if err != nil {
+		log.Error("an error has occurred while getting cd pipelines", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+	if flash.Data["success"] != "" {
+		c.Data["Success"] = flash.Data["success"]
+	}
+	contextRoles := c.GetSession("realm_roles").([]string)
+	c.Data["Templates"] = templates
+	c.Data["CDPipelines"] = pipelines
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["HasRights"] = auth.IsAdmin(contextRoles)
+	c.Data["Type"] = "delivery"
+	c.Data["BasePath"] = context.BasePath
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "cd_pipeline_templates.html"
+}
+
+func (c *CDPipelineTemplateController) CreateTemplate() {
+	flash := beego.NewFlash()
+	name := c.GetString("templateName")
+	pipeName := c.GetString("pipelineName")
+	overviewUrl := fmt.Sprintf("%s/admin/edp/cd-pipeline-template/overview", context.BasePath)
+	log.Debug("request to create cd pipeline template has been retrieved",
+		zap.String("template", name), zap.String("pipe", pipeName))
+
+	if match, _ := regexp.MatchString(templateNameRegex, name); !match {
+		flash.Error("Template name may contain only: lower-case letters, numbers and dashes and cannot start and end with dash.")
+		flash.Store(&c.Controller)
+		c.Redirect(overviewUrl, http.StatusFound)
+		return
+	}
+
+	p, err := c.PipelineService.GetCDPipelineByName(pipeName)
+	if err != nil {
+		log.Error("an error has occurred while getting cd pipeline", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	if p == nil {
+		flash.Error(fmt.Sprintf("cd pipeline %v doesn't exist", pipeName))
+		flash.Store(&c.Controller)
+		c.Redirect(overviewUrl, http.StatusFound)
+		return
+	}
+
+	if err := c.PipelineTemplateService.CreateTemplateFromStages(name, c.GetString("description"), p.Stage); err != nil {
+		if _, ok := err.(*edperror.CDPipelineTemplateExistsError); ok {
+			flash.Error(fmt.Sprintf("cd pipeline template %v is already exists", name))
+			flash.Store(&c.Controller)
+			c.Redirect(overviewUrl, http.StatusFound)
+			return
+		}
+		log.Error("an error has occurred while creating cd pipeline template", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	flash.Success(fmt.Sprintf("CD Pipeline template %v has been created.", name))
+	flash.Store(&c.Controller)
+	c.Redirect(overviewUrl, http.StatusFound)
+}
+
+func (c *CDPipelineTemplateController) DeleteTemplate() {
+	flash := beego.NewFlash()
+	name := c.GetString("name")
+	log.Debug("request to delete cd pipeline template has been retrieved", zap.String("name", name))
+	if err := c.PipelineTemplateService.DeleteTemplate(name); err != nil {
+		if _, ok := err.(*edperror.CDPipelineTemplateDoesNotExistError); ok {
+			flash.Error(fmt.Sprintf("cd pipeline template %v doesn't exist", name))
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline-template/overview", context.BasePath), http.StatusFound)
+			return
+		}
+		log.Error("an error has occurred while deleting cd pipeline template", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	flash.Success(fmt.Sprintf("CD Pipeline template %v has been deleted.", name))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/cd-pipeline-template/overview", context.BasePath), http.StatusFound)
+}
@@ -19,8 +19,12 @@ package controllers
 import (
 	"edp-admin-console/controllers/validation"
 	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
 	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
 	"edp-admin-console/service/cd_pipeline"
+	"edp-admin-console/service/label"
+	pts "edp-admin-console/service/pipeline-template"
 	dberror "edp-admin-console/util/error/db-errors"
 	"encoding/json"
 	"fmt"
@@ -32,13 +36,34 @@ import (
 
 type CDPipelineRestController struct {
 	beego.Controller
-	CDPipelineService cd_pipeline.CDPipelineService
+	CDPipelineService       cd_pipeline.CDPipelineService
+	PipelineTemplateService pts.PipelineTemplateService
 }
 
 func (c *CDPipelineRestController) Prepare() {
 	c.EnableXSRF = false
 }
 
+func (c *CDPipelineRestController) GetCDPipelines() {
+	labels, err := label.ParseLabels(c.GetStrings("label")...)
+	if err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pipelines, err := c.CDPipelineService.GetAllPipelines(query.CDPipelineCriteria{
+		Status: query.Status(c.GetString("status")),
+		Labels: labels,
+	})
+	if err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Data["json"] = pipelines
+	c.ServeJSON()
+}
+
 func (c *CDPipelineRestController) GetCDPipelineByName() {
 	pipelineName := c.GetString(":name")
 	cdPipeline, err := c.CDPipelineService.GetCDPipelineByName(pipelineName)
@@ -83,6 +108,32 @@ func (c *CDPipelineRestController) CreateCDPipeline() {
 		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
 		return
 	}
+
+	if cdPipelineCreateCommand.TemplateRef != "" {
+		if len(cdPipelineCreateCommand.Stages) != 0 {
+			http.Error(c.Ctx.ResponseWriter, "stages and templateRef can't be passed together", http.StatusBadRequest)
+			return
+		}
+		stages, err := c.PipelineTemplateService.ExpandTemplate(cdPipelineCreateCommand.TemplateRef,
+			cdPipelineCreateCommand.TemplateParams)
+		if err != nil {
+			switch err.(type) {
+			case *edperror.CDPipelineTemplateDoesNotExistError:
+				http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("cd pipeline template %v doesn't exist",
+					cdPipelineCreateCommand.TemplateRef), http.StatusBadRequest)
+				return
+			case *edperror.NonValidTemplateParamsError:
+				http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+				return
+			default:
+				log.Error("couldn't expand cd pipeline template", zap.Error(err))
+				http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		cdPipelineCreateCommand.Stages = stages
+	}
+
 	errMsg := validation.ValidateCDPipelineRequest(cdPipelineCreateCommand)
 	if errMsg != nil {
 		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
@@ -105,6 +156,9 @@ func (c *CDPipelineRestController) CreateCDPipeline() {
 		case *edperror.NonValidRelatedBranchError:
 			http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("one or more applications have non valid branches: %v", cdPipelineCreateCommand.Applications), http.StatusBadRequest)
 			return
+		case *edperror.NonValidThirdPartyServiceError:
+			http.Error(c.Ctx.ResponseWriter, pipelineErr.Error(), http.StatusBadRequest)
+			return
 		default:
 			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
 			return
@@ -134,7 +188,8 @@ func (c *CDPipelineRestController) UpdateCDPipeline() {
 	log.Info("Request data is received to update CD pipeline",
 		zap.String("pipeline", pipelineUpdateCommand.Name),
 		zap.Any("applications", pipelineUpdateCommand.Applications),
-		zap.Any("stages", pipelineUpdateCommand.Stages))
+		zap.Any("stages", pipelineUpdateCommand.Stages),
+		zap.Any("stageServices", pipelineUpdateCommand.StageServices))
 
 	err = c.CDPipelineService.UpdatePipeline(pipelineUpdateCommand)
 	if err != nil {
@@ -146,6 +201,12 @@ func (c *CDPipelineRestController) UpdateCDPipeline() {
 		case *edperror.NonValidRelatedBranchError:
 			http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("one or more applications have non valid branches: %v", pipelineUpdateCommand.Name), http.StatusNotFound)
 			return
+		case *edperror.StageFrozenError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusConflict)
+			return
+		case *edperror.NonValidThirdPartyServiceError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+			return
 		default:
 			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
 			return
@@ -183,3 +244,115 @@ func (c *CDPipelineRestController) DeleteCDStage() {
 	c.Ctx.ResponseWriter.WriteHeader(200)
 	c.Ctx.Output.Header("Location", location)
 }
+
+func (c *CDPipelineRestController) DeleteCDPipeline() {
+	name := c.GetString(":name")
+	dryRun, _ := c.GetBool("dryRun")
+	log.Debug("request to delete cd pipeline has been retrieved",
+		zap.String("pipeline", name),
+		zap.Bool("dryRun", dryRun))
+
+	plan, err := c.CDPipelineService.GetCDPipelineDeletionPlan(name)
+	if err != nil {
+		if _, ok := err.(*edperror.CDPipelineDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("cd pipeline %v doesn't exist", name), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't get cd pipeline deletion plan", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if dryRun {
+		c.Data["json"] = plan
+		c.ServeJSON()
+		return
+	}
+
+	if !plan.Deletable {
+		c.writeDeletionConflict(fmt.Sprintf("cd pipeline %v can't be deleted", name), plan)
+		return
+	}
+
+	if err := c.CDPipelineService.DeleteCDPipeline(name); err != nil {
+		if dberror.CDPipelineErrorOccurred(err) {
+			perr := err.(dberror.RemoveCDPipelineRestriction)
+			log.Error(perr.Message, zap.Error(err))
+			c.writeDeletionConflict(perr.Message, plan)
+			return
+		}
+		log.Error("cd pipeline delete process is failed", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, "delete process is failed", http.StatusInternalServerError)
+		return
+	}
+	log.Info("cd pipeline has been marked for deletion", zap.String("pipeline", name))
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *CDPipelineRestController) writeDeletionConflict(message string, plan *dto.CDPipelineDeletionPlan) {
+	body, _ := json.Marshal(struct {
+		Message      string                  `json:"message"`
+		Dependents   []dto.CDStageDependents `json:"dependents"`
+		FrozenStages []string                `json:"frozenStages"`
+	}{
+		Message:      message,
+		Dependents:   plan.Dependents,
+		FrozenStages: plan.FrozenStages,
+	})
+	c.Ctx.Output.Header("Content-Type", "application/json; charset=utf-8")
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusConflict)
+	c.Ctx.ResponseWriter.Write(body)
+}
+
+func (c *CDPipelineRestController) CloneCDPipeline() {
+	var cc command.CloneCDPipelineCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cc); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cc.Username, _ = c.Ctx.Input.Session("username").(string)
+	source := c.GetString(":name")
+	log.Info("request data is received to clone CD pipeline",
+		zap.String("source", source),
+		zap.String("pipeline", cc.Name),
+		zap.Any("applications", cc.Applications))
+
+	pc, err := c.CDPipelineService.CreateCloneCommand(source, cc)
+	if err != nil {
+		switch err.(type) {
+		case *edperror.CDPipelineDoesNotExistError:
+			http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("cd pipeline %v doesn't exist", source), http.StatusNotFound)
+			return
+		case *edperror.NonValidApplicationMappingError, *edperror.NonValidRelatedBranchError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			log.Error("couldn't build command to clone cd pipeline", zap.Error(err))
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if errMsg := validation.ValidateCDPipelineRequest(*pc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, http.StatusBadRequest)
+		return
+	}
+
+	if _, err := c.CDPipelineService.CreatePipeline(*pc); err != nil {
+		switch err.(type) {
+		case *edperror.CDPipelineExistsError:
+			http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("cd pipeline %v is already exists", pc.Name), http.StatusFound)
+			return
+		case *edperror.NonValidRelatedBranchError:
+			http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("one or more applications have non valid branches: %v", pc.Applications), http.StatusBadRequest)
+			return
+		default:
+			log.Error("couldn't clone cd pipeline", zap.Error(err))
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
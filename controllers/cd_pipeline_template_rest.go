@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	pts "edp-admin-console/service/pipeline-template"
+	"encoding/json"
+	"fmt"
+	"github.com/astaxie/beego"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type CDPipelineTemplateRestController struct {
+	beego.Controller
+	PipelineTemplateService pts.PipelineTemplateService
+}
+
+func (c *CDPipelineTemplateRestController) Prepare() {
+	c.EnableXSRF = false
+}
+
+func (c *CDPipelineTemplateRestController) GetTemplates() {
+	templates, err := c.PipelineTemplateService.GetTemplates()
+	if err != nil {
+		log.Error("couldn't get cd pipeline templates", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Data["json"] = templates
+	c.ServeJSON()
+}
+
+func (c *CDPipelineTemplateRestController) GetTemplate() {
+	name := c.GetString(":name")
+	t, err := c.PipelineTemplateService.GetTemplate(name)
+	if err != nil {
+		log.Error("couldn't get cd pipeline template", zap.String("name", name), zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if t == nil {
+		http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("cd pipeline template %v doesn't exist", name), http.StatusNotFound)
+		return
+	}
+
+	c.Data["json"] = t
+	c.ServeJSON()
+}
+
+func (c *CDPipelineTemplateRestController) CreateTemplate() {
+	var tc command.CDPipelineTemplateCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&tc); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if errMsg := validation.ValidateCDPipelineTemplateRequest(tc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, http.StatusBadRequest)
+		return
+	}
+	log.Info("request data is received to create cd pipeline template",
+		zap.String("name", tc.Name), zap.Any("stages", tc.Stages))
+
+	if err := c.PipelineTemplateService.CreateTemplate(tc); err != nil {
+		if _, ok := err.(*edperror.CDPipelineTemplateExistsError); ok {
+			http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("cd pipeline template %v is already exists", tc.Name), http.StatusConflict)
+			return
+		}
+		log.Error("couldn't create cd pipeline template", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	location := fmt.Sprintf("%s/%s", c.Ctx.Input.URL(), uuid.NewV4().String())
+	c.Ctx.Output.Header("Location", location)
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *CDPipelineTemplateRestController) UpdateTemplate() {
+	var tc command.CDPipelineTemplateCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&tc); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tc.Name = c.GetString(":name")
+
+	if errMsg := validation.ValidateCDPipelineTemplateRequest(tc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, http.StatusBadRequest)
+		return
+	}
+
+	if err := c.PipelineTemplateService.UpdateTemplate(tc); err != nil {
+		if _, ok := err.(*edperror.CDPipelineTemplateDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("cd pipeline template %v doesn't exist", tc.Name), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't update cd pipeline template", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *CDPipelineTemplateRestController) DeleteTemplate() {
+	name := c.GetString(":name")
+	if err := c.PipelineTemplateService.DeleteTemplate(name); err != nil {
+		if _, ok := err.(*edperror.CDPipelineTemplateDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("cd pipeline template %v doesn't exist", name), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't delete cd pipeline template", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
@@ -20,10 +20,14 @@ import (
 	"edp-admin-console/context"
 	"edp-admin-console/controllers/validation"
 	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/service"
 	cbs "edp-admin-console/service/codebasebranch"
 	ec "edp-admin-console/service/edp-component"
+	jiraservice "edp-admin-console/service/jira-server"
+	"edp-admin-console/service/label"
+	"edp-admin-console/service/perfboard"
 	"edp-admin-console/util"
 	"edp-admin-console/util/auth"
 	"edp-admin-console/util/consts"
@@ -43,10 +47,20 @@ type CodebaseController struct {
 	BranchService    cbs.CodebaseBranchService
 	GitServerService service.GitServerService
 	EDPComponent     ec.EDPComponentService
+	SlaveService     service.SlaveService
+	JobProvisioning  service.JobProvisioning
+	JiraServer       jiraservice.JiraServer
+	PerfService      perfboard.PerfBoard
+	LabelService     label.LabelService
+
+	CiTools         []string
+	PerfDataSources []string
 }
 
 const (
 	paramWaitingForBranch = "waitingforbranch"
+	archivePageType       = "archive"
+	tempSecretPageType    = "tempsecret"
 )
 
 func (c *CodebaseController) GetCodebaseOverviewPage() {
@@ -222,6 +236,135 @@ func (c *CodebaseController) Delete() {
 	c.Redirect(createCodebaseIsDeletedURL(cn, ct), 302)
 }
 
+func (c *CodebaseController) Archive() {
+	cn := c.GetString("name")
+	ct := c.GetString("codebase-type")
+	log.Debug("archive codebase method is invoked", zap.String("name", cn))
+	if err := c.CodebaseService.Archive(cn); err != nil {
+		if dberror.CodebaseIsUsed(err) {
+			log.Error("codebase is used by cd pipeline", zap.String("name", cn), zap.Error(err))
+			c.Redirect(createCodebaseOverviewURL(cn, ct, "codebaseIsNotArchived"), 302)
+			return
+		}
+		switch err.(type) {
+		case *edperror.CodebaseDoesNotExistError:
+			c.Abort("404")
+		case *edperror.NonValidArchiveError:
+			c.Abort("400")
+		default:
+			log.Error("archive process is failed", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+	log.Info("archive codebase method is finished", zap.String("name", cn))
+	c.Redirect(createCodebaseOverviewURL(cn, ct, "codebaseIsArchived"), 302)
+}
+
+func (c *CodebaseController) Restore() {
+	cn := c.GetString("name")
+	log.Debug("restore codebase method is invoked", zap.String("name", cn))
+	if err := c.CodebaseService.Restore(cn); err != nil {
+		switch err.(type) {
+		case *edperror.CodebaseDoesNotExistError:
+			c.Abort("404")
+		case *edperror.NonValidArchiveError:
+			c.Abort("400")
+		default:
+			log.Error("restore process is failed", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+	log.Info("restore codebase method is finished", zap.String("name", cn))
+	c.Redirect(fmt.Sprintf("%s/admin/edp/codebase/archive?codebase=%v#codebaseIsRestored", context.BasePath, cn), 302)
+}
+
+func (c *CodebaseController) GetArchivedCodebasesPage() {
+	codebases, err := c.CodebaseService.GetCodebasesByCriteria(query.CodebaseCriteria{Archived: true})
+	if err != nil {
+		log.Error("couldn't get archived codebases", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	retention, _ := beego.AppConfig.Int("archiveRetentionDays")
+	c.Data["Codebases"] = codebases
+	c.Data["RetentionDays"] = retention
+	c.Data["Type"] = archivePageType
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "archived_codebases.html"
+}
+
+func (c *CodebaseController) GetTempSecretsPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["success"] != "" {
+		c.Data["Success"] = flash.Data["success"]
+	}
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	secrets, err := c.CodebaseService.GetTempSecrets()
+	if err != nil {
+		log.Error("couldn't get temporary secrets", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["TempSecrets"] = secrets
+	c.Data["Type"] = tempSecretPageType
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "temp_secrets.html"
+}
+
+func (c *CodebaseController) DeleteTempSecret() {
+	flash := beego.NewFlash()
+	name := c.GetString("name")
+	if err := c.CodebaseService.DeleteTempSecret(name); err != nil {
+		if _, ok := err.(*edperror.TempSecretDoesNotExistError); !ok {
+			log.Error("couldn't delete temporary secret", zap.String("name", name), zap.Error(err))
+			c.Abort("500")
+			return
+		}
+		flash.Error(err.Error())
+	} else {
+		flash.Success(fmt.Sprintf("Temporary secret %v is deleted.", name))
+	}
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/codebase/temp-secret", context.BasePath), 302)
+}
+
+func (c *CodebaseController) SweepTempSecrets() {
+	flash := beego.NewFlash()
+	swept, err := c.CodebaseService.SweepTempSecrets()
+	if err != nil {
+		log.Error("couldn't sweep temporary secrets", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	flash.Success(fmt.Sprintf("%v temporary secret(s) are deleted.", len(swept)))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/codebase/temp-secret", context.BasePath), 302)
+}
+
+func createCodebaseOverviewURL(codebaseName, codebaseType, anchor string) string {
+	if codebaseType == consts.Autotest {
+		codebaseType = "autotest"
+	}
+	return fmt.Sprintf("%s/admin/edp/%v/overview?codebase=%v#%v", context.BasePath, codebaseType, codebaseName, anchor)
+}
+
 func createCodebaseIsUsedURL(codebaseName, codebaseType string) string {
 	if codebaseType == consts.Autotest {
 		codebaseType = "autotest"
@@ -253,6 +396,23 @@ func (c *CodebaseController) GetEditCodebasePage() {
 		return
 	}
 
+	if err := c.setEditOptions(); err != nil {
+		log.Error("couldn't get codebase edit options", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	if codebase.JiraServer != nil {
+		c.Data["CurrentJiraServer"] = *codebase.JiraServer
+	}
+	if codebase.GitServer != nil {
+		c.Data["CurrentGitServer"] = *codebase.GitServer
+		if err := c.addCurrentGitServer(*codebase.GitServer); err != nil {
+			log.Error("couldn't get current git server of codebase", zap.Error(err))
+			c.Abort("500")
+			return
+		}
+	}
 	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
 	c.Data["BasePath"] = context.BasePath
 	c.Data["Codebase"] = codebase
@@ -270,6 +430,25 @@ func (c *CodebaseController) Update() {
 		Name:               name,
 		CommitMessageRegex: c.GetString("commitMessagePattern"),
 		TicketNameRegex:    c.GetString("ticketNamePattern"),
+		Description:        util.GetStringP(c.GetString("description")),
+		JiraServer:         util.GetStringP(c.GetString("jiraServer")),
+		JenkinsSlave:       util.GetStringP(c.GetString("jenkinsSlave")),
+		JobProvisioning:    util.GetStringP(c.GetString("jobProvisioning")),
+		CiTool:             util.GetStringP(c.GetString("ciTool")),
+		DefaultBranch:      util.GetStringP(c.GetString("defaultBranch")),
+		Perf: &command.Perf{
+			Name:        c.GetString("perfServer"),
+			DataSources: c.GetStrings("dataSource"),
+		},
+	}
+	if gs := c.GetString("gitServer"); gs != "" {
+		cc.GitServer = &gs
+	}
+	if c.GetString("routeSite") != "" || c.GetString("routePath") != "" {
+		cc.Route = &command.Route{
+			Site: c.GetString("routeSite"),
+			Path: c.GetString("routePath"),
+		}
 	}
 
 	errMsg := validation.ValidateCodebaseUpdateRequestData(cc)
@@ -281,9 +460,32 @@ func (c *CodebaseController) Update() {
 		return
 	}
 
+	labels, err := label.ParseLabels(c.GetString("labels"))
+	if err != nil {
+		flash.Error(err.Error())
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%v/admin/edp/codebase/%v/update", context.BasePath, cc.Name), 302)
+		return
+	}
+
 	codebase, err := c.CodebaseService.Update(cc)
 	if err != nil {
-		log.Error("couldn't update codebase", zap.Error(err))
+		switch err.(type) {
+		case *edperror.CodebaseDoesNotExistError:
+			c.Abort("404")
+		case *edperror.ImmutableCodebaseFieldError, *edperror.NonValidCodebaseUpdateError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%v/admin/edp/codebase/%v/update", context.BasePath, cc.Name), 302)
+		default:
+			log.Error("couldn't update codebase", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+
+	if err := c.LabelService.UpdateCodebaseLabels(cc.Name, labels); err != nil {
+		log.Error("couldn't update codebase labels", zap.Error(err))
 		c.Abort("500")
 		return
 	}
@@ -292,6 +494,154 @@ func (c *CodebaseController) Update() {
 		context.BasePath, getType(codebase.Spec.Type)), 302)
 }
 
+func (c *CodebaseController) GetImportCodebasesPage() {
+	if err := c.setImportPageData(); err != nil {
+		log.Error("couldn't get codebase import options", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.Data["Import"] = command.BulkImportCodebaseCommand{}
+	c.TplName = "import_codebases.html"
+}
+
+func (c *CodebaseController) ImportCodebases() {
+	cmd := command.BulkImportCodebaseCommand{
+		GitServer:     c.GetString("gitServer"),
+		PathPrefix:    c.GetString("pathPrefix"),
+		Type:          c.GetString("type"),
+		Lang:          c.GetString("lang"),
+		BuildTool:     c.GetString("buildTool"),
+		DefaultBranch: c.GetString("defaultBranch"),
+		CiTool:        c.GetString("ciTool"),
+		Versioning: command.Versioning{
+			Type: c.GetString("versioningType"),
+		},
+		Csv: c.GetString("projects"),
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+	if f := c.GetString("framework"); f != "" {
+		cmd.Framework = &f
+	}
+	if sf := c.GetString("startVersioningFrom"); sf != "" {
+		cmd.Versioning.StartFrom = &sf
+	}
+	if s := c.GetString("jenkinsSlave"); s != "" {
+		cmd.JenkinsSlave = &s
+	}
+	if p := c.GetString("jobProvisioning"); p != "" {
+		cmd.JobProvisioning = &p
+	}
+	if js := c.GetString("jiraServer"); js != "" {
+		cmd.JiraServer = &js
+	}
+
+	if err := c.setImportPageData(); err != nil {
+		log.Error("couldn't get codebase import options", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.Data["Import"] = cmd
+	c.TplName = "import_codebases.html"
+
+	if errMsg := validation.ValidateBulkImportRequest(cmd); errMsg != nil {
+		log.Error("Bulk import request data is invalid", zap.String("err", errMsg.Message))
+		c.Data["ImportError"] = errMsg.Message
+		return
+	}
+
+	report, err := c.CodebaseService.BulkImport(cmd)
+	if err != nil {
+		if _, ok := err.(*edperror.NonValidBulkImportError); ok {
+			c.Data["ImportError"] = err.Error()
+			return
+		}
+		log.Error("couldn't import codebases", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.Data["ImportReport"] = report
+}
+
+func (c *CodebaseController) setImportPageData() error {
+	if err := c.setEditOptions(); err != nil {
+		return err
+	}
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["Type"] = query.App
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	return nil
+}
+
+func (c *CodebaseController) setEditOptions() error {
+	gs, err := c.GitServerService.GetServers(query.GitServerCriteria{Available: true})
+	if err != nil {
+		return err
+	}
+
+	s, err := c.SlaveService.GetAllSlaves()
+	if err != nil {
+		return err
+	}
+
+	p, err := c.JobProvisioning.GetAllJobProvisioners(query.JobProvisioningCriteria{Scope: util.GetStringP(scope)})
+	if err != nil {
+		return err
+	}
+
+	js, err := c.JiraServer.GetJiraServers()
+	if err != nil {
+		return err
+	}
+
+	ps, err := c.PerfService.GetPerfServers()
+	if err != nil {
+		return err
+	}
+
+	c.Data["GitServers"] = gs
+	c.Data["JenkinsSlaves"] = s
+	c.Data["JobProvisioners"] = p
+	c.Data["JiraServer"] = js
+	c.Data["PerfServer"] = ps
+	c.Data["CiTools"] = c.CiTools
+	c.Data["PerfDataSources"] = c.PerfDataSources
+	return nil
+}
+
+func (c *CodebaseController) addCurrentGitServer(name string) error {
+	gs := c.Data["GitServers"].([]*query.GitServer)
+	for _, g := range gs {
+		if g.Name == name {
+			return nil
+		}
+	}
+
+	g, err := c.GitServerService.GetGitServer(name)
+	if err != nil {
+		return err
+	}
+	if g != nil && g.Available {
+		c.Data["GitServers"] = append(gs, g)
+	}
+	return nil
+}
+
+func getLabelFilter(c *beego.Controller) map[string]string {
+	f := c.GetString("label")
+	if f == "" {
+		return nil
+	}
+	c.Data["LabelFilter"] = f
+
+	labels, err := label.ParseLabels(f)
+	if err != nil {
+		c.Data["LabelError"] = err.Error()
+		return nil
+	}
+	return labels
+}
+
 func getType(codebaseType string) string {
 	if codebaseType == "autotests" {
 		return "autotest"
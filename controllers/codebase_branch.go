@@ -4,6 +4,8 @@ import (
 	"edp-admin-console/context"
 	validation2 "edp-admin-console/controllers/validation"
 	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/service"
 	cbs "edp-admin-console/service/codebasebranch"
 	"edp-admin-console/util"
@@ -13,6 +15,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/astaxie/beego"
 	"github.com/astaxie/beego/validation"
@@ -66,6 +69,17 @@ func (c *BranchController) CreateCodebaseBranch() {
 
 	cb, err := c.BranchService.CreateCodebaseBranch(branchInfo, appName)
 	if err != nil {
+		switch err.(type) {
+		case *edperror.NonValidCommitError:
+			c.Redirect(fmt.Sprintf("%s/admin/edp/codebase/%s/overview?errorCommit=%s#commitNotFoundModal", context.BasePath,
+				appName, url.PathEscape(branchInfo.Commit)), 302)
+			return
+		case *edperror.UnverifiedCommitError:
+			c.Redirect(fmt.Sprintf("%s/admin/edp/codebase/%s/overview?errorCommit=%s#commitNotVerifiedModal", context.BasePath,
+				appName, url.PathEscape(branchInfo.Commit)), 302)
+			return
+		}
+		log.Error("couldn't create codebase branch", zap.Error(err))
 		c.Abort("500")
 		return
 	}
@@ -114,6 +128,33 @@ func validCodebaseBranchRequestData(requestData command.CreateCodebaseBranch) *v
 	return &validation2.ErrMsg{string(validation2.CreateErrorResponseBody(valid)), http.StatusBadRequest}
 }
 
+func (c *BranchController) CutReleases() {
+	rc := command.CutReleaseCommand{
+		Codebases: c.GetStrings("codebase"),
+		Bump:      c.GetString("bump"),
+		Username:  c.Ctx.Input.Session("username").(string),
+	}
+	ct := c.GetString("type")
+
+	if errMsg := validation2.ValidateCutReleaseRequest(rc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		c.Redirect(fmt.Sprintf("%s/admin/edp/%s/overview", context.BasePath, getType(ct)), 302)
+		return
+	}
+
+	var released, failed []string
+	for _, r := range c.BranchService.CutReleases(rc) {
+		if r.Status == dto.ReleaseCreated {
+			released = append(released, r.Codebase)
+			continue
+		}
+		failed = append(failed, r.Codebase)
+	}
+
+	c.Redirect(fmt.Sprintf("%s/admin/edp/%s/overview?released=%s&failed=%s#codebasesReleased", context.BasePath,
+		getType(ct), url.QueryEscape(strings.Join(released, ",")), url.QueryEscape(strings.Join(failed, ","))), 302)
+}
+
 func (c *BranchController) Delete() {
 	cn := c.GetString("codebase-name")
 	bn := c.GetString("name")
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	cbs "edp-admin-console/service/codebasebranch"
+	"encoding/json"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type CodebaseReleaseRestController struct {
+	beego.Controller
+	BranchService cbs.CodebaseBranchService
+}
+
+func (c *CodebaseReleaseRestController) Prepare() {
+	c.EnableXSRF = false
+}
+
+func (c *CodebaseReleaseRestController) CutRelease() {
+	var rc command.CutReleaseCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&rc); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rc.Codebases = []string{c.GetString(":codebaseName")}
+	rc.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateCutReleaseRequest(rc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+	log.Info("request data is received to cut release", zap.Strings("codebases", rc.Codebases))
+
+	r, err := c.BranchService.CutRelease(rc.Codebases[0], rc.Bump, rc.Username)
+	if err != nil {
+		switch err.(type) {
+		case *edperror.CodebaseDoesNotExistError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+		case *edperror.NonValidReleaseError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		default:
+			log.Error("couldn't cut release", zap.Error(err))
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Ctx.Output.SetStatus(http.StatusCreated)
+	c.Data["json"] = r
+	c.ServeJSON()
+}
+
+func (c *CodebaseReleaseRestController) CutReleases() {
+	var rc command.CutReleaseCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&rc); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rc.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateCutReleaseRequest(rc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+	log.Info("request data is received to cut releases", zap.Strings("codebases", rc.Codebases))
+
+	c.Data["json"] = c.BranchService.CutReleases(rc)
+	c.ServeJSON()
+}
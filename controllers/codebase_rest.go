@@ -22,6 +22,7 @@ import (
 	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/service"
+	"edp-admin-console/service/label"
 	dberror "edp-admin-console/util/error/db-errors"
 	"encoding/json"
 	"errors"
@@ -61,12 +62,25 @@ func (c *CodebaseRestController) GetCodebases() {
 
 func getFilterCriteria(this *CodebaseRestController) (*query.CodebaseCriteria, error) {
 	codebaseType := this.GetString("type")
-	if codebaseType == "" || validation.IsCodebaseTypeAcceptable(codebaseType) {
-		return &query.CodebaseCriteria{
-			Type: query.CodebaseTypes[codebaseType],
-		}, nil
+	if codebaseType != "" && !validation.IsCodebaseTypeAcceptable(codebaseType) {
+		return nil, errors.New("type is not valid")
 	}
-	return nil, errors.New("type is not valid")
+
+	labels, err := label.ParseLabels(this.GetStrings("label")...)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := this.GetBool("archived", false)
+	if err != nil {
+		return nil, errors.New("archived is not valid")
+	}
+
+	return &query.CodebaseCriteria{
+		Type:     query.CodebaseTypes[codebaseType],
+		Labels:   labels,
+		Archived: archived,
+	}, nil
 }
 
 func (c *CodebaseRestController) GetCodebase() {
@@ -103,6 +117,12 @@ func (c *CodebaseRestController) CreateCodebase() {
 		codebase.Name = path.Base(*codebase.GitUrlPath)
 	}
 
+	if hasRawCredentials(codebase) {
+		http.Error(c.Ctx.ResponseWriter, "login and password aren't accepted, please reference existing secret by secretName",
+			http.StatusBadRequest)
+		return
+	}
+
 	errMsg := validation.ValidCodebaseRequestData(codebase)
 	if errMsg != nil {
 		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
@@ -125,6 +145,71 @@ func (c *CodebaseRestController) CreateCodebase() {
 	c.Ctx.Output.Header("Location", location)
 }
 
+func (c *CodebaseRestController) UpdateCodebase() {
+	var cc command.UpdateCodebaseCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cc); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cc.Name = c.GetString(":codebaseName")
+
+	if errMsg := validation.ValidateCodebaseUpdateRequestData(cc); errMsg != nil {
+		log.Error("Codebase update request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+	log.Info("request data is received to update codebase", zap.String("name", cc.Name))
+
+	if _, err := c.CodebaseService.Update(cc); err != nil {
+		switch err.(type) {
+		case *edperror.CodebaseDoesNotExistError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+		case *edperror.ImmutableCodebaseFieldError, *edperror.NonValidCodebaseUpdateError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		default:
+			log.Error("couldn't update codebase", zap.Error(err))
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *CodebaseRestController) BulkImportCodebases() {
+	var cmd command.BulkImportCodebaseCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateBulkImportRequest(cmd); errMsg != nil {
+		log.Error("Bulk import request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+	log.Info("request data is received to import codebases", zap.String("git server", cmd.GitServer),
+		zap.Int("projects", len(cmd.Projects)))
+
+	report, err := c.CodebaseService.BulkImport(cmd)
+	if err != nil {
+		if _, ok := err.(*edperror.NonValidBulkImportError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Error("couldn't import codebases", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !report.Valid {
+		c.Ctx.Output.SetStatus(http.StatusBadRequest)
+	}
+	c.Data["json"] = report
+	c.ServeJSON()
+}
+
 func (c *CodebaseRestController) checkError(err error, name string, url *string) {
 	switch err.(type) {
 	case *edperror.CodebaseAlreadyExistsError:
@@ -133,6 +218,8 @@ func (c *CodebaseRestController) checkError(err error, name string, url *string)
 	case *edperror.CodebaseWithGitUrlPathAlreadyExistsError:
 		errMsg := fmt.Sprintf("Codebase %v with %v project path already exists.", name, *url)
 		http.Error(c.Ctx.ResponseWriter, errMsg, http.StatusBadRequest)
+	case *edperror.NonValidPerfServerError, *edperror.NonValidCredentialsSecretError, *edperror.NonValidGitServerError:
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
 	default:
 		log.Error("couldn't create codebase", zap.Error(err))
 		errMsg := fmt.Sprintf("Failed to create codebase: %v", err.Error())
@@ -140,6 +227,13 @@ func (c *CodebaseRestController) checkError(err error, name string, url *string)
 	}
 }
 
+func hasRawCredentials(codebase command.CreateCodebase) bool {
+	if codebase.Repository != nil && (codebase.Repository.Login != "" || codebase.Repository.Password != "") {
+		return true
+	}
+	return codebase.Vcs != nil && (codebase.Vcs.Login != "" || codebase.Vcs.Password != "")
+}
+
 func (c *CodebaseRestController) Delete() {
 	var cr command.DeleteCodebaseCommand
 	err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cr)
@@ -178,3 +272,75 @@ func (c *CodebaseRestController) Delete() {
 	c.Ctx.ResponseWriter.WriteHeader(200)
 	c.Ctx.Output.Header("Location", location)
 }
+
+func (c *CodebaseRestController) ArchiveCodebase() {
+	name := c.GetString(":codebaseName")
+	log.Debug("archive codebase method is invoked", zap.String("name", name))
+	if err := c.CodebaseService.Archive(name); err != nil {
+		if dberror.CodebaseIsUsed(err) {
+			cerr := err.(dberror.CodebaseIsUsedByCDPipeline)
+			http.Error(c.Ctx.ResponseWriter, cerr.Message, http.StatusConflict)
+			return
+		}
+		writeArchiveError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *CodebaseRestController) RestoreCodebase() {
+	name := c.GetString(":codebaseName")
+	log.Debug("restore codebase method is invoked", zap.String("name", name))
+	if err := c.CodebaseService.Restore(name); err != nil {
+		writeArchiveError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *CodebaseRestController) GetTempSecrets() {
+	secrets, err := c.CodebaseService.GetTempSecrets()
+	if err != nil {
+		log.Error("couldn't get temporary secrets", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = secrets
+	c.ServeJSON()
+}
+
+func (c *CodebaseRestController) DeleteTempSecret() {
+	if err := c.CodebaseService.DeleteTempSecret(c.GetString(":name")); err != nil {
+		if _, ok := err.(*edperror.TempSecretDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't delete temporary secret", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *CodebaseRestController) SweepTempSecrets() {
+	swept, err := c.CodebaseService.SweepTempSecrets()
+	if err != nil {
+		log.Error("couldn't sweep temporary secrets", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = swept
+	c.ServeJSON()
+}
+
+func writeArchiveError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case *edperror.CodebaseDoesNotExistError:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case *edperror.NonValidArchiveError:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		log.Error("couldn't change archive state of codebase", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
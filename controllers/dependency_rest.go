@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service/dependency"
+	"edp-admin-console/util/auth"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type DependencyRestController struct {
+	beego.Controller
+	DependencyService dependency.DependencyService
+}
+
+func (c *DependencyRestController) GetDependencyGraph() {
+	refresh, allowed := getDependencyRefresh(&c.Controller)
+	if !allowed {
+		http.Error(c.Ctx.ResponseWriter, "Forbidden.", http.StatusForbidden)
+		return
+	}
+	g, err := c.DependencyService.GetDependencyGraph(refresh)
+	if err != nil {
+		log.Error("couldn't build dependency graph", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Data["json"] = g
+	c.ServeJSON()
+}
+
+func (c *DependencyRestController) GetLibraryImpact() {
+	name := c.GetString(":libraryName")
+	refresh, allowed := getDependencyRefresh(&c.Controller)
+	if !allowed {
+		http.Error(c.Ctx.ResponseWriter, "Forbidden.", http.StatusForbidden)
+		return
+	}
+	i, err := c.DependencyService.GetLibraryImpact(name, refresh)
+	if err != nil {
+		if _, ok := err.(*edperror.CodebaseDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't get library impact", zap.String("library", name), zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Data["json"] = i
+	c.ServeJSON()
+}
+
+//getDependencyRefresh returns whether rescan of repositories is requested and whether the user is allowed to request it.
+//Rescan clones all repositories, so only administrators are allowed to request it
+func getDependencyRefresh(c *beego.Controller) (refresh bool, allowed bool) {
+	refresh, _ = c.GetBool("refresh")
+	if !refresh {
+		return false, true
+	}
+	roles, _ := c.GetSession("realm_roles").([]string)
+	return true, auth.IsAdmin(roles)
+}
@@ -37,21 +37,29 @@ const diagramPageType = "diagram"
 
 func (c *DiagramController) GetDiagramPage() {
 	log.Debug("start rendering delivery_dashboard_diagram.html page")
-	cJson, err := c.getCodebasesJson()
+	labels := getLabelFilter(&c.Controller)
+	codebases, err := c.CodebaseService.GetCodebasesByCriteria(query.CodebaseCriteria{Labels: labels})
 	if err != nil {
 		log.Error("couldn't get codebases from db", zap.Error(err))
 		c.Abort("500")
 		return
 	}
 
-	pJson, err := c.getPipelinesJson()
+	cJson, err := toJson(codebases)
+	if err != nil {
+		log.Error("couldn't convert codebases to json", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	pJson, err := c.getPipelinesJson(labels)
 	if err != nil {
 		log.Error("couldn't get pipelines from db", zap.Error(err))
 		c.Abort("500")
 		return
 	}
 
-	sJson, err := c.getCodebaseDokcerStreamsJson()
+	sJson, err := c.getCodebaseDokcerStreamsJson(labels, codebases)
 	if err != nil {
 		log.Error("couldn't get codebase docker streams from db", zap.Error(err))
 		c.Abort("500")
@@ -69,20 +77,16 @@ func (c *DiagramController) GetDiagramPage() {
 	c.TplName = "delivery_dashboard_diagram.html"
 }
 
-func (c *DiagramController) getCodebasesJson() (*string, error) {
-	codebases, err := c.CodebaseService.GetCodebasesByCriteria(query.CodebaseCriteria{})
-	if err != nil {
-		return nil, err
-	}
-	buf, err := json.Marshal(codebases)
+func toJson(v interface{}) (*string, error) {
+	buf, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
 	return util.GetStringP(string(buf)), nil
 }
 
-func (c *DiagramController) getPipelinesJson() (*string, error) {
-	pipelines, err := c.PipelineService.GetAllPipelines(query.CDPipelineCriteria{})
+func (c *DiagramController) getPipelinesJson(labels map[string]string) (*string, error) {
+	pipelines, err := c.PipelineService.GetAllPipelines(query.CDPipelineCriteria{Labels: labels})
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +97,11 @@ func (c *DiagramController) getPipelinesJson() (*string, error) {
 	return util.GetStringP(string(buf)), nil
 }
 
-func (c *DiagramController) getCodebaseDokcerStreamsJson() (*string, error) {
+func (c *DiagramController) getCodebaseDokcerStreamsJson(labels map[string]string, codebases []*query.Codebase) (*string, error) {
+	if labels != nil {
+		return toJson(getCodebaseDockerStreamNames(codebases))
+	}
+
 	streams, err := c.PipelineService.GetAllCodebaseDockerStreams()
 	if err != nil {
 		return nil, err
@@ -104,3 +112,15 @@ func (c *DiagramController) getCodebaseDokcerStreamsJson() (*string, error) {
 	}
 	return util.GetStringP(string(buf)), nil
 }
+
+func getCodebaseDockerStreamNames(codebases []*query.Codebase) []string {
+	var names []string
+	for _, c := range codebases {
+		for _, b := range c.CodebaseBranch {
+			for _, s := range b.CodebaseDockerStream {
+				names = append(names, s.OcImageStreamName)
+			}
+		}
+	}
+	return names
+}
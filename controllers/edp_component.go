@@ -0,0 +1,253 @@
+package controllers
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	ec "edp-admin-console/service/edp-component"
+	"edp-admin-console/util/auth"
+	"encoding/base64"
+	"fmt"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+)
+
+type EDPComponentController struct {
+	beego.Controller
+	EDPComponent ec.EDPComponentService
+}
+
+const edpComponentPageType = "edpcomponent"
+
+func (c *EDPComponentController) GetEDPComponentsPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["success"] != "" {
+		c.Data["Success"] = flash.Data["success"]
+	}
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	components, err := c.EDPComponent.GetEDPComponentsDetails()
+	if err != nil {
+		log.Error("couldn't get edp components", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["EDPComponents"] = components
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
+	c.Data["Type"] = edpComponentPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "edp_components_admin.html"
+}
+
+func (c *EDPComponentController) GetCreateEDPComponentPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	c.Data["EDPComponent"] = command.EDPComponentCommand{Visible: true}
+	c.setEDPComponentFormData()
+}
+
+func (c *EDPComponentController) CreateEDPComponent() {
+	flash := beego.NewFlash()
+	cmd, err := c.readEDPComponentCommand(c.GetString("type"))
+	if err != nil {
+		log.Error("couldn't read edp component icon", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	log.Debug("start executing CreateEDPComponent method", zap.String("type", cmd.Type))
+
+	if errMsg := validation.ValidateEDPComponentRequest(cmd); errMsg != nil {
+		log.Error("edp component request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/edp-component/create", context.BasePath), 302)
+		return
+	}
+
+	if err := c.EDPComponent.CreateEDPComponent(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.EDPComponentExistsError, *edperror.NonValidEDPComponentError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/edp-component/create", context.BasePath), 302)
+		default:
+			log.Error("couldn't create edp component", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("EDP component %v is created.", cmd.Type))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/edp-component/overview", context.BasePath), 302)
+}
+
+func (c *EDPComponentController) GetEditEDPComponentPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	componentType := c.GetString(":type")
+	comp, err := c.EDPComponent.GetEDPComponentDetails(componentType)
+	if err != nil {
+		if _, ok := err.(*edperror.EDPComponentDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't get edp component", zap.String("type", componentType), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["EDPComponent"] = command.EDPComponentCommand{
+		Type:    comp.Type,
+		Url:     comp.Url,
+		Icon:    comp.Icon,
+		Visible: comp.Visible,
+		Ordinal: comp.Ordinal,
+	}
+	c.Data["Edit"] = true
+	c.setEDPComponentFormData()
+}
+
+func (c *EDPComponentController) UpdateEDPComponent() {
+	flash := beego.NewFlash()
+	cmd, err := c.readEDPComponentCommand(c.GetString(":type"))
+	if err != nil {
+		log.Error("couldn't read edp component icon", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	log.Debug("start executing UpdateEDPComponent method", zap.String("type", cmd.Type))
+
+	if errMsg := validation.ValidateEDPComponentRequest(cmd); errMsg != nil {
+		log.Error("edp component request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/edp-component/%v/update", context.BasePath, cmd.Type), 302)
+		return
+	}
+
+	if err := c.EDPComponent.UpdateEDPComponent(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.EDPComponentDoesNotExistError:
+			c.Abort("404")
+		case *edperror.NonValidEDPComponentError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/edp-component/%v/update", context.BasePath, cmd.Type), 302)
+		default:
+			log.Error("couldn't update edp component", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("EDP component %v is updated.", cmd.Type))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/edp-component/overview", context.BasePath), 302)
+}
+
+func (c *EDPComponentController) DeleteEDPComponent() {
+	flash := beego.NewFlash()
+	componentType := c.GetString("type")
+	if err := c.EDPComponent.DeleteEDPComponent(componentType); err != nil {
+		switch err.(type) {
+		case *edperror.EDPComponentDoesNotExistError:
+			c.Abort("404")
+			return
+		case *edperror.NonValidEDPComponentError:
+			flash.Error(err.Error())
+		default:
+			log.Error("couldn't delete edp component", zap.String("type", componentType), zap.Error(err))
+			c.Abort("500")
+			return
+		}
+	} else {
+		flash.Success(fmt.Sprintf("EDP component %v is deleted.", componentType))
+	}
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/edp-component/overview", context.BasePath), 302)
+}
+
+func (c *EDPComponentController) ShowEDPComponent() {
+	c.setVisible(true)
+}
+
+func (c *EDPComponentController) HideEDPComponent() {
+	c.setVisible(false)
+}
+
+func (c *EDPComponentController) setVisible(visible bool) {
+	componentType := c.GetString(":type")
+	if err := c.EDPComponent.SetVisible(componentType, visible); err != nil {
+		if _, ok := err.(*edperror.EDPComponentDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't change edp component visibility", zap.String("type", componentType), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.Redirect(fmt.Sprintf("%s/admin/edp/edp-component/overview", context.BasePath), 302)
+}
+
+func (c *EDPComponentController) readEDPComponentCommand(componentType string) (command.EDPComponentCommand, error) {
+	ordinal, _ := c.GetInt("ordinal")
+	visible, _ := c.GetBool("visible")
+	username, _ := c.Ctx.Input.Session("username").(string)
+	icon, err := c.readIcon()
+	if err != nil {
+		return command.EDPComponentCommand{}, err
+	}
+	return command.EDPComponentCommand{
+		Type:     componentType,
+		Url:      c.GetString("url"),
+		Icon:     icon,
+		Visible:  visible,
+		Ordinal:  ordinal,
+		Username: username,
+	}, nil
+}
+
+func (c *EDPComponentController) readIcon() (string, error) {
+	f, _, err := c.GetFile("icon")
+	if err != nil {
+		if err == http.ErrMissingFile {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func (c *EDPComponentController) setEDPComponentFormData() {
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["Type"] = edpComponentPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "edp_component_form.html"
+}
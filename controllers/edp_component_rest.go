@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	ec "edp-admin-console/service/edp-component"
+	"encoding/json"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type EDPComponentRestController struct {
+	beego.Controller
+	EDPComponent ec.EDPComponentService
+}
+
+func (c *EDPComponentRestController) GetEDPComponents() {
+	components, err := c.EDPComponent.GetEDPComponentsDetails()
+	if err != nil {
+		log.Error("couldn't get edp components", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = components
+	c.ServeJSON()
+}
+
+func (c *EDPComponentRestController) GetEDPComponent() {
+	comp, err := c.EDPComponent.GetEDPComponentDetails(c.GetString(":type"))
+	if err != nil {
+		writeEDPComponentError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = comp
+	c.ServeJSON()
+}
+
+func (c *EDPComponentRestController) CreateEDPComponent() {
+	var cmd command.EDPComponentCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateEDPComponentRequest(cmd); errMsg != nil {
+		log.Error("edp component request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.EDPComponent.CreateEDPComponent(cmd); err != nil {
+		writeEDPComponentError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *EDPComponentRestController) UpdateEDPComponent() {
+	var cmd command.EDPComponentCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Type = c.GetString(":type")
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateEDPComponentRequest(cmd); errMsg != nil {
+		log.Error("edp component request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.EDPComponent.UpdateEDPComponent(cmd); err != nil {
+		writeEDPComponentError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *EDPComponentRestController) DeleteEDPComponent() {
+	if err := c.EDPComponent.DeleteEDPComponent(c.GetString(":type")); err != nil {
+		writeEDPComponentError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func writeEDPComponentError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case *edperror.EDPComponentDoesNotExistError:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case *edperror.EDPComponentExistsError:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case *edperror.NonValidEDPComponentError:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Error("edp component request is failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
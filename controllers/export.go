@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"bytes"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/service/export"
+	"fmt"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+type ExportController struct {
+	beego.Controller
+	ExportService export.ExportService
+}
+
+var exportContentTypes = map[string]string{
+	export.CsvFormat:  "text/csv; charset=utf-8",
+	export.XlsxFormat: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+func (c *ExportController) ExportApplications() {
+	c.exportCodebases(query.App, "applications")
+}
+
+func (c *ExportController) ExportLibraries() {
+	c.exportCodebases(query.Library, "libraries")
+}
+
+func (c *ExportController) ExportAutotests() {
+	c.exportCodebases(query.Autotests, "autotests")
+}
+
+func (c *ExportController) ExportCDPipelines() {
+	labels := getLabelFilter(&c.Controller)
+	if e, ok := c.Data["LabelError"]; ok {
+		http.Error(c.Ctx.ResponseWriter, e.(string), http.StatusBadRequest)
+		return
+	}
+
+	format := c.GetString("format", export.CsvFormat)
+	var buf bytes.Buffer
+	err := c.ExportService.ExportCDPipelines(query.CDPipelineCriteria{Labels: labels}, c.getColumns(), format, &buf)
+	c.writeExport(err, "cd-pipelines", format, buf.Bytes())
+}
+
+func (c *ExportController) exportCodebases(codebaseType query.CodebaseType, fileName string) {
+	labels := getLabelFilter(&c.Controller)
+	if e, ok := c.Data["LabelError"]; ok {
+		http.Error(c.Ctx.ResponseWriter, e.(string), http.StatusBadRequest)
+		return
+	}
+
+	format := c.GetString("format", export.CsvFormat)
+	var buf bytes.Buffer
+	err := c.ExportService.ExportCodebases(query.CodebaseCriteria{
+		Type:   codebaseType,
+		Labels: labels,
+	}, c.getColumns(), format, &buf)
+	c.writeExport(err, fileName, format, buf.Bytes())
+}
+
+func (c *ExportController) writeExport(err error, fileName, format string, content []byte) {
+	if err != nil {
+		if _, ok := err.(*edperror.NonValidExportParamsError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Error("couldn't export data", zap.String("file", fileName), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.Ctx.Output.Header("Content-Type", exportContentTypes[format])
+	c.Ctx.Output.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%v.%v", fileName, format))
+	_ = c.Ctx.Output.Body(content)
+}
+
+func (c *ExportController) getColumns() []string {
+	var columns []string
+	for _, v := range c.GetStrings("columns") {
+		for _, col := range strings.Split(v, ",") {
+			if col = strings.TrimSpace(col); col != "" {
+				columns = append(columns, col)
+			}
+		}
+	}
+	return columns
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	fws "edp-admin-console/service/freeze-window"
+	"encoding/json"
+	"fmt"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type FreezeWindowRestController struct {
+	beego.Controller
+	FreezeWindowService fws.FreezeWindowService
+}
+
+func (c *FreezeWindowRestController) Prepare() {
+	c.EnableXSRF = false
+}
+
+func (c *FreezeWindowRestController) GetFreezeStatus() {
+	pipelineName := c.GetString(":pipelineName")
+	stageName := c.GetString(":stageName")
+
+	at := time.Now()
+	if s := c.GetString("at"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			http.Error(c.Ctx.ResponseWriter, "at parameter should be in RFC3339 format", http.StatusBadRequest)
+			return
+		}
+		at = t
+	}
+
+	status, err := c.FreezeWindowService.GetFreezeStatus(pipelineName, stageName, at)
+	if err != nil {
+		if _, ok := err.(*edperror.CDStageDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't get freeze status of stage", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Data["json"] = status
+	c.ServeJSON()
+}
+
+func (c *FreezeWindowRestController) GetFreezeWindows() {
+	pipelineName := c.GetString(":pipelineName")
+	stageName := c.GetString(":stageName")
+
+	windows, err := c.FreezeWindowService.GetFreezeWindows(pipelineName, stageName)
+	if err != nil {
+		if _, ok := err.(*edperror.CDStageDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't get freeze windows of stage", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Data["json"] = windows
+	c.ServeJSON()
+}
+
+func (c *FreezeWindowRestController) CreateFreezeWindow() {
+	var wc command.FreezeWindowCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&wc); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	wc.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateFreezeWindowRequest(wc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	pipelineName := c.GetString(":pipelineName")
+	stageName := c.GetString(":stageName")
+	log.Info("request data is received to create freeze window",
+		zap.String("pipeline", pipelineName),
+		zap.String("stage", stageName),
+		zap.Any("window", wc))
+
+	w, err := c.FreezeWindowService.CreateFreezeWindow(pipelineName, stageName, wc)
+	if err != nil {
+		switch err.(type) {
+		case *edperror.CDStageDoesNotExistError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		case *edperror.NonValidFreezeWindowError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Error("couldn't create freeze window", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Ctx.Output.Header("Location", fmt.Sprintf("%s/%v", c.Ctx.Input.URL(), w.Id))
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *FreezeWindowRestController) DeleteFreezeWindow() {
+	pipelineName := c.GetString(":pipelineName")
+	stageName := c.GetString(":stageName")
+	id, err := c.GetInt(":id")
+	if err != nil {
+		http.Error(c.Ctx.ResponseWriter, "freeze window id should be a number", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.FreezeWindowService.DeleteFreezeWindow(pipelineName, stageName, id); err != nil {
+		switch err.(type) {
+		case *edperror.CDStageDoesNotExistError, *edperror.FreezeWindowDoesNotExistError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't delete freeze window", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
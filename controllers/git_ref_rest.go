@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service/gitref"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type GitRefRestController struct {
+	beego.Controller
+	GitRefService gitref.GitRefService
+}
+
+func (c *GitRefRestController) GetCodebaseRefs() {
+	name := c.GetString(":codebaseName")
+	refs, err := c.GitRefService.GetCodebaseRefs(name)
+	if err != nil {
+		writeGitRefError(c.Ctx.ResponseWriter, name, err)
+		return
+	}
+
+	c.Data["json"] = refs
+	c.ServeJSON()
+}
+
+func (c *GitRefRestController) GetCodebaseCommits() {
+	name := c.GetString(":codebaseName")
+	commits, err := c.GitRefService.GetCodebaseCommits(name, c.GetString("branch"))
+	if err != nil {
+		writeGitRefError(c.Ctx.ResponseWriter, name, err)
+		return
+	}
+
+	c.Data["json"] = commits
+	c.ServeJSON()
+}
+
+func writeGitRefError(w http.ResponseWriter, name string, err error) {
+	if _, ok := err.(*edperror.CodebaseDoesNotExistError); ok {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	log.Error("couldn't read codebase repository", zap.String("codebase", name), zap.Error(err))
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service"
+	"edp-admin-console/util/auth"
+	"fmt"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"html/template"
+)
+
+type GitServerController struct {
+	beego.Controller
+	GitServerService service.GitServerService
+}
+
+const gitServerPageType = "gitserver"
+
+func (c *GitServerController) GetGitServersPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["success"] != "" {
+		c.Data["Success"] = flash.Data["success"]
+	}
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	gitServers, err := c.GitServerService.GetAllServers()
+	if err != nil {
+		log.Error("couldn't get git servers", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["GitServers"] = gitServers
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
+	c.Data["Type"] = gitServerPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "git_servers.html"
+}
+
+func (c *GitServerController) GetCreateGitServerPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	c.Data["GitServer"] = command.GitServerCommand{SshPort: 22, HttpsPort: 443}
+	c.setGitServerFormData()
+}
+
+func (c *GitServerController) CreateGitServer() {
+	flash := beego.NewFlash()
+	cmd := c.readGitServerCommand(c.GetString("name"))
+	log.Debug("start executing CreateGitServer method", zap.String("name", cmd.Name))
+
+	if errMsg := validation.ValidateGitServerRequest(cmd); errMsg != nil {
+		log.Error("git server request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/git-server/create", context.BasePath), 302)
+		return
+	}
+
+	if err := c.GitServerService.CreateGitServer(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.GitServerExistsError, *edperror.NonValidGitServerError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/git-server/create", context.BasePath), 302)
+		default:
+			log.Error("couldn't create git server", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("Git server %v is created. It will be available after the operator processes it.", cmd.Name))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/git-server/overview", context.BasePath), 302)
+}
+
+func (c *GitServerController) GetEditGitServerPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	name := c.GetString(":name")
+	gs, err := c.GitServerService.GetGitServerDetails(name)
+	if err != nil {
+		if _, ok := err.(*edperror.GitServerDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't get git server", zap.String("name", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["GitServer"] = command.GitServerCommand{
+		Name:      gs.Name,
+		Hostname:  gs.Hostname,
+		SshPort:   gs.SshPort,
+		HttpsPort: gs.HttpsPort,
+		User:      gs.User,
+	}
+	c.Data["Edit"] = true
+	c.setGitServerFormData()
+}
+
+func (c *GitServerController) UpdateGitServer() {
+	flash := beego.NewFlash()
+	cmd := c.readGitServerCommand(c.GetString(":name"))
+	log.Debug("start executing UpdateGitServer method", zap.String("name", cmd.Name))
+
+	if errMsg := validation.ValidateGitServerRequest(cmd); errMsg != nil {
+		log.Error("git server request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/git-server/%v/update", context.BasePath, cmd.Name), 302)
+		return
+	}
+
+	if err := c.GitServerService.UpdateGitServer(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.GitServerDoesNotExistError:
+			c.Abort("404")
+		case *edperror.NonValidGitServerError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/git-server/%v/update", context.BasePath, cmd.Name), 302)
+		default:
+			log.Error("couldn't update git server", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("Git server %v is updated.", cmd.Name))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/git-server/overview", context.BasePath), 302)
+}
+
+func (c *GitServerController) TestConnection() {
+	flash := beego.NewFlash()
+	name := c.GetString(":name")
+	res, err := c.GitServerService.TestConnection(name)
+	if err != nil {
+		if _, ok := err.(*edperror.GitServerDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't test git server connection", zap.String("name", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	msg := fmt.Sprintf("Git server %v. SSH: %v. HTTPS: %v.", name, res.Ssh.Message, res.Https.Message)
+	if res.Ssh.Success && res.Https.Success {
+		flash.Success(msg)
+	} else {
+		flash.Error(msg)
+	}
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/git-server/overview", context.BasePath), 302)
+}
+
+func (c *GitServerController) DisableGitServer() {
+	c.setDisabled(true)
+}
+
+func (c *GitServerController) EnableGitServer() {
+	c.setDisabled(false)
+}
+
+func (c *GitServerController) setDisabled(disabled bool) {
+	name := c.GetString(":name")
+	if err := c.GitServerService.SetDisabled(name, disabled); err != nil {
+		if _, ok := err.(*edperror.GitServerDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't change git server state", zap.String("name", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.Redirect(fmt.Sprintf("%s/admin/edp/git-server/overview", context.BasePath), 302)
+}
+
+func (c *GitServerController) readGitServerCommand(name string) command.GitServerCommand {
+	sshPort, _ := c.GetInt32("sshPort")
+	httpsPort, _ := c.GetInt32("httpsPort")
+	username, _ := c.Ctx.Input.Session("username").(string)
+	return command.GitServerCommand{
+		Name:       name,
+		Hostname:   c.GetString("hostname"),
+		SshPort:    sshPort,
+		HttpsPort:  httpsPort,
+		User:       c.GetString("user"),
+		SshKey:     c.GetString("sshKey"),
+		KnownHosts: c.GetString("knownHosts"),
+		Username:   username,
+	}
+}
+
+func (c *GitServerController) setGitServerFormData() {
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["Type"] = gitServerPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "git_server_form.html"
+}
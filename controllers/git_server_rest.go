@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service"
+	"encoding/json"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type GitServerRestController struct {
+	beego.Controller
+	GitServerService service.GitServerService
+}
+
+func (c *GitServerRestController) GetGitServers() {
+	gitServers, err := c.GitServerService.GetAllServers()
+	if err != nil {
+		log.Error("couldn't get git servers", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = gitServers
+	c.ServeJSON()
+}
+
+func (c *GitServerRestController) GetGitServer() {
+	gs, err := c.GitServerService.GetGitServerDetails(c.GetString(":name"))
+	if err != nil {
+		writeGitServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = gs
+	c.ServeJSON()
+}
+
+func (c *GitServerRestController) CreateGitServer() {
+	var cmd command.GitServerCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateGitServerRequest(cmd); errMsg != nil {
+		log.Error("git server request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.GitServerService.CreateGitServer(cmd); err != nil {
+		writeGitServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *GitServerRestController) UpdateGitServer() {
+	var cmd command.GitServerCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Name = c.GetString(":name")
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateGitServerRequest(cmd); errMsg != nil {
+		log.Error("git server request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.GitServerService.UpdateGitServer(cmd); err != nil {
+		writeGitServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *GitServerRestController) TestConnection() {
+	res, err := c.GitServerService.TestConnection(c.GetString(":name"))
+	if err != nil {
+		writeGitServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = res
+	c.ServeJSON()
+}
+
+func (c *GitServerRestController) DisableGitServer() {
+	if err := c.GitServerService.SetDisabled(c.GetString(":name"), true); err != nil {
+		writeGitServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *GitServerRestController) EnableGitServer() {
+	if err := c.GitServerService.SetDisabled(c.GetString(":name"), false); err != nil {
+		writeGitServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func writeGitServerError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case *edperror.GitServerDoesNotExistError:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case *edperror.GitServerExistsError:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case *edperror.NonValidGitServerError:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Error("git server request is failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service/graph"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type GraphRestController struct {
+	beego.Controller
+	GraphService graph.GraphService
+}
+
+func (c *GraphRestController) GetGraph() {
+	scope := dto.GraphScope{
+		Pipeline: c.GetString("pipeline"),
+		Codebase: c.GetString("codebase"),
+	}
+	if scope.Pipeline != "" && scope.Codebase != "" {
+		http.Error(c.Ctx.ResponseWriter, "graph can be scoped either by pipeline or by codebase", http.StatusBadRequest)
+		return
+	}
+
+	format := c.GetString("format", graph.JsonFormat)
+	if format != graph.JsonFormat && format != graph.DotFormat && format != graph.MermaidFormat {
+		http.Error(c.Ctx.ResponseWriter, "format should be one of json, dot, mermaid", http.StatusBadRequest)
+		return
+	}
+
+	g, err := c.GraphService.GetGraph(scope)
+	if err != nil {
+		if _, ok := err.(*edperror.GraphScopeNotFoundError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't build graph", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch format {
+	case graph.DotFormat:
+		c.Ctx.Output.Header("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		_ = c.Ctx.Output.Body([]byte(graph.ToDot(g)))
+	case graph.MermaidFormat:
+		c.Ctx.Output.Header("Content-Type", "text/plain; charset=utf-8")
+		_ = c.Ctx.Output.Body([]byte(graph.ToMermaid(g)))
+	default:
+		c.Data["json"] = g
+		c.ServeJSON()
+	}
+}
@@ -0,0 +1,253 @@
+package controllers
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service"
+	"edp-admin-console/util/auth"
+	dberror "edp-admin-console/util/error/db-errors"
+	"fmt"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"html/template"
+)
+
+type JenkinsController struct {
+	beego.Controller
+	SlaveService    service.SlaveService
+	JobProvisioning service.JobProvisioning
+}
+
+const jenkinsPageType = "jenkins"
+
+func (c *JenkinsController) GetJenkinsPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["success"] != "" {
+		c.Data["Success"] = flash.Data["success"]
+	}
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	slaves, err := c.SlaveService.GetSlavesUsage()
+	if err != nil {
+		log.Error("couldn't get jenkins slaves", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	provisioners, err := c.JobProvisioning.GetJobProvisionersUsage()
+	if err != nil {
+		log.Error("couldn't get job provisioners", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["Slaves"] = slaves
+	c.Data["JobProvisioners"] = provisioners
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
+	c.Data["Type"] = jenkinsPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "jenkins.html"
+}
+
+func (c *JenkinsController) CreateSlave() {
+	cmd := command.JenkinsSlaveCommand{
+		Name:     c.GetString("name"),
+		Template: c.GetString("template"),
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJenkinsSlaveRequest(cmd); errMsg != nil {
+		log.Error("jenkins slave request data is invalid", zap.String("err", errMsg.Message))
+		c.redirectToJenkinsPage("", errMsg.Message)
+		return
+	}
+
+	if err := c.SlaveService.CreateSlave(cmd); err != nil {
+		if _, ok := err.(*edperror.JenkinsResourceExistsError); ok {
+			c.redirectToJenkinsPage("", err.Error())
+			return
+		}
+		log.Error("couldn't create jenkins slave", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.redirectToJenkinsPage(fmt.Sprintf("Jenkins slave %v is created.", cmd.Name), "")
+}
+
+func (c *JenkinsController) GetEditSlavePage() {
+	name := c.GetString(":name")
+	sl, err := c.SlaveService.GetSlave(name)
+	if err != nil {
+		if _, ok := err.(*edperror.JenkinsResourceDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't get jenkins slave", zap.String("name", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["Kind"] = "slave"
+	c.Data["Name"] = sl.Name
+	c.Data["Content"] = sl.Template
+	c.Data["Action"] = fmt.Sprintf("%s/admin/edp/jenkins/slave/%v/update", context.BasePath, sl.Name)
+	c.setJenkinsFormData()
+}
+
+func (c *JenkinsController) UpdateSlave() {
+	cmd := command.JenkinsSlaveCommand{
+		Name:     c.GetString(":name"),
+		Template: c.GetString("content"),
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJenkinsSlaveRequest(cmd); errMsg != nil {
+		log.Error("jenkins slave request data is invalid", zap.String("err", errMsg.Message))
+		c.redirectToJenkinsPage("", errMsg.Message)
+		return
+	}
+
+	if err := c.SlaveService.UpdateSlave(cmd); err != nil {
+		if _, ok := err.(*edperror.JenkinsResourceDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't update jenkins slave", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.redirectToJenkinsPage(fmt.Sprintf("Jenkins slave %v is updated.", cmd.Name), "")
+}
+
+func (c *JenkinsController) DeleteSlave() {
+	name := c.GetString("name")
+	if err := c.SlaveService.DeleteSlave(name); err != nil {
+		c.handleJenkinsDeleteError(err)
+		return
+	}
+	c.redirectToJenkinsPage(fmt.Sprintf("Jenkins slave %v is deleted.", name), "")
+}
+
+func (c *JenkinsController) CreateJobProvisioner() {
+	cmd := command.JobProvisionerCommand{
+		Name:   c.GetString("name"),
+		Scope:  c.GetString("scope"),
+		Script: c.GetString("script"),
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJobProvisionerRequest(cmd); errMsg != nil {
+		log.Error("job provisioner request data is invalid", zap.String("err", errMsg.Message))
+		c.redirectToJenkinsPage("", errMsg.Message)
+		return
+	}
+
+	if err := c.JobProvisioning.CreateJobProvisioner(cmd); err != nil {
+		if _, ok := err.(*edperror.JenkinsResourceExistsError); ok {
+			c.redirectToJenkinsPage("", err.Error())
+			return
+		}
+		log.Error("couldn't create job provisioner", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.redirectToJenkinsPage(fmt.Sprintf("Job provisioner %v is created.", cmd.Name), "")
+}
+
+func (c *JenkinsController) GetEditJobProvisionerPage() {
+	name := c.GetString(":name")
+	scope := c.GetString(":scope")
+	p, err := c.JobProvisioning.GetJobProvisioner(name, scope)
+	if err != nil {
+		if _, ok := err.(*edperror.JenkinsResourceDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't get job provisioner", zap.String("name", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["Kind"] = "job provisioner"
+	c.Data["Name"] = fmt.Sprintf("%v (%v)", p.Name, p.Scope)
+	c.Data["Content"] = p.Script
+	c.Data["Action"] = fmt.Sprintf("%s/admin/edp/jenkins/job-provisioner/%v/%v/update", context.BasePath, p.Scope, p.Name)
+	c.setJenkinsFormData()
+}
+
+func (c *JenkinsController) UpdateJobProvisioner() {
+	cmd := command.JobProvisionerCommand{
+		Name:   c.GetString(":name"),
+		Scope:  c.GetString(":scope"),
+		Script: c.GetString("content"),
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJobProvisionerRequest(cmd); errMsg != nil {
+		log.Error("job provisioner request data is invalid", zap.String("err", errMsg.Message))
+		c.redirectToJenkinsPage("", errMsg.Message)
+		return
+	}
+
+	if err := c.JobProvisioning.UpdateJobProvisioner(cmd); err != nil {
+		if _, ok := err.(*edperror.JenkinsResourceDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't update job provisioner", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+	c.redirectToJenkinsPage(fmt.Sprintf("Job provisioner %v is updated.", cmd.Name), "")
+}
+
+func (c *JenkinsController) DeleteJobProvisioner() {
+	name := c.GetString("name")
+	if err := c.JobProvisioning.DeleteJobProvisioner(name, c.GetString("scope")); err != nil {
+		c.handleJenkinsDeleteError(err)
+		return
+	}
+	c.redirectToJenkinsPage(fmt.Sprintf("Job provisioner %v is deleted.", name), "")
+}
+
+func (c *JenkinsController) handleJenkinsDeleteError(err error) {
+	if dberror.JenkinsResourceIsUsed(err) {
+		c.redirectToJenkinsPage("", err.(dberror.JenkinsResourceIsUsedByCodebase).Message)
+		return
+	}
+	if _, ok := err.(*edperror.JenkinsResourceDoesNotExistError); ok {
+		c.Abort("404")
+		return
+	}
+	log.Error("couldn't delete jenkins resource", zap.Error(err))
+	c.Abort("500")
+}
+
+func (c *JenkinsController) redirectToJenkinsPage(success, failure string) {
+	flash := beego.NewFlash()
+	if success != "" {
+		flash.Success(success)
+	}
+	if failure != "" {
+		flash.Error(failure)
+	}
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/jenkins/overview", context.BasePath), 302)
+}
+
+func (c *JenkinsController) setJenkinsFormData() {
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["Type"] = jenkinsPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "jenkins_form.html"
+}
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service"
+	dberror "edp-admin-console/util/error/db-errors"
+	"encoding/json"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type JenkinsRestController struct {
+	beego.Controller
+	SlaveService    service.SlaveService
+	JobProvisioning service.JobProvisioning
+}
+
+func (c *JenkinsRestController) GetSlaves() {
+	slaves, err := c.SlaveService.GetSlavesUsage()
+	if err != nil {
+		log.Error("couldn't get jenkins slaves", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = slaves
+	c.ServeJSON()
+}
+
+func (c *JenkinsRestController) GetSlave() {
+	sl, err := c.SlaveService.GetSlave(c.GetString(":name"))
+	if err != nil {
+		writeJenkinsError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = sl
+	c.ServeJSON()
+}
+
+func (c *JenkinsRestController) CreateSlave() {
+	var cmd command.JenkinsSlaveCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJenkinsSlaveRequest(cmd); errMsg != nil {
+		log.Error("jenkins slave request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.SlaveService.CreateSlave(cmd); err != nil {
+		writeJenkinsError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *JenkinsRestController) UpdateSlave() {
+	var cmd command.JenkinsSlaveCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Name = c.GetString(":name")
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJenkinsSlaveRequest(cmd); errMsg != nil {
+		log.Error("jenkins slave request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.SlaveService.UpdateSlave(cmd); err != nil {
+		writeJenkinsError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *JenkinsRestController) DeleteSlave() {
+	if err := c.SlaveService.DeleteSlave(c.GetString(":name")); err != nil {
+		writeJenkinsError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *JenkinsRestController) GetJobProvisioners() {
+	provisioners, err := c.JobProvisioning.GetJobProvisionersUsage()
+	if err != nil {
+		log.Error("couldn't get job provisioners", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = provisioners
+	c.ServeJSON()
+}
+
+func (c *JenkinsRestController) GetJobProvisioner() {
+	p, err := c.JobProvisioning.GetJobProvisioner(c.GetString(":name"), c.GetString(":scope"))
+	if err != nil {
+		writeJenkinsError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = p
+	c.ServeJSON()
+}
+
+func (c *JenkinsRestController) CreateJobProvisioner() {
+	var cmd command.JobProvisionerCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJobProvisionerRequest(cmd); errMsg != nil {
+		log.Error("job provisioner request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.JobProvisioning.CreateJobProvisioner(cmd); err != nil {
+		writeJenkinsError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *JenkinsRestController) UpdateJobProvisioner() {
+	var cmd command.JobProvisionerCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Name = c.GetString(":name")
+	cmd.Scope = c.GetString(":scope")
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJobProvisionerRequest(cmd); errMsg != nil {
+		log.Error("job provisioner request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.JobProvisioning.UpdateJobProvisioner(cmd); err != nil {
+		writeJenkinsError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *JenkinsRestController) DeleteJobProvisioner() {
+	if err := c.JobProvisioning.DeleteJobProvisioner(c.GetString(":name"), c.GetString(":scope")); err != nil {
+		writeJenkinsError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func writeJenkinsError(w http.ResponseWriter, err error) {
+	if dberror.JenkinsResourceIsUsed(err) {
+		http.Error(w, err.(dberror.JenkinsResourceIsUsedByCodebase).Message, http.StatusConflict)
+		return
+	}
+	switch err.(type) {
+	case *edperror.JenkinsResourceDoesNotExistError:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case *edperror.JenkinsResourceExistsError:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		log.Error("jenkins resource request is failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
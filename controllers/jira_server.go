@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	jiraservice "edp-admin-console/service/jira-server"
+	"edp-admin-console/util/auth"
+	"fmt"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"html/template"
+	"strings"
+)
+
+type JiraServerController struct {
+	beego.Controller
+	JiraServer jiraservice.JiraServer
+}
+
+const jiraServerPageType = "jiraserver"
+
+func (c *JiraServerController) GetJiraServersPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["success"] != "" {
+		c.Data["Success"] = flash.Data["success"]
+	}
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	servers, err := c.JiraServer.GetJiraServers()
+	if err != nil {
+		log.Error("couldn't get jira servers", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["JiraServers"] = servers
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
+	c.Data["Type"] = jiraServerPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "jira_servers.html"
+}
+
+func (c *JiraServerController) GetCreateJiraServerPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	c.Data["JiraServer"] = command.JiraServerCommand{}
+	c.setJiraServerFormData()
+}
+
+func (c *JiraServerController) CreateJiraServer() {
+	flash := beego.NewFlash()
+	cmd := c.readJiraServerCommand(c.GetString("name"))
+	log.Debug("start executing CreateJiraServer method", zap.String("name", cmd.Name))
+
+	if errMsg := validation.ValidateJiraServerRequest(cmd); errMsg != nil {
+		log.Error("jira server request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/create", context.BasePath), 302)
+		return
+	}
+
+	if err := c.JiraServer.CreateJiraServer(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.JiraServerExistsError, *edperror.NonValidJiraServerError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/create", context.BasePath), 302)
+		default:
+			log.Error("couldn't create jira server", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("Jira server %v is created. It will be available after the operator processes it.", cmd.Name))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/overview", context.BasePath), 302)
+}
+
+func (c *JiraServerController) GetEditJiraServerPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	name := c.GetString(":name")
+	js, err := c.JiraServer.GetJiraServer(name)
+	if err != nil {
+		if _, ok := err.(*edperror.JiraServerDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't get jira server", zap.String("name", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["JiraServer"] = command.JiraServerCommand{
+		Name:    js.Name,
+		ApiUrl:  js.ApiUrl,
+		RootUrl: js.RootUrl,
+	}
+	c.Data["Edit"] = true
+	c.setJiraServerFormData()
+}
+
+func (c *JiraServerController) UpdateJiraServer() {
+	flash := beego.NewFlash()
+	cmd := c.readJiraServerCommand(c.GetString(":name"))
+	log.Debug("start executing UpdateJiraServer method", zap.String("name", cmd.Name))
+
+	if errMsg := validation.ValidateJiraServerRequest(cmd); errMsg != nil {
+		log.Error("jira server request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/%v/update", context.BasePath, cmd.Name), 302)
+		return
+	}
+
+	if err := c.JiraServer.UpdateJiraServer(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.JiraServerDoesNotExistError:
+			c.Abort("404")
+		case *edperror.NonValidJiraServerError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/%v/update", context.BasePath, cmd.Name), 302)
+		default:
+			log.Error("couldn't update jira server", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("Jira server %v is updated.", cmd.Name))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/overview", context.BasePath), 302)
+}
+
+func (c *JiraServerController) DeleteJiraServer() {
+	flash := beego.NewFlash()
+	name := c.GetString("name")
+	if err := c.JiraServer.DeleteJiraServer(name); err != nil {
+		switch err.(type) {
+		case *edperror.JiraServerDoesNotExistError:
+			c.Abort("404")
+			return
+		case *edperror.NonValidJiraServerError:
+			flash.Error(err.Error())
+		default:
+			log.Error("couldn't delete jira server", zap.String("name", name), zap.Error(err))
+			c.Abort("500")
+			return
+		}
+	} else {
+		flash.Success(fmt.Sprintf("Jira server %v is deleted.", name))
+	}
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/overview", context.BasePath), 302)
+}
+
+func (c *JiraServerController) TestConnection() {
+	flash := beego.NewFlash()
+	name := c.GetString(":name")
+	res, err := c.JiraServer.TestConnection(name)
+	if err != nil {
+		switch err.(type) {
+		case *edperror.JiraServerDoesNotExistError:
+			c.Abort("404")
+			return
+		case *edperror.NonValidJiraServerError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/overview", context.BasePath), 302)
+			return
+		}
+		log.Error("couldn't test jira server connection", zap.String("name", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	msg := fmt.Sprintf("Jira server %v. Connection: %v. Permissions: %v.", name,
+		res.Connection.Message, res.Permissions.Message)
+	if res.Connection.Success && res.Permissions.Success {
+		flash.Success(msg)
+	} else {
+		flash.Error(msg)
+	}
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/overview", context.BasePath), 302)
+}
+
+func (c *JiraServerController) CheckTicketPattern() {
+	flash := beego.NewFlash()
+	codebase := c.GetString("codebase")
+	project := c.GetString("project")
+	res, err := c.JiraServer.CheckTicketPattern(codebase, project, c.GetString("pattern"))
+	if err != nil {
+		switch err.(type) {
+		case *edperror.CodebaseDoesNotExistError, *edperror.JiraServerDoesNotExistError, *edperror.NonValidJiraServerError:
+			flash.Error(err.Error())
+		default:
+			log.Error("couldn't check ticket name pattern", zap.String("codebase", codebase), zap.Error(err))
+			c.Abort("500")
+			return
+		}
+	} else {
+		msg := fmt.Sprintf("Pattern %v of %v codebase matches %v of %v issues of %v project.", res.Pattern, codebase,
+			len(res.Matched), len(res.Matched)+len(res.NotMatched), project)
+		if len(res.NotMatched) > 0 {
+			flash.Error(fmt.Sprintf("%v Not matched: %v.", msg, strings.Join(res.NotMatched, ", ")))
+		} else {
+			flash.Success(msg)
+		}
+	}
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/jira-server/overview", context.BasePath), 302)
+}
+
+func (c *JiraServerController) readJiraServerCommand(name string) command.JiraServerCommand {
+	username, _ := c.Ctx.Input.Session("username").(string)
+	return command.JiraServerCommand{
+		Name:     name,
+		ApiUrl:   c.GetString("apiUrl"),
+		RootUrl:  c.GetString("rootUrl"),
+		User:     c.GetString("user"),
+		Password: c.GetString("password"),
+		Username: username,
+	}
+}
+
+func (c *JiraServerController) setJiraServerFormData() {
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["Type"] = jiraServerPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "jira_server_form.html"
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	jiraservice "edp-admin-console/service/jira-server"
+	"encoding/json"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type JiraServerRestController struct {
+	beego.Controller
+	JiraServer jiraservice.JiraServer
+}
+
+func (c *JiraServerRestController) GetJiraServers() {
+	servers, err := c.JiraServer.GetJiraServers()
+	if err != nil {
+		log.Error("couldn't get jira servers", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = servers
+	c.ServeJSON()
+}
+
+func (c *JiraServerRestController) GetJiraServer() {
+	js, err := c.JiraServer.GetJiraServer(c.GetString(":name"))
+	if err != nil {
+		writeJiraServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = js
+	c.ServeJSON()
+}
+
+func (c *JiraServerRestController) CreateJiraServer() {
+	var cmd command.JiraServerCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJiraServerRequest(cmd); errMsg != nil {
+		log.Error("jira server request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.JiraServer.CreateJiraServer(cmd); err != nil {
+		writeJiraServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *JiraServerRestController) UpdateJiraServer() {
+	var cmd command.JiraServerCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Name = c.GetString(":name")
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateJiraServerRequest(cmd); errMsg != nil {
+		log.Error("jira server request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.JiraServer.UpdateJiraServer(cmd); err != nil {
+		writeJiraServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *JiraServerRestController) DeleteJiraServer() {
+	if err := c.JiraServer.DeleteJiraServer(c.GetString(":name")); err != nil {
+		writeJiraServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *JiraServerRestController) TestConnection() {
+	res, err := c.JiraServer.TestConnection(c.GetString(":name"))
+	if err != nil {
+		writeJiraServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = res
+	c.ServeJSON()
+}
+
+func (c *JiraServerRestController) CheckTicketPattern() {
+	res, err := c.JiraServer.CheckTicketPattern(c.GetString(":codebaseName"), c.GetString("project"),
+		c.GetString("pattern"))
+	if err != nil {
+		writeJiraServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = res
+	c.ServeJSON()
+}
+
+func writeJiraServerError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case *edperror.JiraServerDoesNotExistError, *edperror.CodebaseDoesNotExistError:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case *edperror.JiraServerExistsError:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case *edperror.NonValidJiraServerError:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Error("jira server request is failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
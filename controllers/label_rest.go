@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service/label"
+	"encoding/json"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type LabelRestController struct {
+	beego.Controller
+	LabelService label.LabelService
+}
+
+func (c *LabelRestController) Prepare() {
+	c.EnableXSRF = false
+}
+
+func (c *LabelRestController) UpdateCodebaseLabels() {
+	labels, ok := c.readLabels()
+	if !ok {
+		return
+	}
+	name := c.GetString(":codebaseName")
+	log.Info("request data is received to update codebase labels", zap.String("name", name))
+
+	c.writeLabelsResult(c.LabelService.UpdateCodebaseLabels(name, labels))
+}
+
+func (c *LabelRestController) UpdateCDPipelineLabels() {
+	labels, ok := c.readLabels()
+	if !ok {
+		return
+	}
+	name := c.GetString(":name")
+	log.Info("request data is received to update cd pipeline labels", zap.String("name", name))
+
+	c.writeLabelsResult(c.LabelService.UpdateCDPipelineLabels(name, labels))
+}
+
+func (c *LabelRestController) readLabels() (map[string]string, bool) {
+	labels := map[string]string{}
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&labels); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return labels, true
+}
+
+func (c *LabelRestController) writeLabelsResult(err error) {
+	if err == nil {
+		c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch err.(type) {
+	case *edperror.CodebaseDoesNotExistError, *edperror.CDPipelineDoesNotExistError:
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+	case *edperror.NonValidLabelError:
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+	default:
+		log.Error("couldn't update labels", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+	}
+}
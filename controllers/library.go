@@ -8,6 +8,8 @@ import (
 	"edp-admin-console/models/query"
 	"edp-admin-console/service"
 	cbs "edp-admin-console/service/codebasebranch"
+	"edp-admin-console/service/dependency"
+	"edp-admin-console/service/export"
 	jiraservice "edp-admin-console/service/jira-server"
 	"edp-admin-console/service/perfboard"
 	"edp-admin-console/util"
@@ -36,6 +38,7 @@ type LibraryController struct {
 	JobProvisioning  service.JobProvisioning
 	JiraServer       jiraservice.JiraServer
 	PerfService      perfboard.PerfBoard
+	Dependency       dependency.DependencyService
 
 	IntegrationStrategies []string
 	BuildTools            []string
@@ -48,7 +51,8 @@ type LibraryController struct {
 func (c *LibraryController) GetLibraryListPage() {
 	flash := beego.ReadFromRequest(&c.Controller)
 	codebases, err := c.CodebaseService.GetCodebasesByCriteria(query.CodebaseCriteria{
-		Type: query.Library,
+		Type:   query.Library,
+		Labels: getLabelFilter(&c.Controller),
 	})
 	codebases = addCodebaseInProgressIfAny(codebases, c.GetString(paramWaitingForCodebase))
 	if err != nil {
@@ -67,12 +71,49 @@ func (c *LibraryController) GetLibraryListPage() {
 	c.Data["Username"] = c.Ctx.Input.Session("username")
 	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
 	c.Data["Type"] = query.Library
+	c.Data["ExportColumns"] = export.CodebaseColumns()
 	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
 	c.Data["BasePath"] = context.BasePath
 	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
 	c.TplName = "codebase.html"
 }
 
+func (c *LibraryController) GetLibraryImpactPage() {
+	name := c.GetString(":libraryName")
+	refresh, allowed := getDependencyRefresh(&c.Controller)
+	if !allowed {
+		c.Abort("403")
+		return
+	}
+	impact, err := c.Dependency.GetLibraryImpact(name, refresh)
+	if err != nil {
+		if _, ok := err.(*edperror.CodebaseDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't get library impact", zap.String("library", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	g, err := c.Dependency.GetDependencyGraph(false)
+	if err != nil {
+		log.Error("couldn't build dependency graph", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["Impact"] = impact
+	c.Data["ScanErrors"] = g.Errors
+	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["Type"] = query.Library
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "library_impact.html"
+}
+
 func (c *LibraryController) GetCreatePage() {
 	flash := beego.ReadFromRequest(&c.Controller)
 	if flash.Data["error"] != "" {
@@ -181,6 +222,10 @@ func (c *LibraryController) checkError(err error, flash *beego.FlashData, name s
 		flash.Error("Library %v with %v project path already exists.", name, *url)
 		flash.Store(&c.Controller)
 		c.Redirect(fmt.Sprintf("%s/admin/edp/library/create", context.BasePath), 302)
+	case *edperror.NonValidPerfServerError, *edperror.NonValidCredentialsSecretError, *edperror.NonValidGitServerError:
+		flash.Error(err.Error())
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/library/create", context.BasePath), 302)
 	default:
 		log.Error("couldn't create codebase", zap.Error(err))
 		c.Abort("500")
@@ -245,14 +290,22 @@ func (c *LibraryController) extractLibraryRequestData() command.CreateCodebase {
 
 		isRepoPrivate, _ := c.GetBool("isRepoPrivate", false)
 		if isRepoPrivate {
-			library.Repository.Login = c.GetString("repoLogin")
-			library.Repository.Password = c.GetString("repoPassword")
+			if secret := c.GetString("repoSecret"); secret != "" {
+				library.Repository.SecretName = secret
+			} else {
+				library.Repository.Login = c.GetString("repoLogin")
+				library.Repository.Password = c.GetString("repoPassword")
+			}
 		}
 	}
 
 	vcsLogin := c.GetString("vcsLogin")
 	vcsPassword := c.GetString("vcsPassword")
-	if vcsLogin != "" && vcsPassword != "" {
+	if vcsSecret := c.GetString("vcsSecret"); vcsSecret != "" {
+		library.Vcs = &command.Vcs{
+			SecretName: vcsSecret,
+		}
+	} else if vcsLogin != "" && vcsPassword != "" {
 		library.Vcs = &command.Vcs{
 			Login:    vcsLogin,
 			Password: vcsPassword,
@@ -282,16 +335,23 @@ func validateLibraryRequestData(library command.CreateCodebase) *validation2.Err
 	if library.Strategy == "clone" && library.Repository != nil {
 		_, err = valid.Valid(library.Repository)
 
-		isAvailable := util.IsGitRepoAvailable(library.Repository.Url, library.Repository.Login, library.Repository.Password)
+		if library.Repository.SecretName == "" {
+			isAvailable := util.IsGitRepoAvailable(library.Repository.Url, library.Repository.Login, library.Repository.Password)
 
-		if !isAvailable {
-			err := &validation.Error{Key: "repository", Message: "Repository doesn't exist or invalid login and password."}
-			valid.Errors = append(valid.Errors, err)
+			if !isAvailable {
+				err := &validation.Error{Key: "repository", Message: "Repository doesn't exist or invalid login and password."}
+				valid.Errors = append(valid.Errors, err)
+			}
 		}
 	}
 
 	if library.Vcs != nil {
 		_, err = valid.Valid(library.Vcs)
+
+		if library.Vcs.SecretName == "" && (library.Vcs.Login == "" || library.Vcs.Password == "") {
+			err := &validation.Error{Key: "vcs", Message: "either secret name or login and password should be specified for VCS"}
+			valid.Errors = append(valid.Errors, err)
+		}
 	}
 
 	if library.Perf != nil {
@@ -315,11 +375,12 @@ func logLibraryRequestData(library command.CreateCodebase) {
 		library.Name, library.Strategy, library.Lang, library.BuildTool))
 
 	if library.Repository != nil {
-		result.WriteString(fmt.Sprintf(", repositoryUrl=%s, repositoryLogin=%s", library.Repository.Url, library.Repository.Login))
+		result.WriteString(fmt.Sprintf(", repositoryUrl=%s, repositoryLogin=%s, repositorySecret=%s",
+			library.Repository.Url, library.Repository.Login, library.Repository.SecretName))
 	}
 
 	if library.Vcs != nil {
-		result.WriteString(fmt.Sprintf(", vcsLogin=%s", library.Vcs.Login))
+		result.WriteString(fmt.Sprintf(", vcsLogin=%s, vcsSecret=%s", library.Vcs.Login, library.Vcs.SecretName))
 	}
 
 	log.Info(result.String())
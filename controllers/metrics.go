@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/service/metrics"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"time"
+)
+
+type MetricsController struct {
+	beego.Controller
+	MetricsService metrics.MetricsService
+}
+
+const metricsPageType = "metrics"
+
+func (c *MetricsController) GetDoraMetricsPage() {
+	from, to, err := parseMetricsPeriod(&c.Controller)
+	if err != nil {
+		log.Info("couldn't parse metrics period", zap.Error(err))
+		c.Abort("400")
+		return
+	}
+
+	report, err := c.MetricsService.GetDoraReport(from, to)
+	if err != nil {
+		log.Error("couldn't calculate DORA metrics", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["Report"] = report
+	c.Data["From"] = from.Format(metricsDateLayout)
+	c.Data["To"] = to.Add(-24 * time.Hour).Format(metricsDateLayout)
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["Type"] = metricsPageType
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "dora_metrics.html"
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service/metrics"
+	"encoding/json"
+	"errors"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const (
+	metricsDateLayout    = "2006-01-02"
+	defaultMetricsPeriod = 90 * 24 * time.Hour
+)
+
+type MetricsRestController struct {
+	beego.Controller
+	MetricsService metrics.MetricsService
+}
+
+func (c *MetricsRestController) Prepare() {
+	c.EnableXSRF = false
+}
+
+func (c *MetricsRestController) GetDoraMetrics() {
+	from, to, err := parseMetricsPeriod(&c.Controller)
+	if err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := c.MetricsService.GetDoraReport(from, to)
+	if err != nil {
+		log.Error("couldn't calculate DORA metrics", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = report
+	c.ServeJSON()
+}
+
+func (c *MetricsRestController) CreateDeploymentEvent() {
+	var event command.DeploymentEventCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&event); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	event.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateMetricsEventRequest(event); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.MetricsService.CreateDeploymentEvent(event); err != nil {
+		if _, ok := err.(*edperror.CDStageDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't create deployment event", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *MetricsRestController) CreateBuildEvent() {
+	var event command.BuildEventCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&event); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	event.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateMetricsEventRequest(event); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.MetricsService.CreateBuildEvent(event); err != nil {
+		if _, ok := err.(*edperror.CodebaseDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't create build event", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func parseMetricsPeriod(c *beego.Controller) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	if v := c.GetString("to"); v != "" {
+		t, err := time.Parse(metricsDateLayout, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to should be a date in YYYY-MM-DD format")
+		}
+		to = t.Add(24 * time.Hour)
+	}
+
+	from := to.Add(-defaultMetricsPeriod)
+	if v := c.GetString("from"); v != "" {
+		t, err := time.Parse(metricsDateLayout, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from should be a date in YYYY-MM-DD format")
+		}
+		from = t
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from should not be after to")
+	}
+	return from, to, nil
+}
@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service/perfboard"
+	"edp-admin-console/util/auth"
+	"fmt"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"html/template"
+)
+
+type PerfServerController struct {
+	beego.Controller
+	PerfBoard perfboard.PerfBoard
+}
+
+const perfServerPageType = "perfserver"
+
+func (c *PerfServerController) GetPerfServersPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["success"] != "" {
+		c.Data["Success"] = flash.Data["success"]
+	}
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	servers, err := c.PerfBoard.GetPerfServers()
+	if err != nil {
+		log.Error("couldn't get perf servers", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["PerfServers"] = servers
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["HasRights"] = auth.IsAdmin(c.GetSession("realm_roles").([]string))
+	c.Data["Type"] = perfServerPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "perf_servers.html"
+}
+
+func (c *PerfServerController) GetCreatePerfServerPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	c.Data["PerfServer"] = command.PerfServerCommand{DataSources: c.PerfBoard.DataSources}
+	c.setPerfServerFormData()
+}
+
+func (c *PerfServerController) CreatePerfServer() {
+	flash := beego.NewFlash()
+	cmd := c.readPerfServerCommand(c.GetString("name"))
+	log.Debug("start executing CreatePerfServer method", zap.String("name", cmd.Name))
+
+	if errMsg := validation.ValidatePerfServerRequest(cmd); errMsg != nil {
+		log.Error("perf server request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/perf-server/create", context.BasePath), 302)
+		return
+	}
+
+	if err := c.PerfBoard.CreatePerfServer(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.PerfServerExistsError, *edperror.NonValidPerfServerError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/perf-server/create", context.BasePath), 302)
+		default:
+			log.Error("couldn't create perf server", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("Perf server %v is created. It will be available after the operator processes it.", cmd.Name))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/perf-server/overview", context.BasePath), 302)
+}
+
+func (c *PerfServerController) GetEditPerfServerPage() {
+	flash := beego.ReadFromRequest(&c.Controller)
+	if flash.Data["error"] != "" {
+		c.Data["Error"] = flash.Data["error"]
+	}
+
+	name := c.GetString(":name")
+	ps, err := c.PerfBoard.GetPerfServer(name)
+	if err != nil {
+		if _, ok := err.(*edperror.PerfServerDoesNotExistError); ok {
+			c.Abort("404")
+			return
+		}
+		log.Error("couldn't get perf server", zap.String("name", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["PerfServer"] = command.PerfServerCommand{
+		Name:        ps.Name,
+		ApiUrl:      ps.ApiUrl,
+		RootUrl:     ps.RootUrl,
+		ProjectName: ps.ProjectName,
+		DataSources: ps.DataSources,
+	}
+	c.Data["Edit"] = true
+	c.setPerfServerFormData()
+}
+
+func (c *PerfServerController) UpdatePerfServer() {
+	flash := beego.NewFlash()
+	cmd := c.readPerfServerCommand(c.GetString(":name"))
+	log.Debug("start executing UpdatePerfServer method", zap.String("name", cmd.Name))
+
+	if errMsg := validation.ValidatePerfServerRequest(cmd); errMsg != nil {
+		log.Error("perf server request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&c.Controller)
+		c.Redirect(fmt.Sprintf("%s/admin/edp/perf-server/%v/update", context.BasePath, cmd.Name), 302)
+		return
+	}
+
+	if err := c.PerfBoard.UpdatePerfServer(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.PerfServerDoesNotExistError:
+			c.Abort("404")
+		case *edperror.NonValidPerfServerError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/perf-server/%v/update", context.BasePath, cmd.Name), 302)
+		default:
+			log.Error("couldn't update perf server", zap.Error(err))
+			c.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("Perf server %v is updated.", cmd.Name))
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/perf-server/overview", context.BasePath), 302)
+}
+
+func (c *PerfServerController) DeletePerfServer() {
+	flash := beego.NewFlash()
+	name := c.GetString("name")
+	if err := c.PerfBoard.DeletePerfServer(name); err != nil {
+		switch err.(type) {
+		case *edperror.PerfServerDoesNotExistError:
+			c.Abort("404")
+			return
+		case *edperror.NonValidPerfServerError:
+			flash.Error(err.Error())
+		default:
+			log.Error("couldn't delete perf server", zap.String("name", name), zap.Error(err))
+			c.Abort("500")
+			return
+		}
+	} else {
+		flash.Success(fmt.Sprintf("Perf server %v is deleted.", name))
+	}
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/perf-server/overview", context.BasePath), 302)
+}
+
+func (c *PerfServerController) TestConnection() {
+	flash := beego.NewFlash()
+	name := c.GetString(":name")
+	res, err := c.PerfBoard.TestConnection(name)
+	if err != nil {
+		switch err.(type) {
+		case *edperror.PerfServerDoesNotExistError:
+			c.Abort("404")
+			return
+		case *edperror.NonValidPerfServerError:
+			flash.Error(err.Error())
+			flash.Store(&c.Controller)
+			c.Redirect(fmt.Sprintf("%s/admin/edp/perf-server/overview", context.BasePath), 302)
+			return
+		}
+		log.Error("couldn't test perf server connection", zap.String("name", name), zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	msg := fmt.Sprintf("Perf server %v. Connection: %v. Project: %v.", name,
+		res.Connection.Message, res.Project.Message)
+	if res.Connection.Success && res.Project.Success {
+		flash.Success(msg)
+	} else {
+		flash.Error(msg)
+	}
+	flash.Store(&c.Controller)
+	c.Redirect(fmt.Sprintf("%s/admin/edp/perf-server/overview", context.BasePath), 302)
+}
+
+func (c *PerfServerController) readPerfServerCommand(name string) command.PerfServerCommand {
+	username, _ := c.Ctx.Input.Session("username").(string)
+	return command.PerfServerCommand{
+		Name:        name,
+		ApiUrl:      c.GetString("apiUrl"),
+		RootUrl:     c.GetString("rootUrl"),
+		ProjectName: c.GetString("projectName"),
+		User:        c.GetString("user"),
+		Password:    c.GetString("password"),
+		DataSources: c.GetStrings("dataSource"),
+		Username:    username,
+	}
+}
+
+func (c *PerfServerController) setPerfServerFormData() {
+	c.Data["SupportedDataSources"] = c.PerfBoard.DataSources
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["Type"] = perfServerPageType
+	c.Data["xsrfdata"] = template.HTML(c.XSRFFormHTML())
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "perf_server_form.html"
+}
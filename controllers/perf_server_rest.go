@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service/perfboard"
+	"encoding/json"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type PerfServerRestController struct {
+	beego.Controller
+	PerfBoard perfboard.PerfBoard
+}
+
+func (c *PerfServerRestController) GetPerfServers() {
+	servers, err := c.PerfBoard.GetPerfServers()
+	if err != nil {
+		log.Error("couldn't get perf servers", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = servers
+	c.ServeJSON()
+}
+
+func (c *PerfServerRestController) GetPerfServer() {
+	ps, err := c.PerfBoard.GetPerfServer(c.GetString(":name"))
+	if err != nil {
+		writePerfServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = ps
+	c.ServeJSON()
+}
+
+func (c *PerfServerRestController) CreatePerfServer() {
+	var cmd command.PerfServerCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidatePerfServerRequest(cmd); errMsg != nil {
+		log.Error("perf server request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.PerfBoard.CreatePerfServer(cmd); err != nil {
+		writePerfServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *PerfServerRestController) UpdatePerfServer() {
+	var cmd command.PerfServerCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Name = c.GetString(":name")
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidatePerfServerRequest(cmd); errMsg != nil {
+		log.Error("perf server request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.PerfBoard.UpdatePerfServer(cmd); err != nil {
+		writePerfServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *PerfServerRestController) DeletePerfServer() {
+	if err := c.PerfBoard.DeletePerfServer(c.GetString(":name")); err != nil {
+		writePerfServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *PerfServerRestController) TestConnection() {
+	res, err := c.PerfBoard.TestConnection(c.GetString(":name"))
+	if err != nil {
+		writePerfServerError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = res
+	c.ServeJSON()
+}
+
+func writePerfServerError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case *edperror.PerfServerDoesNotExistError:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case *edperror.PerfServerExistsError:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case *edperror.NonValidPerfServerError:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Error("perf server request is failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
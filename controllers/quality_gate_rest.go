@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	qgs "edp-admin-console/service/quality-gate"
+	"encoding/json"
+	"fmt"
+	"github.com/astaxie/beego"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type QualityGateRestController struct {
+	beego.Controller
+	QualityGateService qgs.QualityGateService
+}
+
+func (c *QualityGateRestController) Prepare() {
+	c.EnableXSRF = false
+}
+
+func (c *QualityGateRestController) MakeDecision() {
+	var dc command.QualityGateDecisionCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&dc); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	dc.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateQualityGateDecisionRequest(dc); errMsg != nil {
+		log.Error("Failed to validate request data", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	pipelineName := c.GetString(":pipelineName")
+	stageName := c.GetString(":stageName")
+	step := c.GetString(":step")
+	log.Info("request data is received to make decision on quality gate",
+		zap.String("pipeline", pipelineName),
+		zap.String("stage", stageName),
+		zap.String("step", step),
+		zap.String("decision", dc.Decision))
+
+	if _, err := c.QualityGateService.MakeDecision(pipelineName, stageName, step, dc); err != nil {
+		switch err.(type) {
+		case *edperror.QualityGateDoesNotExistError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		case *edperror.StageFrozenError:
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusConflict)
+			return
+		}
+		log.Error("couldn't make decision on quality gate", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	location := fmt.Sprintf("%s/%s", c.Ctx.Input.URL(), uuid.NewV4().String())
+	c.Ctx.Output.Header("Location", location)
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *QualityGateRestController) GetDecision() {
+	pipelineName := c.GetString(":pipelineName")
+	stageName := c.GetString(":stageName")
+	step := c.GetString(":step")
+
+	var since *time.Time
+	if s := c.GetString("since"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			http.Error(c.Ctx.ResponseWriter, "since parameter should be in RFC3339 format", http.StatusBadRequest)
+			return
+		}
+		since = &t
+	}
+
+	d, err := c.QualityGateService.GetDecision(pipelineName, stageName, step, since)
+	if err != nil {
+		if _, ok := err.(*edperror.QualityGateDoesNotExistError); ok {
+			http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusNotFound)
+			return
+		}
+		log.Error("couldn't get decision on quality gate", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if d == nil {
+		http.Error(c.Ctx.ResponseWriter, fmt.Sprintf("decision on quality gate %v hasn't been made yet", step), http.StatusNotFound)
+		return
+	}
+
+	c.Data["json"] = d
+	c.ServeJSON()
+}
@@ -2,23 +2,31 @@ package controllers
 
 import (
 	validation2 "edp-admin-console/controllers/validation"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/service"
+	"edp-admin-console/service/gitref"
 	"edp-admin-console/util"
 	"encoding/json"
 	"github.com/astaxie/beego"
 	"github.com/astaxie/beego/validation"
+	"go.uber.org/zap"
 	"net/http"
 )
 
 type RepositoryRestController struct {
 	beego.Controller
-	AppService service.CodebaseService
+	AppService    service.CodebaseService
+	GitRefService gitref.GitRefService
 }
 
 type RepoData struct {
-	Url      string `json:"url,omitempty"`
-	Login    string `json:"login,omitempty"`
-	Password string `json:"password,omitempty"`
+	Url        string `json:"url,omitempty"`
+	Login      string `json:"login,omitempty"`
+	Password   string `json:"password,omitempty"`
+	SecretName string `json:"secretName,omitempty"`
+	GitServer  string `json:"gitServer,omitempty"`
+	GitUrlPath string `json:"gitUrlPath,omitempty"`
 }
 
 func (this *RepositoryRestController) IsGitRepoAvailable() {
@@ -35,7 +43,54 @@ func (this *RepositoryRestController) IsGitRepoAvailable() {
 		return
 	}
 
-	this.Data["json"] = util.IsGitRepoAvailable(repo.Url, repo.Login, repo.Password)
+	if repo.SecretName == "" {
+		this.Data["json"] = util.CheckGitRepo(repo.Url, util.GitCredentials{Username: repo.Login, Password: repo.Password})
+		this.ServeJSON()
+		return
+	}
+
+	res, err := this.AppService.CheckRepository(repo.Url, repo.SecretName)
+	if err != nil {
+		if _, ok := err.(*edperror.NonValidCredentialsSecretError); ok {
+			http.Error(this.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Error("couldn't check repository", zap.String("url", repo.Url), zap.Error(err))
+		http.Error(this.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	this.Data["json"] = res
+	this.ServeJSON()
+}
+
+func (this *RepositoryRestController) GetRepositoryRefs() {
+	var repo RepoData
+	if err := json.NewDecoder(this.Ctx.Request.Body).Decode(&repo); err != nil {
+		http.Error(this.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var refs *dto.GitRefs
+	var err error
+	switch {
+	case repo.GitServer != "":
+		refs, err = this.GitRefService.GetImportRefs(repo.GitServer, repo.GitUrlPath)
+	case repo.SecretName != "":
+		refs, err = this.AppService.GetRepositoryRefs(repo.Url, repo.SecretName)
+	default:
+		refs, err = util.ListGitRefs(repo.Url, util.GitCredentials{Username: repo.Login, Password: repo.Password})
+	}
+	if err != nil {
+		if _, ok := err.(*edperror.NonValidCredentialsSecretError); ok {
+			http.Error(this.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Error("couldn't list repository refs", zap.String("url", repo.Url),
+			zap.String("gitServer", repo.GitServer), zap.Error(err))
+		http.Error(this.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	this.Data["json"] = refs
 	this.ServeJSON()
 }
 
@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/service/search"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+)
+
+type SearchController struct {
+	beego.Controller
+	SearchService search.SearchService
+}
+
+const searchPageType = "search"
+
+func (c *SearchController) GetSearchPage() {
+	res, err := c.SearchService.Search(c.GetString("q"), search.DefaultLimit)
+	if err != nil {
+		log.Error("couldn't perform search", zap.Error(err))
+		c.Abort("500")
+		return
+	}
+
+	c.Data["Result"] = res
+	c.Data["EDPVersion"] = context.EDPVersion
+	c.Data["Username"] = c.Ctx.Input.Session("username")
+	c.Data["Type"] = searchPageType
+	c.Data["BasePath"] = context.BasePath
+	c.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	c.TplName = "search.html"
+}
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"edp-admin-console/service/search"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type SearchRestController struct {
+	beego.Controller
+	SearchService search.SearchService
+}
+
+func (c *SearchRestController) Search() {
+	limit, err := c.GetInt("limit", search.DefaultLimit)
+	if err != nil {
+		http.Error(c.Ctx.ResponseWriter, "limit should be a number", http.StatusBadRequest)
+		return
+	}
+
+	res, err := c.SearchService.Search(c.GetString("q"), limit)
+	if err != nil {
+		log.Error("couldn't perform search", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = res
+	c.ServeJSON()
+}
@@ -2,8 +2,15 @@ package controllers
 
 import (
 	"edp-admin-console/context"
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/service"
+	"edp-admin-console/util/auth"
+	"fmt"
 	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"html/template"
 )
 
 type ThirdPartyServiceController struct {
@@ -12,17 +19,197 @@ type ThirdPartyServiceController struct {
 }
 
 func (s *ThirdPartyServiceController) GetServicePage() {
-	services, err := s.ThirdPartyService.GetAllServices()
+	flash := beego.ReadFromRequest(&s.Controller)
+	if flash.Data["success"] != "" {
+		s.Data["Success"] = flash.Data["success"]
+	}
+	if flash.Data["error"] != "" {
+		s.Data["Error"] = flash.Data["error"]
+	}
+
+	services, err := s.ThirdPartyService.GetServicesDetails()
 	if err != nil {
+		log.Error("couldn't get services", zap.Error(err))
 		s.Abort("500")
 		return
 	}
 
 	s.Data["EDPVersion"] = context.EDPVersion
 	s.Data["Username"] = s.Ctx.Input.Session("username")
+	s.Data["HasRights"] = auth.IsAdmin(s.GetSession("realm_roles").([]string))
 	s.Data["Services"] = services
 	s.Data["Type"] = "services"
+	s.Data["xsrfdata"] = template.HTML(s.XSRFFormHTML())
 	s.Data["BasePath"] = context.BasePath
 	s.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
 	s.TplName = "service.html"
 }
+
+func (s *ThirdPartyServiceController) GetCreateServicePage() {
+	flash := beego.ReadFromRequest(&s.Controller)
+	if flash.Data["error"] != "" {
+		s.Data["Error"] = flash.Data["error"]
+	}
+
+	s.Data["Service"] = command.ThirdPartyServiceCommand{SourceType: "helm"}
+	s.setServiceFormData()
+}
+
+func (s *ThirdPartyServiceController) CreateService() {
+	flash := beego.NewFlash()
+	cmd, err := s.readServiceCommand(s.GetString("name"))
+	if err != nil {
+		flash.Error(err.Error())
+		flash.Store(&s.Controller)
+		s.Redirect(fmt.Sprintf("%s/admin/edp/service/create", context.BasePath), 302)
+		return
+	}
+	log.Debug("start executing CreateService method", zap.String("name", cmd.Name))
+
+	if errMsg := validation.ValidateThirdPartyServiceRequest(cmd); errMsg != nil {
+		log.Error("service request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&s.Controller)
+		s.Redirect(fmt.Sprintf("%s/admin/edp/service/create", context.BasePath), 302)
+		return
+	}
+
+	if err := s.ThirdPartyService.CreateService(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.ThirdPartyServiceExistsError, *edperror.NonValidThirdPartyServiceError:
+			flash.Error(err.Error())
+			flash.Store(&s.Controller)
+			s.Redirect(fmt.Sprintf("%s/admin/edp/service/create", context.BasePath), 302)
+		default:
+			log.Error("couldn't create service", zap.Error(err))
+			s.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("Service %v is created.", cmd.Name))
+	flash.Store(&s.Controller)
+	s.Redirect(fmt.Sprintf("%s/admin/edp/service/overview", context.BasePath), 302)
+}
+
+func (s *ThirdPartyServiceController) GetEditServicePage() {
+	flash := beego.ReadFromRequest(&s.Controller)
+	if flash.Data["error"] != "" {
+		s.Data["Error"] = flash.Data["error"]
+	}
+
+	name := s.GetString(":name")
+	ts, err := s.ThirdPartyService.GetServiceDetails(name)
+	if err != nil {
+		if _, ok := err.(*edperror.ThirdPartyServiceDoesNotExistError); ok {
+			s.Abort("404")
+			return
+		}
+		log.Error("couldn't get service", zap.String("name", name), zap.Error(err))
+		s.Abort("500")
+		return
+	}
+
+	s.Data["Service"] = command.ThirdPartyServiceCommand{
+		Name:            ts.Name,
+		Version:         ts.Version,
+		Description:     ts.Description,
+		Url:             ts.Url,
+		SourceType:      ts.SourceType,
+		SourceReference: ts.SourceReference,
+	}
+	s.Data["Parameters"] = service.FormatServiceParameters(ts.Parameters)
+	s.Data["Usages"] = ts.Usages
+	s.Data["Edit"] = true
+	s.setServiceFormData()
+}
+
+func (s *ThirdPartyServiceController) UpdateService() {
+	flash := beego.NewFlash()
+	name := s.GetString(":name")
+	cmd, err := s.readServiceCommand(name)
+	if err != nil {
+		flash.Error(err.Error())
+		flash.Store(&s.Controller)
+		s.Redirect(fmt.Sprintf("%s/admin/edp/service/%v/update", context.BasePath, name), 302)
+		return
+	}
+	log.Debug("start executing UpdateService method", zap.String("name", name))
+
+	if errMsg := validation.ValidateThirdPartyServiceRequest(cmd); errMsg != nil {
+		log.Error("service request data is invalid", zap.String("err", errMsg.Message))
+		flash.Error(errMsg.Message)
+		flash.Store(&s.Controller)
+		s.Redirect(fmt.Sprintf("%s/admin/edp/service/%v/update", context.BasePath, name), 302)
+		return
+	}
+
+	if err := s.ThirdPartyService.UpdateService(cmd); err != nil {
+		switch err.(type) {
+		case *edperror.ThirdPartyServiceDoesNotExistError:
+			s.Abort("404")
+		case *edperror.NonValidThirdPartyServiceError:
+			flash.Error(err.Error())
+			flash.Store(&s.Controller)
+			s.Redirect(fmt.Sprintf("%s/admin/edp/service/%v/update", context.BasePath, name), 302)
+		default:
+			log.Error("couldn't update service", zap.Error(err))
+			s.Abort("500")
+		}
+		return
+	}
+
+	flash.Success(fmt.Sprintf("Service %v is updated. Stages keep the version which was selected for them.", name))
+	flash.Store(&s.Controller)
+	s.Redirect(fmt.Sprintf("%s/admin/edp/service/overview", context.BasePath), 302)
+}
+
+func (s *ThirdPartyServiceController) DeleteService() {
+	flash := beego.NewFlash()
+	name := s.GetString("name")
+	if err := s.ThirdPartyService.DeleteService(name); err != nil {
+		switch err.(type) {
+		case *edperror.ThirdPartyServiceDoesNotExistError:
+			s.Abort("404")
+			return
+		case *edperror.NonValidThirdPartyServiceError:
+			flash.Error(err.Error())
+		default:
+			log.Error("couldn't delete service", zap.String("name", name), zap.Error(err))
+			s.Abort("500")
+			return
+		}
+	} else {
+		flash.Success(fmt.Sprintf("Service %v is deleted.", name))
+	}
+	flash.Store(&s.Controller)
+	s.Redirect(fmt.Sprintf("%s/admin/edp/service/overview", context.BasePath), 302)
+}
+
+func (s *ThirdPartyServiceController) readServiceCommand(name string) (command.ThirdPartyServiceCommand, error) {
+	params, err := service.ParseServiceParameters(s.GetString("parameters"))
+	if err != nil {
+		return command.ThirdPartyServiceCommand{}, err
+	}
+	username, _ := s.Ctx.Input.Session("username").(string)
+	return command.ThirdPartyServiceCommand{
+		Name:            name,
+		Version:         s.GetString("version"),
+		Description:     s.GetString("description"),
+		Url:             s.GetString("url"),
+		SourceType:      s.GetString("sourceType"),
+		SourceReference: s.GetString("sourceReference"),
+		Parameters:      params,
+		Username:        username,
+	}, nil
+}
+
+func (s *ThirdPartyServiceController) setServiceFormData() {
+	s.Data["EDPVersion"] = context.EDPVersion
+	s.Data["Username"] = s.Ctx.Input.Session("username")
+	s.Data["Type"] = "services"
+	s.Data["xsrfdata"] = template.HTML(s.XSRFFormHTML())
+	s.Data["BasePath"] = context.BasePath
+	s.Data["DiagramPageEnabled"] = context.DiagramPageEnabled
+	s.TplName = "service_form.html"
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"edp-admin-console/controllers/validation"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/service"
+	"encoding/json"
+	"github.com/astaxie/beego"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type ThirdPartyServiceRestController struct {
+	beego.Controller
+	ThirdPartyService service.ThirdPartyService
+}
+
+func (c *ThirdPartyServiceRestController) GetServices() {
+	services, err := c.ThirdPartyService.GetServicesDetails()
+	if err != nil {
+		log.Error("couldn't get services", zap.Error(err))
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	c.Data["json"] = services
+	c.ServeJSON()
+}
+
+func (c *ThirdPartyServiceRestController) GetService() {
+	ts, err := c.ThirdPartyService.GetServiceDetails(c.GetString(":name"))
+	if err != nil {
+		writeThirdPartyServiceError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Data["json"] = ts
+	c.ServeJSON()
+}
+
+func (c *ThirdPartyServiceRestController) CreateService() {
+	var cmd command.ThirdPartyServiceCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateThirdPartyServiceRequest(cmd); errMsg != nil {
+		log.Error("service request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.ThirdPartyService.CreateService(cmd); err != nil {
+		writeThirdPartyServiceError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusCreated)
+}
+
+func (c *ThirdPartyServiceRestController) UpdateService() {
+	var cmd command.ThirdPartyServiceCommand
+	if err := json.NewDecoder(c.Ctx.Request.Body).Decode(&cmd); err != nil {
+		http.Error(c.Ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd.Name = c.GetString(":name")
+	cmd.Username, _ = c.Ctx.Input.Session("username").(string)
+
+	if errMsg := validation.ValidateThirdPartyServiceRequest(cmd); errMsg != nil {
+		log.Error("service request data is invalid", zap.String("err", errMsg.Message))
+		http.Error(c.Ctx.ResponseWriter, errMsg.Message, errMsg.StatusCode)
+		return
+	}
+
+	if err := c.ThirdPartyService.UpdateService(cmd); err != nil {
+		writeThirdPartyServiceError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func (c *ThirdPartyServiceRestController) DeleteService() {
+	if err := c.ThirdPartyService.DeleteService(c.GetString(":name")); err != nil {
+		writeThirdPartyServiceError(c.Ctx.ResponseWriter, err)
+		return
+	}
+	c.Ctx.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
+
+func writeThirdPartyServiceError(w http.ResponseWriter, err error) {
+	switch err.(type) {
+	case *edperror.ThirdPartyServiceDoesNotExistError:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case *edperror.ThirdPartyServiceExistsError:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case *edperror.NonValidThirdPartyServiceError:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		log.Error("service request is failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -32,11 +32,14 @@ func ValidCodebaseRequestData(codebase command.CreateCodebase) *ErrMsg {
 	if codebase.Repository != nil {
 		_, err := valid.Valid(codebase.Repository)
 
-		isAvailable := util.IsGitRepoAvailable(codebase.Repository.Url, codebase.Repository.Login, codebase.Repository.Password)
+		// availability of repository with referenced secret is checked by service as it reads the secret
+		if codebase.Repository.SecretName == "" {
+			isAvailable := util.IsGitRepoAvailable(codebase.Repository.Url, codebase.Repository.Login, codebase.Repository.Password)
 
-		if !isAvailable {
-			err := &validation.Error{Key: "repository", Message: "Repository doesn't exist or invalid login and password."}
-			valid.Errors = append(valid.Errors, err)
+			if !isAvailable {
+				err := &validation.Error{Key: "repository", Message: "Repository doesn't exist or invalid login and password."}
+				valid.Errors = append(valid.Errors, err)
+			}
 		}
 
 		resErr = err
@@ -54,6 +57,11 @@ func ValidCodebaseRequestData(codebase command.CreateCodebase) *ErrMsg {
 	if codebase.Vcs != nil {
 		_, err := valid.Valid(codebase.Vcs)
 		resErr = err
+
+		if codebase.Vcs.SecretName == "" && (codebase.Vcs.Login == "" || codebase.Vcs.Password == "") {
+			err := &validation.Error{Key: "vcs", Message: "either secret name or login and password should be specified for VCS"}
+			valid.Errors = append(valid.Errors, err)
+		}
 	}
 
 	if codebase.Database != nil {
@@ -98,11 +106,12 @@ func CreateCodebaseLogRequestData(app command.CreateCodebase) strings.Builder {
 		app.Name, app.Strategy, app.Lang, app.BuildTool, app.MultiModule, app.Framework))
 
 	if app.Repository != nil {
-		result.WriteString(fmt.Sprintf(", repositoryUrl=%s, repositoryLogin=%s", app.Repository.Url, app.Repository.Login))
+		result.WriteString(fmt.Sprintf(", repositoryUrl=%s, repositoryLogin=%s, repositorySecret=%s",
+			app.Repository.Url, app.Repository.Login, app.Repository.SecretName))
 	}
 
 	if app.Vcs != nil {
-		result.WriteString(fmt.Sprintf(", vcsLogin=%s", app.Vcs.Login))
+		result.WriteString(fmt.Sprintf(", vcsLogin=%s, vcsSecret=%s", app.Vcs.Login, app.Vcs.SecretName))
 	}
 
 	if app.Route != nil {
@@ -230,6 +239,76 @@ func ValidateCDPipelineUpdateRequestData(cdPipeline command.CDPipelineCommand) *
 	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
 }
 
+func ValidateCDPipelineTemplateRequest(template command.CDPipelineTemplateCommand) *ErrMsg {
+	errMsg := &ErrMsg{"An internal error has occurred on server while validating CD Pipeline template's request body.", http.StatusInternalServerError}
+	valid := validation.Validation{}
+	isTemplateValid, err := valid.Valid(template)
+	if err != nil {
+		return errMsg
+	}
+
+	for _, stage := range template.Stages {
+		isStageValid, err := valid.Valid(stage)
+		if err != nil {
+			return errMsg
+		}
+		isTemplateValid = isTemplateValid && isStageValid
+
+		if len(stage.QualityGates) == 0 {
+			valid.Errors = append(valid.Errors, &validation.Error{Key: "qualityGates", Message: "can not be empty"})
+			isTemplateValid = false
+		}
+
+		for _, gate := range stage.QualityGates {
+			isGateValid, err := valid.Valid(gate)
+			if err != nil {
+				return errMsg
+			}
+			if (gate.QualityGateType == "autotests" && gate.AutotestName == nil) ||
+				(gate.QualityGateType == "manual" && (gate.AutotestName != nil || gate.BranchName != nil)) {
+				valid.Errors = append(valid.Errors, &validation.Error{Key: "qualityGates",
+					Message: fmt.Sprintf("%v step has non valid autotest settings", gate.StepName)})
+				isGateValid = false
+			}
+			isTemplateValid = isTemplateValid && isGateValid
+		}
+	}
+
+	if isTemplateValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateQualityGateDecisionRequest(decision command.QualityGateDecisionCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(decision)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating quality gate decision's request body.", http.StatusInternalServerError}
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateFreezeWindowRequest(window command.FreezeWindowCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(window)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating freeze window's request body.", http.StatusInternalServerError}
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
 func CreateErrorResponseBody(valid validation.Validation) []byte {
 	errJson, _ := json.Marshal(extractErrors(valid))
 	errResponse := struct {
@@ -266,9 +345,248 @@ func ValidateCodebaseUpdateRequestData(c command.UpdateCodebaseCommand) *ErrMsg
 			http.StatusInternalServerError}
 	}
 
+	if c.Route != nil {
+		if _, err := v.Valid(c.Route); err != nil {
+			return &ErrMsg{"an error has occurred while validating Codebase update request body.",
+				http.StatusInternalServerError}
+		}
+	}
+
+	if c.Perf != nil && c.Perf.Name != "" {
+		if _, err := v.Valid(c.Perf); err != nil {
+			return &ErrMsg{"an error has occurred while validating Codebase update request body.",
+				http.StatusInternalServerError}
+		}
+	}
+
 	if v.Errors == nil {
 		return nil
 	}
 
 	return &ErrMsg{string(CreateErrorResponseBody(v)), http.StatusBadRequest}
 }
+
+func ValidateBulkImportRequest(c command.BulkImportCodebaseCommand) *ErrMsg {
+	errMsg := &ErrMsg{"An internal error has occurred on server while validating bulk import request body.", http.StatusInternalServerError}
+	valid := validation.Validation{}
+	if _, err := valid.Valid(c); err != nil {
+		return errMsg
+	}
+
+	for _, p := range c.Projects {
+		if _, err := valid.Valid(p); err != nil {
+			return errMsg
+		}
+	}
+
+	if c.Type != "application" && c.Type != "library" {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "type", Message: "codebase type should be: application or library"})
+	}
+
+	if valid.Errors == nil {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateCutReleaseRequest(c command.CutReleaseCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(c)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating cut release request body.", http.StatusInternalServerError}
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateGitServerRequest(gs command.GitServerCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(gs)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating git server's request body.", http.StatusInternalServerError}
+	}
+
+	if !regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$").MatchString(gs.Name) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "name", Message: "name should contain lowercase letters, digits and dashes"})
+		isValid = false
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateJiraServerRequest(js command.JiraServerCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(js)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating jira server's request body.", http.StatusInternalServerError}
+	}
+
+	if !regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$").MatchString(js.Name) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "name", Message: "name should contain lowercase letters, digits and dashes"})
+		isValid = false
+	}
+
+	urlRegexp := regexp.MustCompile("^https?://[^\\s/]+")
+	if !urlRegexp.MatchString(js.ApiUrl) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "apiUrl", Message: "url should start with http:// or https://"})
+		isValid = false
+	}
+	if !urlRegexp.MatchString(js.RootUrl) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "rootUrl", Message: "url should start with http:// or https://"})
+		isValid = false
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidatePerfServerRequest(ps command.PerfServerCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(ps)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating perf server's request body.", http.StatusInternalServerError}
+	}
+
+	if !regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$").MatchString(ps.Name) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "name", Message: "name should contain lowercase letters, digits and dashes"})
+		isValid = false
+	}
+
+	urlRegexp := regexp.MustCompile("^https?://[^\\s/]+")
+	if !urlRegexp.MatchString(ps.ApiUrl) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "apiUrl", Message: "url should start with http:// or https://"})
+		isValid = false
+	}
+	if !urlRegexp.MatchString(ps.RootUrl) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "rootUrl", Message: "url should start with http:// or https://"})
+		isValid = false
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateJenkinsSlaveRequest(s command.JenkinsSlaveCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(s)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating jenkins slave's request body.", http.StatusInternalServerError}
+	}
+
+	if !regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$").MatchString(s.Name) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "name", Message: "name should contain lowercase letters, digits and dashes"})
+		isValid = false
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateJobProvisionerRequest(p command.JobProvisionerCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(p)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating job provisioner's request body.", http.StatusInternalServerError}
+	}
+
+	if !regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$").MatchString(p.Name) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "name", Message: "name should contain lowercase letters, digits and dashes"})
+		isValid = false
+	}
+
+	if p.Scope != "ci" && p.Scope != "cd" {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "scope", Message: "scope should be ci or cd"})
+		isValid = false
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateEDPComponentRequest(c command.EDPComponentCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(c)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating edp component's request body.", http.StatusInternalServerError}
+	}
+
+	if !regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$").MatchString(c.Type) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "type", Message: "type should contain lowercase letters, digits and dashes"})
+		isValid = false
+	}
+
+	if !regexp.MustCompile("^https?://[^\\s/]+").MatchString(c.Url) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "url", Message: "url should start with http:// or https://"})
+		isValid = false
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateThirdPartyServiceRequest(s command.ThirdPartyServiceCommand) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(s)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating service's request body.", http.StatusInternalServerError}
+	}
+
+	if !regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$").MatchString(s.Name) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "name", Message: "name should contain lowercase letters, digits and dashes"})
+		isValid = false
+	}
+
+	if s.SourceType != "helm" && s.SourceType != "template" {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "sourceType", Message: "source type should be either helm or template"})
+		isValid = false
+	}
+
+	if s.Url != "" && !regexp.MustCompile("^https?://[^\\s/]+").MatchString(s.Url) {
+		valid.Errors = append(valid.Errors, &validation.Error{Key: "url", Message: "url should start with http:// or https://"})
+		isValid = false
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
+
+func ValidateMetricsEventRequest(event interface{}) *ErrMsg {
+	valid := validation.Validation{}
+	isValid, err := valid.Valid(event)
+	if err != nil {
+		return &ErrMsg{"An internal error has occurred on server while validating metrics event's request body.", http.StatusInternalServerError}
+	}
+
+	if isValid {
+		return nil
+	}
+
+	return &ErrMsg{string(CreateErrorResponseBody(valid)), http.StatusBadRequest}
+}
@@ -0,0 +1 @@
+drop table if exists cd_pipeline_template;
\ No newline at end of file
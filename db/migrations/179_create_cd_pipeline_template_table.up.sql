@@ -0,0 +1,11 @@
+create table if not exists cd_pipeline_template
+(
+    id          serial not null
+        constraint cd_pipeline_template_pk
+            primary key,
+    name        text   not null
+        constraint cd_pipeline_template_name_uk
+            unique,
+    description text,
+    stages      jsonb  not null
+);
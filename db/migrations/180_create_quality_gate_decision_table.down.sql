@@ -0,0 +1 @@
+drop table if exists quality_gate_decision;
\ No newline at end of file
@@ -0,0 +1,15 @@
+create table if not exists quality_gate_decision
+(
+    id          serial    not null
+        constraint quality_gate_decision_pk
+            primary key,
+    cd_stage_id integer   not null
+        constraint cd_stage_fk
+            references cd_stage
+            on delete cascade,
+    step_name   text      not null,
+    decision    text      not null,
+    comment     text,
+    username    text      not null,
+    date        timestamp not null default now()
+);
@@ -0,0 +1 @@
+drop table if exists freeze_window;
\ No newline at end of file
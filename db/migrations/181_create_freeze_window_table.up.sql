@@ -0,0 +1,15 @@
+create table if not exists freeze_window
+(
+    id          serial    not null
+        constraint freeze_window_pk
+            primary key,
+    cd_stage_id integer   not null
+        constraint cd_stage_fk
+            references cd_stage
+            on delete cascade,
+    reason      text      not null,
+    start_time  timestamp not null,
+    end_time    timestamp not null,
+    recurrence  text      not null default 'none',
+    created_by  text
+);
@@ -0,0 +1,2 @@
+drop table if exists codebase_label;
+drop table if exists cd_pipeline_label;
@@ -0,0 +1,29 @@
+create table if not exists codebase_label
+(
+    id          serial  not null
+        constraint codebase_label_pk
+            primary key,
+    codebase_id integer not null
+        constraint codebase_fk
+            references codebase
+            on delete cascade,
+    key         text    not null,
+    value       text    not null,
+    constraint codebase_label_key_uindex
+        unique (codebase_id, key)
+);
+
+create table if not exists cd_pipeline_label
+(
+    id             serial  not null
+        constraint cd_pipeline_label_pk
+            primary key,
+    cd_pipeline_id integer not null
+        constraint cd_pipeline_fk
+            references cd_pipeline
+            on delete cascade,
+    key            text    not null,
+    value          text    not null,
+    constraint cd_pipeline_label_key_uindex
+        unique (cd_pipeline_id, key)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+alter table if exists codebase drop column if exists archived_at;
\ No newline at end of file
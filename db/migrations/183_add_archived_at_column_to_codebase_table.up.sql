@@ -0,0 +1 @@
+alter table if exists codebase add column if not exists archived_at timestamp;
\ No newline at end of file
@@ -0,0 +1,7 @@
+drop index if exists codebase_name_trgm_idx;
+drop index if exists codebase_description_trgm_idx;
+drop index if exists codebase_git_project_path_trgm_idx;
+drop index if exists codebase_branch_name_trgm_idx;
+drop index if exists codebase_docker_stream_name_trgm_idx;
+drop index if exists cd_pipeline_name_trgm_idx;
+drop index if exists cd_stage_name_trgm_idx;
@@ -0,0 +1,9 @@
+create extension if not exists pg_trgm with schema public;
+
+create index if not exists codebase_name_trgm_idx on codebase using gin (name public.gin_trgm_ops);
+create index if not exists codebase_description_trgm_idx on codebase using gin (description public.gin_trgm_ops);
+create index if not exists codebase_git_project_path_trgm_idx on codebase using gin (git_project_path public.gin_trgm_ops);
+create index if not exists codebase_branch_name_trgm_idx on codebase_branch using gin (name public.gin_trgm_ops);
+create index if not exists codebase_docker_stream_name_trgm_idx on codebase_docker_stream using gin (oc_image_stream_name public.gin_trgm_ops);
+create index if not exists cd_pipeline_name_trgm_idx on cd_pipeline using gin (name public.gin_trgm_ops);
+create index if not exists cd_stage_name_trgm_idx on cd_stage using gin (name public.gin_trgm_ops);
@@ -0,0 +1 @@
+drop index if exists action_log_updated_at_idx;
\ No newline at end of file
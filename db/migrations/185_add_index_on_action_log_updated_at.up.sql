@@ -0,0 +1,2 @@
+create index if not exists action_log_updated_at_idx
+  on action_log (updated_at);
\ No newline at end of file
@@ -0,0 +1 @@
+alter table if exists git_server drop column if exists disabled;
\ No newline at end of file
@@ -0,0 +1 @@
+alter table if exists git_server add column if not exists disabled boolean not null default false;
\ No newline at end of file
@@ -0,0 +1 @@
+alter table if exists third_party_service drop column if exists parameters, drop column if exists source_type, drop column if exists source_reference;
\ No newline at end of file
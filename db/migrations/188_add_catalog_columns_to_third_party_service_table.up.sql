@@ -0,0 +1 @@
+alter table if exists third_party_service add column if not exists parameters text, add column if not exists source_type text, add column if not exists source_reference text;
\ No newline at end of file
@@ -0,0 +1 @@
+alter type "action" add value 'deploy';
\ No newline at end of file
@@ -0,0 +1 @@
+alter type "action" add value 'build';
\ No newline at end of file
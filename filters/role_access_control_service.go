@@ -33,18 +33,143 @@ func init() {
 		"POST /admin/edp/codebase$":                   {administrator},
 		"POST /admin/edp/stage$":                      {administrator},
 		"POST /admin/edp/cd-pipeline/delete":          {administrator},
+		"GET /admin/edp/cd-pipeline/([^/]*)/clone":    {administrator},
+		"POST /admin/edp/cd-pipeline/([^/]*)/clone":   {administrator},
 		"GET /admin/edp/diagram/overview":             {administrator, developer},
 
+		"GET /admin/edp/cd-pipeline-template/overview": {administrator, developer},
+		"POST /admin/edp/cd-pipeline-template$":        {administrator},
+		"POST /admin/edp/cd-pipeline-template/delete":  {administrator},
+
+		"POST /admin/edp/cd-pipeline/([^/]*)/stage/([^/]*)/gates/([^/]*)/decision$": {administrator},
+		"POST /admin/edp/cd-pipeline/([^/]*)/freeze-window$":                        {administrator},
+		"POST /admin/edp/cd-pipeline/([^/]*)/freeze-window/delete$":                 {administrator},
+
+		"GET /admin/edp/codebase/import$":   {administrator},
+		"POST /admin/edp/codebase/import$":  {administrator},
+		"POST /admin/edp/codebase/release$": {administrator},
+
+		"GET /admin/edp/codebase/([^/]*)/update$":  {administrator},
+		"POST /admin/edp/codebase/([^/]*)/update$": {administrator},
+
 		"GET /api/v1/edp/vcs$":                               {administrator, developer},
 		"GET /api/v1/edp/codebase":                           {administrator, developer},
 		"GET /api/v1/edp/codebase/([^/]*)$":                  {administrator, developer},
 		"GET /api/v1/edp/cd-pipeline/([^/]*)$":               {administrator, developer},
 		"GET /api/v1/edp/cd-pipeline/([^/]*)/stage/([^/]*)$": {administrator, developer},
 		"POST /api/v1/edp/codebase$":                         {administrator},
+		"PUT /api/v1/edp/codebase/([^/]*)$":                  {administrator},
+		"POST /api/v1/edp/codebase/import$":                  {administrator},
+		"POST /api/v1/edp/codebase/release$":                 {administrator},
+		"POST /api/v1/edp/codebase/([^/]*)/release$":         {administrator},
 		"POST /api/v1/edp/cd-pipeline$":                      {administrator},
 		"PUT /api/v1/edp/cd-pipeline/([^/]*)$":               {administrator},
+		"DELETE /api/v1/edp/cd-pipeline/([^/]*)$":            {administrator},
+		"POST /api/v1/edp/cd-pipeline/([^/]*)/clone$":        {administrator},
 		"DELETE /api/v1/edp/codebase$":                       {administrator},
 		"DELETE /api/v1/edp/stage$":                          {administrator},
+
+		"GET /api/v1/edp/cd-pipeline-template":             {administrator, developer},
+		"POST /api/v1/edp/cd-pipeline-template$":           {administrator},
+		"PUT /api/v1/edp/cd-pipeline-template/([^/]*)$":    {administrator},
+		"DELETE /api/v1/edp/cd-pipeline-template/([^/]*)$": {administrator},
+
+		"GET /api/v1/edp/cd-pipeline/([^/]*)/stage/([^/]*)/gates/([^/]*)/decision":   {administrator, developer},
+		"POST /api/v1/edp/cd-pipeline/([^/]*)/stage/([^/]*)/gates/([^/]*)/decision$": {administrator},
+
+		"GET /api/v1/edp/cd-pipeline/([^/]*)/stage/([^/]*)/freeze":                    {administrator, developer},
+		"GET /api/v1/edp/cd-pipeline/([^/]*)/stage/([^/]*)/freeze-window$":            {administrator, developer},
+		"POST /api/v1/edp/cd-pipeline/([^/]*)/stage/([^/]*)/freeze-window$":           {administrator},
+		"DELETE /api/v1/edp/cd-pipeline/([^/]*)/stage/([^/]*)/freeze-window/([^/]*)$": {administrator},
+
+		"GET /api/v1/edp/cd-pipeline($|\\?)":          {administrator, developer},
+		"PUT /api/v1/edp/codebase/([^/]*)/labels$":    {administrator},
+		"PUT /api/v1/edp/cd-pipeline/([^/]*)/labels$": {administrator},
+
+		"GET /admin/edp/library/([^/]*)/impact":     {administrator, developer},
+		"GET /api/v1/edp/dependency($|\\?)":         {administrator, developer},
+		"GET /api/v1/edp/dependency/([^/]*)/impact": {administrator, developer},
+
+		"GET /admin/edp/codebase/archive":            {administrator, developer},
+		"POST /admin/edp/codebase/archive$":          {administrator},
+		"POST /admin/edp/codebase/restore$":          {administrator},
+		"POST /api/v1/edp/codebase/([^/]*)/archive$": {administrator},
+		"POST /api/v1/edp/codebase/([^/]*)/restore$": {administrator},
+
+		"GET /admin/edp/codebase/temp-secret":   {administrator},
+		"POST /admin/edp/codebase/temp-secret/": {administrator},
+		"GET /api/v1/edp/temp-secret":           {administrator},
+		"POST /api/v1/edp/temp-secret/":         {administrator},
+		"DELETE /api/v1/edp/temp-secret/":       {administrator},
+
+		"GET /api/v1/edp/graph($|\\?)": {administrator, developer},
+
+		"GET /api/v1/edp/git-ref/": {administrator, developer},
+
+		"POST /api/v1(/edp)?/repository/available$": {administrator},
+		"POST /api/v1/edp/repository/refs$":         {administrator},
+
+		"GET /admin/edp/search($|\\?)":  {administrator, developer},
+		"GET /api/v1/edp/search($|\\?)": {administrator, developer},
+
+		"GET /admin/edp/metrics/dora($|\\?)":      {administrator, developer},
+		"GET /api/v1/edp/metrics/dora($|\\?)":     {administrator, developer},
+		"POST /api/v1/edp/metrics/events/deploy$": {administrator},
+		"POST /api/v1/edp/metrics/events/build$":  {administrator},
+
+		"GET /admin/edp/(application|library|autotest|cd-pipeline)/export($|\\?)": {administrator, developer},
+
+		"GET /admin/edp/git-server/overview":       {administrator, developer},
+		"GET /admin/edp/git-server/create":         {administrator},
+		"GET /admin/edp/git-server/([^/]*)/update": {administrator},
+		"POST /admin/edp/git-server":               {administrator},
+		"GET /api/v1/edp/git-server":               {administrator, developer},
+		"POST /api/v1/edp/git-server":              {administrator},
+		"PUT /api/v1/edp/git-server/([^/]*)$":      {administrator},
+
+		"GET /admin/edp/jira-server/overview":                    {administrator, developer},
+		"GET /admin/edp/jira-server/create":                      {administrator},
+		"GET /admin/edp/jira-server/([^/]*)/update":              {administrator},
+		"POST /admin/edp/jira-server":                            {administrator},
+		"GET /api/v1/edp/jira-server":                            {administrator, developer},
+		"POST /api/v1/edp/jira-server":                           {administrator},
+		"PUT /api/v1/edp/jira-server/([^/]*)$":                   {administrator},
+		"DELETE /api/v1/edp/jira-server/([^/]*)$":                {administrator},
+		"GET /api/v1/edp/codebase/([^/]*)/ticket-pattern($|\\?)": {administrator, developer},
+
+		"GET /admin/edp/perf-server/overview":       {administrator, developer},
+		"GET /admin/edp/perf-server/create":         {administrator},
+		"GET /admin/edp/perf-server/([^/]*)/update": {administrator},
+		"POST /admin/edp/perf-server":               {administrator},
+		"GET /api/v1/edp/perf-server":               {administrator, developer},
+		"POST /api/v1/edp/perf-server":              {administrator},
+		"PUT /api/v1/edp/perf-server/([^/]*)$":      {administrator},
+		"DELETE /api/v1/edp/perf-server/([^/]*)$":   {administrator},
+
+		"GET /admin/edp/jenkins/overview":                            {administrator, developer},
+		"GET /admin/edp/jenkins/(slave|job-provisioner)/(.*)/update": {administrator},
+		"POST /admin/edp/jenkins/":                                   {administrator},
+		"GET /api/v1/edp/jenkins/":                                   {administrator, developer},
+		"POST /api/v1/edp/jenkins/":                                  {administrator},
+		"PUT /api/v1/edp/jenkins/":                                   {administrator},
+		"DELETE /api/v1/edp/jenkins/":                                {administrator},
+
+		"GET /admin/edp/edp-component/overview":       {administrator, developer},
+		"GET /admin/edp/edp-component/create":         {administrator},
+		"GET /admin/edp/edp-component/([^/]*)/update": {administrator},
+		"POST /admin/edp/edp-component":               {administrator},
+		"GET /api/v1/edp/edp-component":               {administrator, developer},
+		"POST /api/v1/edp/edp-component":              {administrator},
+		"PUT /api/v1/edp/edp-component/([^/]*)$":      {administrator},
+		"DELETE /api/v1/edp/edp-component/([^/]*)$":   {administrator},
+
+		"GET /admin/edp/service/create":         {administrator},
+		"GET /admin/edp/service/([^/]*)/update": {administrator},
+		"POST /admin/edp/service":               {administrator},
+		"GET /api/v1/edp/service":               {administrator, developer},
+		"POST /api/v1/edp/service":              {administrator},
+		"PUT /api/v1/edp/service/([^/]*)$":      {administrator},
+		"DELETE /api/v1/edp/service/([^/]*)$":   {administrator},
 	}
 }
 
@@ -163,6 +163,11 @@ func getOpenshiftApplicationClient() (*appsV1Client.AppsV1Client, error) {
 	return clientset, nil
 }
 
+// CreateEDPRestClient creates client of EDP custom resources for API server on the host
+func CreateEDPRestClient(host string) (*rest.RESTClient, error) {
+	return createCrdClient(&rest.Config{Host: host})
+}
+
 func createCrdClient(cfg *rest.Config) (*rest.RESTClient, error) {
 	scheme := runtime.NewScheme()
 	SchemeBuilder := runtime.NewSchemeBuilder(addKnownTypes)
@@ -187,6 +192,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&edpv1alpha1.CodebaseList{},
 		&edpv1alpha1.CodebaseBranch{},
 		&edpv1alpha1.CodebaseBranchList{},
+		&edpv1alpha1.GitServer{},
+		&edpv1alpha1.GitServerList{},
+		&edpv1alpha1.JiraServer{},
+		&edpv1alpha1.JiraServerList{},
+		&edpv1alpha1.EDPComponent{},
+		&edpv1alpha1.EDPComponentList{},
 		&edppipelinesv1alpha1.CDPipeline{},
 		&edppipelinesv1alpha1.CDPipelineList{},
 		&edppipelinesv1alpha1.Stage{},
@@ -7,11 +7,25 @@ type CDPipelineCommand struct {
 	Applications         []models.CDPipelineApplicationCommand `json:"applications" valid:"Required"`
 	ThirdPartyServices   []string                              `json:"services"`
 	Stages               []CDStageCommand                      `json:"stages"`
+	StageServices        map[string][]string                   `json:"stageServices"`
 	ApplicationToApprove []string                              `json:"-"`
 	Username             string                                `json:"username"`
+	TemplateRef          string                                `json:"templateRef"`
+	TemplateParams       CDPipelineTemplateParams              `json:"templateParams"`
 }
 
 type DeleteStageCommand struct {
 	Name           string `json:"name"`
 	CDPipelineName string `json:"pipelineName"`
 }
+
+type CloneCDPipelineCommand struct {
+	Name         string                        `json:"name"`
+	Applications []CDPipelineApplicationBranch `json:"applications"`
+	Username     string                        `json:"-"`
+}
+
+type CDPipelineApplicationBranch struct {
+	ApplicationName string `json:"appName"`
+	BranchName      string `json:"branchName"`
+}
@@ -0,0 +1,11 @@
+package command
+
+type CDPipelineTemplateCommand struct {
+	Name        string           `json:"name" valid:"Required;Match(/^[a-z0-9]([-a-z0-9]*[a-z0-9])$/)"`
+	Description string           `json:"description"`
+	Stages      []CDStageCommand `json:"stages" valid:"Required"`
+}
+
+type CDPipelineTemplateParams struct {
+	AutotestBranches map[string]string `json:"autotestBranches"`
+}
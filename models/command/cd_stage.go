@@ -13,4 +13,5 @@ type CDStageCommand struct {
 	QualityGates    []edppipelinesv1alpha1.QualityGate `json:"qualityGates" valid:"Required"`
 	Username        string                             `json:"username"`
 	JobProvisioning string                             `json:"jobProvisioning"`
+	Services        []string                           `json:"services"`
 }
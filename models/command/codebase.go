@@ -35,14 +35,16 @@ type Perf struct {
 }
 
 type Repository struct {
-	Url      string `json:"url,omitempty" valid:"Required;Match(/(?:^git|^ssh|^https?|^git@[-\\w.]+):(\\/\\/)?(.*?)(\\.git)(\\/?|\\#[-\\d\\w._]+?)$/)"`
-	Login    string `json:"login,omitempty"`
-	Password string `json:"password,omitempty"`
+	Url        string `json:"url,omitempty" valid:"Required;Match(/(?:^git|^ssh|^https?|^git@[-\\w.]+):(\\/\\/)?(.*?)(\\.git)(\\/?|\\#[-\\d\\w._]+?)$/)"`
+	Login      string `json:"login,omitempty"`
+	Password   string `json:"password,omitempty"`
+	SecretName string `json:"secretName,omitempty"`
 }
 
 type Vcs struct {
-	Login    string `json:"login,omitempty" valid:"Required"`
-	Password string `json:"password,omitempty" valid:"Required"`
+	Login      string `json:"login,omitempty"`
+	Password   string `json:"password,omitempty"`
+	SecretName string `json:"secretName,omitempty"`
 }
 
 type Route struct {
@@ -67,7 +69,19 @@ type Versioning struct {
 }
 
 type UpdateCodebaseCommand struct {
-	Name               string `valid:"Required;Match(/^[a-z][a-z0-9-]*[a-z0-9]$/)"`
-	CommitMessageRegex string `valid:"Required"`
-	TicketNameRegex    string `valid:"Required"`
+	Name               string  `json:"-" valid:"Required;Match(/^[a-z][a-z0-9-]*[a-z0-9]$/)"`
+	CommitMessageRegex string  `json:"commitMessagePattern" valid:"Required"`
+	TicketNameRegex    string  `json:"ticketNamePattern" valid:"Required"`
+	Description        *string `json:"description,omitempty"`
+	GitServer          *string `json:"gitServer,omitempty"`
+	JiraServer         *string `json:"jiraServer,omitempty"`
+	JenkinsSlave       *string `json:"jenkinsSlave,omitempty"`
+	JobProvisioning    *string `json:"jobProvisioning,omitempty"`
+	CiTool             *string `json:"ciTool,omitempty"`
+	Route              *Route  `json:"route,omitempty"`
+	Perf               *Perf   `json:"perf,omitempty"`
+	DefaultBranch      *string `json:"defaultBranch,omitempty"`
+	Lang               *string `json:"lang,omitempty"`
+	Strategy           *string `json:"strategy,omitempty"`
+	Type               *string `json:"type,omitempty"`
 }
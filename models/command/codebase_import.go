@@ -0,0 +1,27 @@
+package command
+
+type BulkImportCodebaseCommand struct {
+	GitServer       string              `json:"gitServer" valid:"Required"`
+	PathPrefix      string              `json:"pathPrefix" valid:"Match(/^$|^\\/.*$/)"`
+	Type            string              `json:"type" valid:"Required"`
+	Lang            string              `json:"lang"`
+	Framework       *string             `json:"framework,omitempty"`
+	BuildTool       string              `json:"buildTool"`
+	Versioning      Versioning          `json:"versioning"`
+	DefaultBranch   string              `json:"defaultBranch" valid:"Required"`
+	CiTool          string              `json:"ciTool" valid:"Required"`
+	JenkinsSlave    *string             `json:"jenkinsSlave,omitempty"`
+	JobProvisioning *string             `json:"jobProvisioning,omitempty"`
+	JiraServer      *string             `json:"jiraServer,omitempty"`
+	Projects        []BulkImportProject `json:"projects"`
+	Csv             string              `json:"csv,omitempty"`
+	Username        string              `json:"-"`
+}
+
+type BulkImportProject struct {
+	Path       string      `json:"path" valid:"Required"`
+	Lang       string      `json:"lang,omitempty"`
+	Framework  *string     `json:"framework,omitempty"`
+	BuildTool  string      `json:"buildTool,omitempty"`
+	Versioning *Versioning `json:"versioning,omitempty"`
+}
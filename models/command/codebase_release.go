@@ -0,0 +1,12 @@
+package command
+
+const (
+	MinorBump = "minor"
+	MajorBump = "major"
+)
+
+type CutReleaseCommand struct {
+	Codebases []string `json:"codebases" valid:"Required"`
+	Bump      string   `json:"bump" valid:"Match(/^(minor|major)?$/)"`
+	Username  string   `json:"-"`
+}
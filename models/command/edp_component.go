@@ -0,0 +1,10 @@
+package command
+
+type EDPComponentCommand struct {
+	Type     string `json:"type"`
+	Url      string `json:"url" valid:"Required;MaxSize(255)"`
+	Icon     string `json:"icon,omitempty"`
+	Visible  bool   `json:"visible"`
+	Ordinal  int    `json:"ordinal" valid:"Min(0)"`
+	Username string `json:"-"`
+}
@@ -0,0 +1,11 @@
+package command
+
+import "time"
+
+type FreezeWindowCommand struct {
+	Reason     string    `json:"reason" valid:"Required;MaxSize(255)"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Recurrence string    `json:"recurrence" valid:"Match(/^(none|daily|weekly)?$/)"`
+	Username   string    `json:"-"`
+}
@@ -0,0 +1,12 @@
+package command
+
+type GitServerCommand struct {
+	Name       string `json:"name"`
+	Hostname   string `json:"hostname" valid:"Required;MaxSize(255)"`
+	SshPort    int32  `json:"sshPort" valid:"Range(1,65535)"`
+	HttpsPort  int32  `json:"httpsPort" valid:"Range(1,65535)"`
+	User       string `json:"user" valid:"Required"`
+	SshKey     string `json:"sshKey,omitempty"`
+	KnownHosts string `json:"knownHosts,omitempty"`
+	Username   string `json:"-"`
+}
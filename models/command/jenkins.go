@@ -0,0 +1,14 @@
+package command
+
+type JenkinsSlaveCommand struct {
+	Name     string `json:"name"`
+	Template string `json:"template" valid:"Required"`
+	Username string `json:"-"`
+}
+
+type JobProvisionerCommand struct {
+	Name     string `json:"name"`
+	Scope    string `json:"scope"`
+	Script   string `json:"script" valid:"Required"`
+	Username string `json:"-"`
+}
@@ -0,0 +1,10 @@
+package command
+
+type JiraServerCommand struct {
+	Name     string `json:"name"`
+	ApiUrl   string `json:"apiUrl" valid:"Required;MaxSize(255)"`
+	RootUrl  string `json:"rootUrl" valid:"Required;MaxSize(255)"`
+	User     string `json:"user" valid:"Required"`
+	Password string `json:"password,omitempty"`
+	Username string `json:"-"`
+}
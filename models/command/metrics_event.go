@@ -0,0 +1,18 @@
+package command
+
+import "time"
+
+type DeploymentEventCommand struct {
+	Pipeline string     `json:"pipeline" valid:"Required"`
+	Stage    string     `json:"stage" valid:"Required"`
+	Result   string     `json:"result" valid:"Required;Match(/^(success|error)$/)"`
+	Time     *time.Time `json:"time"`
+	Username string     `json:"-"`
+}
+
+type BuildEventCommand struct {
+	Codebase string     `json:"codebase" valid:"Required"`
+	Result   string     `json:"result" valid:"Required;Match(/^(success|error)$/)"`
+	Time     *time.Time `json:"time"`
+	Username string     `json:"-"`
+}
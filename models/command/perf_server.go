@@ -0,0 +1,12 @@
+package command
+
+type PerfServerCommand struct {
+	Name        string   `json:"name"`
+	ApiUrl      string   `json:"apiUrl" valid:"Required;MaxSize(255)"`
+	RootUrl     string   `json:"rootUrl" valid:"Required;MaxSize(255)"`
+	ProjectName string   `json:"projectName" valid:"Required"`
+	User        string   `json:"user" valid:"Required"`
+	Password    string   `json:"password,omitempty"`
+	DataSources []string `json:"dataSources" valid:"Required"`
+	Username    string   `json:"-"`
+}
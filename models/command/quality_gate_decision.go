@@ -0,0 +1,7 @@
+package command
+
+type QualityGateDecisionCommand struct {
+	Decision string `json:"decision" valid:"Required;Match(/^(approve|reject)$/)"`
+	Comment  string `json:"comment" valid:"MaxSize(1000)"`
+	Username string `json:"-"`
+}
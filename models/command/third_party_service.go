@@ -0,0 +1,12 @@
+package command
+
+type ThirdPartyServiceCommand struct {
+	Name            string            `json:"name"`
+	Version         string            `json:"version" valid:"Required;MaxSize(64)"`
+	Description     string            `json:"description" valid:"Required"`
+	Url             string            `json:"url"`
+	SourceType      string            `json:"sourceType" valid:"Required"`
+	SourceReference string            `json:"sourceReference" valid:"Required"`
+	Parameters      map[string]string `json:"parameters"`
+	Username        string            `json:"-"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+type CDPipelineDeletionPlan struct {
+	Name         string              `json:"name"`
+	Deletable    bool                `json:"deletable"`
+	Stages       []StageDeletionPlan `json:"stages"`
+	JenkinsJobs  []string            `json:"jenkinsJobs"`
+	Dependents   []CDStageDependents `json:"dependents"`
+	FrozenStages []string            `json:"frozenStages"`
+}
+
+type StageDeletionPlan struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	JenkinsJob string `json:"jenkinsJob"`
+}
+
+type CDStageDependents struct {
+	Stage     string   `json:"stage"`
+	Pipelines []string `json:"pipelines"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+const (
+	ImportValid   = "valid"
+	ImportInvalid = "invalid"
+	ImportCreated = "created"
+	ImportFailed  = "failed"
+)
+
+type BulkImportReport struct {
+	Valid bool             `json:"valid"`
+	Items []BulkImportItem `json:"items"`
+}
+
+type BulkImportItem struct {
+	Path    string `json:"path"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+const (
+	ReleaseCreated = "created"
+	ReleaseFailed  = "failed"
+)
+
+type CodebaseRelease struct {
+	Codebase       string `json:"codebase"`
+	ReleaseBranch  string `json:"releaseBranch,omitempty"`
+	ReleaseVersion string `json:"releaseVersion,omitempty"`
+	NextVersion    string `json:"nextVersion,omitempty"`
+	Status         string `json:"status"`
+	Message        string `json:"message,omitempty"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+type DependencyGraph struct {
+	Nodes  []DependencyNode  `json:"nodes"`
+	Edges  []DependencyEdge  `json:"edges"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+type DependencyNode struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type DependencyEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Manifest string `json:"manifest"`
+}
+
+type LibraryImpact struct {
+	Library   string             `json:"library"`
+	Codebases []ImpactedCodebase `json:"codebases"`
+}
+
+type ImpactedCodebase struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Direct    bool     `json:"direct"`
+	Pipelines []string `json:"pipelines"`
+}
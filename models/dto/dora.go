@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type DoraReport struct {
+	From      time.Time              `json:"from"`
+	To        time.Time              `json:"to"`
+	Total     DoraMetrics            `json:"total"`
+	Codebases map[string]DoraMetrics `json:"codebases"`
+	Teams     map[string]DoraMetrics `json:"teams"`
+	Pipelines map[string]DoraMetrics `json:"pipelines"`
+}
+
+type DoraMetrics struct {
+	Deployments         int     `json:"deployments"`
+	FailedDeployments   int     `json:"failedDeployments"`
+	DeploymentFrequency float64 `json:"deploymentFrequency"`
+	LeadTimeHours       float64 `json:"leadTimeHours"`
+	ChangeFailureRate   float64 `json:"changeFailureRate"`
+	TimeToRestoreHours  float64 `json:"timeToRestoreHours"`
+}
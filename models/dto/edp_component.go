@@ -0,0 +1,10 @@
+package dto
+
+type EDPComponent struct {
+	Type    string `json:"type"`
+	Url     string `json:"url"`
+	Icon    string `json:"icon"`
+	Visible bool   `json:"visible"`
+	Ordinal int    `json:"ordinal"`
+	Core    bool   `json:"core"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+type GitServer struct {
+	Name         string `json:"name"`
+	Hostname     string `json:"hostname"`
+	SshPort      int32  `json:"sshPort"`
+	HttpsPort    int32  `json:"httpsPort"`
+	User         string `json:"user"`
+	SshKeySecret string `json:"sshKeySecret"`
+	Available    bool   `json:"available"`
+	Disabled     bool   `json:"disabled"`
+}
+
+type GitServerConnectionTest struct {
+	Ssh   ConnectionCheck `json:"ssh"`
+	Https ConnectionCheck `json:"https"`
+}
+
+type ConnectionCheck struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+const (
+	CodebaseNode      = "codebase"
+	BranchNode        = "branch"
+	DockerStreamNode  = "docker_stream"
+	PipelineNode      = "pipeline"
+	StageNode         = "stage"
+	AutotestGateNode  = "autotest_gate"
+	LibrarySourceNode = "library_source"
+)
+
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+type GraphNode struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type GraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type GraphScope struct {
+	Pipeline string
+	Codebase string
+}
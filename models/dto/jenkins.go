@@ -0,0 +1,15 @@
+package dto
+
+type JenkinsSlave struct {
+	Name      string   `json:"name"`
+	Template  string   `json:"template"`
+	Codebases []string `json:"codebases"`
+}
+
+type JobProvisioner struct {
+	Name      string   `json:"name"`
+	Scope     string   `json:"scope"`
+	Script    string   `json:"script"`
+	Codebases []string `json:"codebases"`
+	Stages    []string `json:"stages"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+type JiraServer struct {
+	Name           string `json:"name"`
+	ApiUrl         string `json:"apiUrl"`
+	RootUrl        string `json:"rootUrl"`
+	CredentialName string `json:"credentialName"`
+	Available      bool   `json:"available"`
+}
+
+type JiraConnectionTest struct {
+	Connection  ConnectionCheck `json:"connection"`
+	Permissions ConnectionCheck `json:"permissions"`
+}
+
+type TicketPatternCheck struct {
+	Project    string   `json:"project"`
+	Pattern    string   `json:"pattern"`
+	Matched    []string `json:"matched"`
+	NotMatched []string `json:"notMatched"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+type PerfServer struct {
+	Name           string   `json:"name"`
+	ApiUrl         string   `json:"apiUrl"`
+	RootUrl        string   `json:"rootUrl"`
+	ProjectName    string   `json:"projectName"`
+	CredentialName string   `json:"credentialName"`
+	DataSources    []string `json:"dataSources"`
+	Available      bool     `json:"available"`
+}
+
+type PerfConnectionTest struct {
+	Connection ConnectionCheck `json:"connection"`
+	Project    ConnectionCheck `json:"project"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+const (
+	RepositoryAuthFailed      = "auth_failed"
+	RepositoryNotFound        = "not_found"
+	RepositoryTimeout         = "timeout"
+	RepositoryEmpty           = "empty"
+	RepositoryHostKeyRejected = "host_key_rejected"
+	RepositoryUnreachable     = "unreachable"
+)
+
+type RepositoryAvailability struct {
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+type GitRefs struct {
+	Branches []GitRef `json:"branches"`
+	Tags     []GitRef `json:"tags"`
+}
+
+type GitRef struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+}
+
+type GitCommit struct {
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+type SearchResult struct {
+	Query  string        `json:"query"`
+	Groups []SearchGroup `json:"groups"`
+}
+
+type SearchGroup struct {
+	Kind  string       `json:"kind"`
+	Items []SearchItem `json:"items"`
+}
+
+type SearchItem struct {
+	Name      string   `json:"name"`
+	Parent    string   `json:"parent,omitempty"`
+	Link      string   `json:"link"`
+	Rank      float64  `json:"rank"`
+	Pipelines []string `json:"pipelines,omitempty"`
+}
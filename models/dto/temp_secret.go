@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+const (
+	TempSecretPending  = "pending"
+	TempSecretConsumed = "consumed"
+	TempSecretOrphaned = "orphaned"
+)
+
+type TempSecret struct {
+	Name      string    `json:"name"`
+	Codebase  string    `json:"codebase"`
+	State     string    `json:"state"`
+	Owned     bool      `json:"owned"`
+	CreatedAt time.Time `json:"createdAt"`
+}
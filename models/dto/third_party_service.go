@@ -0,0 +1,19 @@
+package dto
+
+type ThirdPartyService struct {
+	Name            string                   `json:"name"`
+	Version         string                   `json:"version"`
+	Description     string                   `json:"description"`
+	Url             string                   `json:"url"`
+	Icon            string                   `json:"-"`
+	SourceType      string                   `json:"sourceType"`
+	SourceReference string                   `json:"sourceReference"`
+	Parameters      map[string]string        `json:"parameters"`
+	Usages          []ThirdPartyServiceUsage `json:"usages"`
+}
+
+type ThirdPartyServiceUsage struct {
+	Pipeline string `json:"pipeline"`
+	Stage    string `json:"stage"`
+	Version  string `json:"version"`
+}
@@ -1,5 +1,10 @@
 package error
 
+import (
+	"fmt"
+	"time"
+)
+
 type CDPipelineExistsError struct {
 }
 
@@ -54,3 +59,479 @@ func (e *CodebaseWithGitUrlPathAlreadyExistsError) Error() string {
 func NewCodebaseWithGitUrlPathAlreadyExistsError() error {
 	return &CodebaseWithGitUrlPathAlreadyExistsError{}
 }
+
+type NonValidApplicationMappingError struct {
+	Application string
+}
+
+func (e *NonValidApplicationMappingError) Error() string {
+	return fmt.Sprintf("application %v isn't used in the source cd pipeline", e.Application)
+}
+
+func NewNonValidApplicationMappingError(application string) error {
+	return &NonValidApplicationMappingError{Application: application}
+}
+
+type CDPipelineTemplateExistsError struct {
+}
+
+func (e *CDPipelineTemplateExistsError) Error() string {
+	return "cd pipeline template already exists"
+}
+
+func NewCDPipelineTemplateExistsError() error {
+	return &CDPipelineTemplateExistsError{}
+}
+
+type CDPipelineTemplateDoesNotExistError struct {
+}
+
+func (e *CDPipelineTemplateDoesNotExistError) Error() string {
+	return "cd pipeline template doesn't exist"
+}
+
+func NewCDPipelineTemplateDoesNotExistError() error {
+	return &CDPipelineTemplateDoesNotExistError{}
+}
+
+type NonValidTemplateParamsError struct {
+	Message string
+}
+
+func (e *NonValidTemplateParamsError) Error() string {
+	return e.Message
+}
+
+func NewNonValidTemplateParamsError(message string) error {
+	return &NonValidTemplateParamsError{Message: message}
+}
+
+type QualityGateDoesNotExistError struct {
+	Stage string
+	Step  string
+}
+
+func (e *QualityGateDoesNotExistError) Error() string {
+	return fmt.Sprintf("manual quality gate %v doesn't exist in stage %v", e.Step, e.Stage)
+}
+
+func NewQualityGateDoesNotExistError(stage, step string) error {
+	return &QualityGateDoesNotExistError{Stage: stage, Step: step}
+}
+
+type CDStageDoesNotExistError struct {
+	Stage string
+}
+
+func (e *CDStageDoesNotExistError) Error() string {
+	return fmt.Sprintf("cd stage %v doesn't exist", e.Stage)
+}
+
+func NewCDStageDoesNotExistError(stage string) error {
+	return &CDStageDoesNotExistError{Stage: stage}
+}
+
+type NonValidFreezeWindowError struct {
+	Message string
+}
+
+func (e *NonValidFreezeWindowError) Error() string {
+	return e.Message
+}
+
+func NewNonValidFreezeWindowError(message string) error {
+	return &NonValidFreezeWindowError{Message: message}
+}
+
+type StageFrozenError struct {
+	Stage  string
+	Reason string
+	Until  time.Time
+}
+
+func (e *StageFrozenError) Error() string {
+	return fmt.Sprintf("cd stage %v is frozen until %v: %v", e.Stage, e.Until.Format(time.RFC3339), e.Reason)
+}
+
+func NewStageFrozenError(stage, reason string, until time.Time) error {
+	return &StageFrozenError{Stage: stage, Reason: reason, Until: until}
+}
+
+type FreezeWindowDoesNotExistError struct {
+	Id int
+}
+
+func (e *FreezeWindowDoesNotExistError) Error() string {
+	return fmt.Sprintf("freeze window %v doesn't exist", e.Id)
+}
+
+func NewFreezeWindowDoesNotExistError(id int) error {
+	return &FreezeWindowDoesNotExistError{Id: id}
+}
+
+type CodebaseDoesNotExistError struct {
+	Name string
+}
+
+func (e *CodebaseDoesNotExistError) Error() string {
+	return fmt.Sprintf("codebase %v doesn't exist", e.Name)
+}
+
+func NewCodebaseDoesNotExistError(name string) error {
+	return &CodebaseDoesNotExistError{Name: name}
+}
+
+type ImmutableCodebaseFieldError struct {
+	Field string
+}
+
+func (e *ImmutableCodebaseFieldError) Error() string {
+	return fmt.Sprintf("%v of codebase can't be changed", e.Field)
+}
+
+func NewImmutableCodebaseFieldError(field string) error {
+	return &ImmutableCodebaseFieldError{Field: field}
+}
+
+type NonValidCodebaseUpdateError struct {
+	Message string
+}
+
+func (e *NonValidCodebaseUpdateError) Error() string {
+	return e.Message
+}
+
+func NewNonValidCodebaseUpdateError(message string) error {
+	return &NonValidCodebaseUpdateError{Message: message}
+}
+
+type NonValidBulkImportError struct {
+	Message string
+}
+
+func (e *NonValidBulkImportError) Error() string {
+	return e.Message
+}
+
+func NewNonValidBulkImportError(message string) error {
+	return &NonValidBulkImportError{Message: message}
+}
+
+type NonValidReleaseError struct {
+	Codebase string
+	Message  string
+}
+
+func (e *NonValidReleaseError) Error() string {
+	return fmt.Sprintf("couldn't cut release of codebase %v: %v", e.Codebase, e.Message)
+}
+
+func NewNonValidReleaseError(codebase, message string) error {
+	return &NonValidReleaseError{Codebase: codebase, Message: message}
+}
+
+type NonValidLabelError struct {
+	Message string
+}
+
+func (e *NonValidLabelError) Error() string {
+	return e.Message
+}
+
+func NewNonValidLabelError(message string) error {
+	return &NonValidLabelError{Message: message}
+}
+
+type NonValidArchiveError struct {
+	Codebase string
+	Message  string
+}
+
+func (e *NonValidArchiveError) Error() string {
+	return fmt.Sprintf("couldn't change archive state of codebase %v: %v", e.Codebase, e.Message)
+}
+
+func NewNonValidArchiveError(codebase, message string) error {
+	return &NonValidArchiveError{Codebase: codebase, Message: message}
+}
+
+type GraphScopeNotFoundError struct {
+	Node string
+}
+
+func (e *GraphScopeNotFoundError) Error() string {
+	return fmt.Sprintf("%v isn't found in graph", e.Node)
+}
+
+func NewGraphScopeNotFoundError(node string) error {
+	return &GraphScopeNotFoundError{Node: node}
+}
+
+type NonValidExportParamsError struct {
+	Message string
+}
+
+func (e *NonValidExportParamsError) Error() string {
+	return e.Message
+}
+
+func NewNonValidExportParamsError(message string) error {
+	return &NonValidExportParamsError{Message: message}
+}
+
+type GitServerExistsError struct {
+	Name string
+}
+
+func (e *GitServerExistsError) Error() string {
+	return fmt.Sprintf("git server %v already exists", e.Name)
+}
+
+func NewGitServerExistsError(name string) error {
+	return &GitServerExistsError{Name: name}
+}
+
+type GitServerDoesNotExistError struct {
+	Name string
+}
+
+func (e *GitServerDoesNotExistError) Error() string {
+	return fmt.Sprintf("git server %v doesn't exist", e.Name)
+}
+
+func NewGitServerDoesNotExistError(name string) error {
+	return &GitServerDoesNotExistError{Name: name}
+}
+
+type NonValidGitServerError struct {
+	Message string
+}
+
+func (e *NonValidGitServerError) Error() string {
+	return e.Message
+}
+
+func NewNonValidGitServerError(message string) error {
+	return &NonValidGitServerError{Message: message}
+}
+
+type JiraServerExistsError struct {
+	Name string
+}
+
+func (e *JiraServerExistsError) Error() string {
+	return fmt.Sprintf("jira server %v already exists", e.Name)
+}
+
+func NewJiraServerExistsError(name string) error {
+	return &JiraServerExistsError{Name: name}
+}
+
+type JiraServerDoesNotExistError struct {
+	Name string
+}
+
+func (e *JiraServerDoesNotExistError) Error() string {
+	return fmt.Sprintf("jira server %v doesn't exist", e.Name)
+}
+
+func NewJiraServerDoesNotExistError(name string) error {
+	return &JiraServerDoesNotExistError{Name: name}
+}
+
+type NonValidJiraServerError struct {
+	Message string
+}
+
+func (e *NonValidJiraServerError) Error() string {
+	return e.Message
+}
+
+func NewNonValidJiraServerError(message string) error {
+	return &NonValidJiraServerError{Message: message}
+}
+
+type PerfServerExistsError struct {
+	Name string
+}
+
+func (e *PerfServerExistsError) Error() string {
+	return fmt.Sprintf("perf server %v already exists", e.Name)
+}
+
+func NewPerfServerExistsError(name string) error {
+	return &PerfServerExistsError{Name: name}
+}
+
+type PerfServerDoesNotExistError struct {
+	Name string
+}
+
+func (e *PerfServerDoesNotExistError) Error() string {
+	return fmt.Sprintf("perf server %v doesn't exist", e.Name)
+}
+
+func NewPerfServerDoesNotExistError(name string) error {
+	return &PerfServerDoesNotExistError{Name: name}
+}
+
+type NonValidPerfServerError struct {
+	Message string
+}
+
+func (e *NonValidPerfServerError) Error() string {
+	return e.Message
+}
+
+func NewNonValidPerfServerError(message string) error {
+	return &NonValidPerfServerError{Message: message}
+}
+
+type JenkinsResourceExistsError struct {
+	Kind string
+	Name string
+}
+
+func (e *JenkinsResourceExistsError) Error() string {
+	return fmt.Sprintf("%v %v already exists", e.Kind, e.Name)
+}
+
+func NewJenkinsResourceExistsError(kind, name string) error {
+	return &JenkinsResourceExistsError{Kind: kind, Name: name}
+}
+
+type JenkinsResourceDoesNotExistError struct {
+	Kind string
+	Name string
+}
+
+func (e *JenkinsResourceDoesNotExistError) Error() string {
+	return fmt.Sprintf("%v %v doesn't exist", e.Kind, e.Name)
+}
+
+func NewJenkinsResourceDoesNotExistError(kind, name string) error {
+	return &JenkinsResourceDoesNotExistError{Kind: kind, Name: name}
+}
+
+type EDPComponentExistsError struct {
+	Type string
+}
+
+func (e *EDPComponentExistsError) Error() string {
+	return fmt.Sprintf("edp component %v already exists", e.Type)
+}
+
+func NewEDPComponentExistsError(componentType string) error {
+	return &EDPComponentExistsError{Type: componentType}
+}
+
+type EDPComponentDoesNotExistError struct {
+	Type string
+}
+
+func (e *EDPComponentDoesNotExistError) Error() string {
+	return fmt.Sprintf("edp component %v doesn't exist", e.Type)
+}
+
+func NewEDPComponentDoesNotExistError(componentType string) error {
+	return &EDPComponentDoesNotExistError{Type: componentType}
+}
+
+type NonValidEDPComponentError struct {
+	Message string
+}
+
+func (e *NonValidEDPComponentError) Error() string {
+	return e.Message
+}
+
+func NewNonValidEDPComponentError(message string) error {
+	return &NonValidEDPComponentError{Message: message}
+}
+
+type ThirdPartyServiceExistsError struct {
+	Name string
+}
+
+func (e *ThirdPartyServiceExistsError) Error() string {
+	return fmt.Sprintf("service %v already exists", e.Name)
+}
+
+func NewThirdPartyServiceExistsError(name string) error {
+	return &ThirdPartyServiceExistsError{Name: name}
+}
+
+type ThirdPartyServiceDoesNotExistError struct {
+	Name string
+}
+
+func (e *ThirdPartyServiceDoesNotExistError) Error() string {
+	return fmt.Sprintf("service %v doesn't exist", e.Name)
+}
+
+func NewThirdPartyServiceDoesNotExistError(name string) error {
+	return &ThirdPartyServiceDoesNotExistError{Name: name}
+}
+
+type NonValidThirdPartyServiceError struct {
+	Message string
+}
+
+func (e *NonValidThirdPartyServiceError) Error() string {
+	return e.Message
+}
+
+func NewNonValidThirdPartyServiceError(message string) error {
+	return &NonValidThirdPartyServiceError{Message: message}
+}
+
+type TempSecretDoesNotExistError struct {
+	Name string
+}
+
+func (e *TempSecretDoesNotExistError) Error() string {
+	return fmt.Sprintf("temporary secret %v doesn't exist", e.Name)
+}
+
+func NewTempSecretDoesNotExistError(name string) error {
+	return &TempSecretDoesNotExistError{Name: name}
+}
+
+type NonValidCredentialsSecretError struct {
+	Message string
+}
+
+func (e *NonValidCredentialsSecretError) Error() string {
+	return e.Message
+}
+
+func NewNonValidCredentialsSecretError(message string) error {
+	return &NonValidCredentialsSecretError{Message: message}
+}
+
+type NonValidCommitError struct {
+	Codebase string
+	Commit   string
+}
+
+func (e *NonValidCommitError) Error() string {
+	return fmt.Sprintf("commit %v doesn't exist in repository of codebase %v", e.Commit, e.Codebase)
+}
+
+func NewNonValidCommitError(codebase, commit string) error {
+	return &NonValidCommitError{Codebase: codebase, Commit: commit}
+}
+
+type UnverifiedCommitError struct {
+	Codebase string
+	Commit   string
+	Reason   string
+}
+
+func (e *UnverifiedCommitError) Error() string {
+	return fmt.Sprintf("commit %v couldn't be verified in repository of codebase %v: %v", e.Commit, e.Codebase, e.Reason)
+}
+
+func NewUnverifiedCommitError(codebase, commit, reason string) error {
+	return &UnverifiedCommitError{Codebase: codebase, Commit: commit, Reason: reason}
+}
@@ -12,6 +12,7 @@ type CDPipeline struct {
 	ApplicationsToPromote []string                                                `json:"applicationsToPromote" orm:"-"`
 	CodebaseDockerStream  []*CodebaseDockerStream                                 `json:"image_stream" orm:"rel(m2m);rel_table(cd_pipeline_docker_stream)"`
 	ActionLog             []*ActionLog                                            `json:"-" orm:"rel(m2m);rel_table(cd_pipeline_action_log)"`
+	Labels                map[string]string                                       `json:"labels" orm:"-"`
 }
 
 type CDCodebaseStageMatrixKey struct {
@@ -32,6 +33,7 @@ func (c *CDPipeline) GetCDCodebaseStageMatrixValue(codebase *CodebaseBranch, sta
 
 type CDPipelineCriteria struct {
 	Status Status
+	Labels map[string]string
 }
 
 func (cb *CDPipeline) TableName() string {
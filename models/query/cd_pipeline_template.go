@@ -0,0 +1,29 @@
+package query
+
+type CDPipelineTemplate struct {
+	Id          int             `json:"id" orm:"column(id)"`
+	Name        string          `json:"name" orm:"column(name)"`
+	Description string          `json:"description" orm:"column(description)"`
+	RawStages   string          `json:"-" orm:"column(stages);type(jsonb)"`
+	Stages      []TemplateStage `json:"stages" orm:"-"`
+}
+
+type TemplateStage struct {
+	Name            string                `json:"name"`
+	Description     string                `json:"description"`
+	TriggerType     string                `json:"triggerType"`
+	JobProvisioning string                `json:"jobProvisioning"`
+	Source          Source                `json:"source"`
+	QualityGates    []TemplateQualityGate `json:"qualityGates"`
+}
+
+type TemplateQualityGate struct {
+	QualityGateType string  `json:"qualityGateType"`
+	StepName        string  `json:"stepName"`
+	AutotestName    *string `json:"autotestName"`
+	BranchName      *string `json:"branchName"`
+}
+
+func (t *CDPipelineTemplate) TableName() string {
+	return "cd_pipeline_template"
+}
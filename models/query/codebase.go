@@ -1,5 +1,7 @@
 package query
 
+import "time"
+
 type Codebase struct {
 	Id                   int               `json:"id" orm:"column(id)"`
 	Name                 string            `json:"name" orm:"column(name)"`
@@ -38,6 +40,8 @@ type Codebase struct {
 	PerfServerId         *int              `json:"-" orm:"column(perf_server_id)"`
 	Perf                 *Perf             `json:"perf" orm:"-"`
 	DefaultBranch        string            `json:"defaultBranch" orm:"column(default_branch)"`
+	Labels               map[string]string `json:"labels" orm:"-"`
+	ArchivedAt           *time.Time        `json:"archivedAt" orm:"column(archived_at);null"`
 }
 
 type Perf struct {
@@ -54,6 +58,8 @@ type CodebaseCriteria struct {
 	Status       Status
 	Type         CodebaseType
 	Language     CodebaseLanguage
+	Labels       map[string]string
+	Archived     bool
 }
 
 type CodebaseType string
@@ -0,0 +1,15 @@
+package query
+
+import "time"
+
+type DeploymentEvent struct {
+	Pipeline string    `json:"pipeline" orm:"column(pipeline)"`
+	Stage    string    `json:"stage" orm:"column(stage)"`
+	Time     time.Time `json:"time" orm:"column(updated_at)"`
+	Result   string    `json:"result" orm:"column(result)"`
+}
+
+type BuildEvent struct {
+	Codebase string    `json:"codebase" orm:"column(codebase)"`
+	Time     time.Time `json:"time" orm:"column(updated_at)"`
+}
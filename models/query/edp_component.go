@@ -6,6 +6,7 @@ type EDPComponent struct {
 	Url     string `json:"url" orm:"column(url)"`
 	Icon    string `json:"icon" orm:"column(icon)"`
 	Visible bool   `json:"visible" orm:"column(visible)"`
+	Ordinal int    `json:"ordinal" orm:"-"`
 }
 
 func (c *EDPComponent) TableName() string {
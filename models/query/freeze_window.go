@@ -0,0 +1,31 @@
+package query
+
+import "time"
+
+const (
+	NoRecurrence     = "none"
+	DailyRecurrence  = "daily"
+	WeeklyRecurrence = "weekly"
+)
+
+type FreezeWindow struct {
+	Id         int       `json:"id" orm:"column(id)"`
+	CdStageId  int       `json:"-" orm:"column(cd_stage_id)"`
+	Reason     string    `json:"reason" orm:"column(reason)"`
+	StartTime  time.Time `json:"startTime" orm:"column(start_time)"`
+	EndTime    time.Time `json:"endTime" orm:"column(end_time)"`
+	Recurrence string    `json:"recurrence" orm:"column(recurrence)"`
+	CreatedBy  string    `json:"createdBy" orm:"column(created_by)"`
+	Active     bool      `json:"active" orm:"-"`
+}
+
+func (w *FreezeWindow) TableName() string {
+	return "freeze_window"
+}
+
+type FreezeStatus struct {
+	Frozen  bool            `json:"frozen"`
+	Reason  string          `json:"reason,omitempty"`
+	Until   *time.Time      `json:"until,omitempty"`
+	Windows []*FreezeWindow `json:"windows"`
+}
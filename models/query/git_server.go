@@ -5,6 +5,7 @@ type GitServer struct {
 	Name      string `json:"name" orm:"column(name)"`
 	Hostname  string `json:"hostname" orm:"column(hostname)"`
 	Available bool   `json:"available" orm:"column(available)"`
+	Disabled  bool   `json:"disabled" orm:"column(disabled)"`
 }
 
 func (c *GitServer) TableName() string {
@@ -13,4 +14,5 @@ func (c *GitServer) TableName() string {
 
 type GitServerCriteria struct {
 	Available bool
+	Disabled  bool
 }
@@ -0,0 +1,12 @@
+package query
+
+const (
+	CodebaseLabels   = "codebase"
+	CDPipelineLabels = "cd_pipeline"
+)
+
+type Label struct {
+	EntityId int    `json:"-" orm:"column(entity_id)"`
+	Key      string `json:"key" orm:"column(key)"`
+	Value    string `json:"value" orm:"column(value)"`
+}
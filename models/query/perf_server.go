@@ -1,6 +1,7 @@
 package query
 
 type PerfServer struct {
-	Id   int    `json:"id" orm:"column(id)"`
-	Name string `json:"name" orm:"column(name)"`
+	Id        int    `json:"id" orm:"column(id)"`
+	Name      string `json:"name" orm:"column(name)"`
+	Available bool   `json:"available" orm:"column(available)"`
 }
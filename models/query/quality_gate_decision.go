@@ -0,0 +1,22 @@
+package query
+
+import "time"
+
+const (
+	Approved = "approved"
+	Rejected = "rejected"
+)
+
+type QualityGateDecision struct {
+	Id        int       `json:"-" orm:"column(id)"`
+	CdStageId int       `json:"-" orm:"column(cd_stage_id)"`
+	StepName  string    `json:"stepName" orm:"column(step_name)"`
+	Decision  string    `json:"decision" orm:"column(decision)"`
+	Comment   string    `json:"comment" orm:"column(comment)"`
+	Username  string    `json:"username" orm:"column(username)"`
+	Date      time.Time `json:"date" orm:"column(date)"`
+}
+
+func (d *QualityGateDecision) TableName() string {
+	return "quality_gate_decision"
+}
@@ -0,0 +1,16 @@
+package query
+
+const (
+	CodebaseSearchKind     = "codebase"
+	BranchSearchKind       = "branch"
+	DockerStreamSearchKind = "docker_stream"
+	CDPipelineSearchKind   = "cd_pipeline"
+	StageSearchKind        = "stage"
+)
+
+type SearchHit struct {
+	Kind   string  `json:"kind" orm:"column(kind)"`
+	Name   string  `json:"name" orm:"column(name)"`
+	Parent string  `json:"parent" orm:"column(parent)"`
+	Rank   float64 `json:"rank" orm:"column(rank)"`
+}
@@ -14,6 +14,7 @@ type Stage struct {
 	Source                    Source                      `json:"source" orm:"-"`
 	JobProvisioning           *JobProvisioning            `orm:"null;rel(one)"`
 	StageCodebaseDockerStream []StageCodebaseDockerStream `json:"stageCodebaseDockerStream" orm:"-"`
+	FreezeWindows             []*FreezeWindow             `json:"freezeWindows" orm:"-"`
 }
 
 type Source struct {
@@ -27,14 +28,15 @@ type SourceLibrary struct {
 }
 
 type QualityGate struct {
-	Id               int             `json:"id" orm:"column(id)"`
-	QualityGateType  string          `json:"qualityGateType" orm:"column(quality_gate)"`
-	StepName         string          `json:"stepName" orm:"column(step_name)"`
-	CdStageId        *int            `json:"cdStageId" orm:"column(cd_stage_id)"`
-	CodebaseId       *int            `json:"-" orm:"column(codebase_id)"`
-	CodebaseBranchId *int            `json:"branchId" orm:"column(codebase_branch_id)"`
-	Autotest         *Codebase       `json:"autotest" orm:"-"`
-	Branch           *CodebaseBranch `json:"codebaseBranch" orm:"-"`
+	Id               int                  `json:"id" orm:"column(id)"`
+	QualityGateType  string               `json:"qualityGateType" orm:"column(quality_gate)"`
+	StepName         string               `json:"stepName" orm:"column(step_name)"`
+	CdStageId        *int                 `json:"cdStageId" orm:"column(cd_stage_id)"`
+	CodebaseId       *int                 `json:"-" orm:"column(codebase_id)"`
+	CodebaseBranchId *int                 `json:"branchId" orm:"column(codebase_branch_id)"`
+	Autotest         *Codebase            `json:"autotest" orm:"-"`
+	Branch           *CodebaseBranch      `json:"codebaseBranch" orm:"-"`
+	Decision         *QualityGateDecision `json:"decision" orm:"-"`
 }
 
 type StageCodebaseDockerStream struct {
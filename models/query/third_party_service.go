@@ -1,12 +1,15 @@
 package query
 
 type ThirdPartyService struct {
-	Id          int    `json:"id" orm:"column(id)"`
-	Name        string `json:"name" orm:"column(name)"`
-	Description string `json:"description" orm:"column(description)"`
-	Version     string `json:"version" orm:"column(version)"`
-	Url         string `json:"url" orm:"column(url)"`
-	Icon        string `json:"-" orm:"column(icon)"`
+	Id              int    `json:"id" orm:"column(id)"`
+	Name            string `json:"name" orm:"column(name)"`
+	Description     string `json:"description" orm:"column(description)"`
+	Version         string `json:"version" orm:"column(version)"`
+	Url             string `json:"url" orm:"column(url)"`
+	Icon            string `json:"-" orm:"column(icon)"`
+	Parameters      string `json:"-" orm:"column(parameters);null"`
+	SourceType      string `json:"sourceType" orm:"column(source_type);null"`
+	SourceReference string `json:"sourceReference" orm:"column(source_reference);null"`
 }
 
 func (cb *ThirdPartyService) TableName() string {
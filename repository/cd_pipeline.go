@@ -42,6 +42,7 @@ type ICDPipelineRepository interface {
 	GetCDPipelinesUsingLibraryAndBranch(codebase, branch string) ([]string, error)
 	GetAllCodebaseDockerStreams() ([]string, error)
 	SelectCountStages(pipeName string) (*int, error)
+	SelectCodebaseDockerStreamName(codebase, branch string) (*string, error)
 }
 
 const (
@@ -158,6 +159,14 @@ const (
 		" where cd_stage_id = ?;"
 	selectCountStages = "select count(*) from cd_stage cs " +
 		"left join cd_pipeline cp on cs.cd_pipeline_id = cp.id where cp.name = ?;"
+	selectCodebaseDockerStreamName = "select cds.oc_image_stream_name " +
+		"	from codebase_docker_stream cds " +
+		"left join codebase_branch cb on cds.codebase_branch_id = cb.id " +
+		"left join codebase c on cb.codebase_id = c.id " +
+		"where c.name = ? " +
+		"  and cb.name = ? " +
+		"order by cds.id " +
+		"limit 1;"
 )
 
 type CDPipelineRepository struct {
@@ -229,6 +238,10 @@ func (r CDPipelineRepository) GetCDPipelineByName(pipelineName string) (*query.C
 		return nil, err
 	}
 
+	if cdPipeline.Labels, err = loadRelatedLabels(query.CDPipelineLabels, cdPipeline.Id); err != nil {
+		return nil, err
+	}
+
 	err = loadRelatedActionLogForCDPipeline(&cdPipeline)
 	if err != nil {
 		return nil, err
@@ -385,6 +398,17 @@ func (r CDPipelineRepository) GetCDPipelines(criteria query.CDPipelineCriteria)
 		qs = qs.Filter("status", criteria.Status)
 	}
 
+	if len(criteria.Labels) > 0 {
+		ids, err := selectIdsByLabels(query.CDPipelineLabels, criteria.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return pipelines, nil
+		}
+		qs = qs.Filter("id__in", ids)
+	}
+
 	_, err := qs.OrderBy("name").All(&pipelines)
 	if err != nil {
 		if err == orm.ErrNoRows {
@@ -415,6 +439,18 @@ func (r CDPipelineRepository) GetCDPipelines(criteria query.CDPipelineCriteria)
 		}
 	}
 
+	var ids []int
+	for _, p := range pipelines {
+		ids = append(ids, p.Id)
+	}
+	labels, err := loadEntitiesLabels(query.CDPipelineLabels, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pipelines {
+		p.Labels = labels[p.Id]
+	}
+
 	return pipelines, nil
 }
 
@@ -646,3 +682,15 @@ func (CDPipelineRepository) SelectCountStages(pipeName string) (*int, error) {
 	}
 	return &c, nil
 }
+
+func (CDPipelineRepository) SelectCodebaseDockerStreamName(codebase, branch string) (*string, error) {
+	o := orm.NewOrm()
+	var n string
+	if err := o.Raw(selectCodebaseDockerStreamName, codebase, branch).QueryRow(&n); err != nil {
+		if err == orm.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &n, nil
+}
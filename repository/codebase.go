@@ -18,6 +18,7 @@ package repository
 
 import (
 	"edp-admin-console/models/query"
+	"time"
 
 	"github.com/astaxie/beego/orm"
 )
@@ -31,6 +32,9 @@ type ICodebaseRepository interface {
 	SelectApplicationToPromote(cdPipelineId int) ([]*query.ApplicationsToPromote, error)
 	FindCodebaseByName(name string) bool
 	FindCodebaseByProjectPath(gitProjectPath *string) bool
+	UpdateArchivedAt(name string, archivedAt *time.Time) error
+	SelectCodebasesArchivedBefore(t time.Time) ([]string, error)
+	DeleteCodebase(name string) error
 }
 
 type CodebaseRepository struct {
@@ -54,8 +58,24 @@ func (CodebaseRepository) GetCodebasesByCriteria(criteria query.CodebaseCriteria
 		qs = qs.Filter("language", criteria.Language)
 	}
 
+	qs = qs.Filter("archived_at__isnull", !criteria.Archived)
+
+	if len(criteria.Labels) > 0 {
+		ids, err := selectIdsByLabels(query.CodebaseLabels, criteria.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return codebases, nil
+		}
+		qs = qs.Filter("id__in", ids)
+	}
+
 	_, err := qs.OrderBy("name").
 		All(&codebases)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, c := range codebases {
 
@@ -100,9 +120,20 @@ func (CodebaseRepository) GetCodebasesByCriteria(criteria query.CodebaseCriteria
 				return nil, err
 			}
 		}
+	}
 
+	var ids []int
+	for _, c := range codebases {
+		ids = append(ids, c.Id)
 	}
-	return codebases, err
+	labels, err := loadEntitiesLabels(query.CodebaseLabels, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range codebases {
+		c.Labels = labels[c.Id]
+	}
+	return codebases, nil
 }
 
 func (CodebaseRepository) FindCodebaseByName(name string) bool {
@@ -158,6 +189,10 @@ func (CodebaseRepository) GetCodebaseByName(name string) (*query.Codebase, error
 		}
 	}
 
+	if codebase.Labels, err = loadRelatedLabels(query.CodebaseLabels, codebase.Id); err != nil {
+		return nil, err
+	}
+
 	if codebase.JobProvisioningId != nil {
 		err = loadRelatedJobProvisioner(&codebase)
 		if err != nil {
@@ -366,3 +401,22 @@ func (CodebaseRepository) SelectApplicationToPromote(cdPipelineId int) ([]*query
 
 	return applicationsToPromote, err
 }
+
+func (CodebaseRepository) UpdateArchivedAt(name string, archivedAt *time.Time) error {
+	o := orm.NewOrm()
+	_, err := o.Raw("update codebase set archived_at = ? where name = ? ;", archivedAt, name).Exec()
+	return err
+}
+
+func (CodebaseRepository) SelectCodebasesArchivedBefore(t time.Time) ([]string, error) {
+	o := orm.NewOrm()
+	var names []string
+	_, err := o.Raw("select name from codebase where archived_at < ? order by name ;", t).QueryRows(&names)
+	return names, err
+}
+
+func (CodebaseRepository) DeleteCodebase(name string) error {
+	o := orm.NewOrm()
+	_, err := o.Raw("delete from codebase where name = ? ;", name).Exec()
+	return err
+}
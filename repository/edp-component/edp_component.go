@@ -9,6 +9,7 @@ import (
 type IEDPComponentRepository interface {
 	GetEDPComponent(componentType string) (*query.EDPComponent, error)
 	GetEDPComponents() ([]*query.EDPComponent, error)
+	DeleteEDPComponent(componentType string) error
 }
 
 type EDPComponent struct {
@@ -46,3 +47,11 @@ func (EDPComponent) GetEDPComponents() ([]*query.EDPComponent, error) {
 
 	return c, nil
 }
+
+func (EDPComponent) DeleteEDPComponent(componentType string) error {
+	o := orm.NewOrm()
+	_, err := o.QueryTable(new(query.EDPComponent)).
+		Filter("type", componentType).
+		Delete()
+	return err
+}
@@ -0,0 +1,64 @@
+package freeze_window
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/astaxie/beego/orm"
+)
+
+const (
+	selectStageId = "select cs.id " +
+		"from cd_stage cs " +
+		"		left join cd_pipeline cp on cs.cd_pipeline_id = cp.id " +
+		"where cp.name = ? " +
+		"  and cs.name = ? ;"
+)
+
+type IFreezeWindowRepository interface {
+	SelectStageId(pipelineName, stageName string) (*int, error)
+	SelectFreezeWindows(stageId int) ([]*query.FreezeWindow, error)
+	CreateFreezeWindow(window *query.FreezeWindow) error
+	DeleteFreezeWindow(stageId, id int) (int64, error)
+}
+
+type FreezeWindowRepository struct {
+}
+
+func (FreezeWindowRepository) SelectStageId(pipelineName, stageName string) (*int, error) {
+	o := orm.NewOrm()
+	var id int
+	err := o.Raw(selectStageId, pipelineName, stageName).QueryRow(&id)
+	if err == orm.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (FreezeWindowRepository) SelectFreezeWindows(stageId int) ([]*query.FreezeWindow, error) {
+	o := orm.NewOrm()
+	var windows []*query.FreezeWindow
+	_, err := o.QueryTable(new(query.FreezeWindow)).
+		Filter("cd_stage_id", stageId).
+		OrderBy("start_time").
+		All(&windows)
+	if err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+func (FreezeWindowRepository) CreateFreezeWindow(window *query.FreezeWindow) error {
+	o := orm.NewOrm()
+	_, err := o.Insert(window)
+	return err
+}
+
+func (FreezeWindowRepository) DeleteFreezeWindow(stageId, id int) (int64, error) {
+	o := orm.NewOrm()
+	return o.QueryTable(new(query.FreezeWindow)).
+		Filter("cd_stage_id", stageId).
+		Filter("id", id).
+		Delete()
+}
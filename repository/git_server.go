@@ -8,6 +8,8 @@ import (
 type IGitServerRepository interface {
 	GetGitServersByCriteria(criteria query.GitServerCriteria) ([]*query.GitServer, error)
 	GetGitServerByName(name string) (*query.GitServer, error)
+	GetAllGitServers() ([]*query.GitServer, error)
+	UpdateDisabled(name string, disabled bool) error
 }
 
 type GitServerRepository struct {
@@ -20,6 +22,7 @@ func (GitServerRepository) GetGitServersByCriteria(criteria query.GitServerCrite
 
 	_, err := o.QueryTable(new(query.GitServer)).
 		Filter("available", criteria.Available).
+		Filter("disabled", criteria.Disabled).
 		OrderBy("name").
 		All(&gitServers)
 	if err != nil {
@@ -45,3 +48,20 @@ func (GitServerRepository) GetGitServerByName(name string) (*query.GitServer, er
 
 	return &gitServer, nil
 }
+
+func (GitServerRepository) GetAllGitServers() ([]*query.GitServer, error) {
+	o := orm.NewOrm()
+	var gitServers []*query.GitServer
+	if _, err := o.QueryTable(new(query.GitServer)).OrderBy("name").All(&gitServers); err != nil {
+		return nil, err
+	}
+	return gitServers, nil
+}
+
+func (GitServerRepository) UpdateDisabled(name string, disabled bool) error {
+	o := orm.NewOrm()
+	_, err := o.QueryTable(new(query.GitServer)).
+		Filter("name", name).
+		Update(orm.Params{"disabled": disabled})
+	return err
+}
@@ -5,8 +5,17 @@ import (
 	"github.com/astaxie/beego/orm"
 )
 
+const (
+	selectCountCodebases = "select count(c.id) " +
+		"from codebase c " +
+		"		left join jira_server js on c.jira_server_id = js.id " +
+		"where js.name = ? ;"
+)
+
 type IJiraServer interface {
 	GetJiraServers() ([]*query.JiraServer, error)
+	GetJiraServer(name string) (*query.JiraServer, error)
+	SelectCountCodebases(name string) (int, error)
 }
 
 type JiraServer struct {
@@ -24,3 +33,27 @@ func (JiraServer) GetJiraServers() ([]*query.JiraServer, error) {
 	}
 	return servers, nil
 }
+
+func (JiraServer) GetJiraServer(name string) (*query.JiraServer, error) {
+	o := orm.NewOrm()
+	server := query.JiraServer{}
+	err := o.QueryTable(new(query.JiraServer)).
+		Filter("name", name).
+		One(&server)
+	if err != nil {
+		if err == orm.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &server, nil
+}
+
+func (JiraServer) SelectCountCodebases(name string) (int, error) {
+	o := orm.NewOrm()
+	var c int
+	if err := o.Raw(selectCountCodebases, name).QueryRow(&c); err != nil {
+		return 0, err
+	}
+	return c, nil
+}
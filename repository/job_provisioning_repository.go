@@ -6,8 +6,28 @@ import (
 	"github.com/astaxie/beego/orm"
 )
 
+const (
+	selectCodebasesUsingJobProvisioner = "select c.name " +
+		"from codebase c " +
+		"		left join job_provisioning jp on c.job_provisioning_id = jp.id " +
+		"where jp.name = ? " +
+		"  and jp.scope = ? " +
+		"order by c.name ;"
+	selectStagesUsingJobProvisioner = "select cp.name || '/' || cs.name " +
+		"from cd_stage cs " +
+		"		left join cd_pipeline cp on cs.cd_pipeline_id = cp.id " +
+		"		left join job_provisioning jp on cs.job_provisioning_id = jp.id " +
+		"where jp.name = ? " +
+		"  and jp.scope = ? " +
+		"order by cp.name, cs.name ;"
+)
+
 type IJobProvisioningRepository interface {
 	GetAllJobProvisioners(criteria query.JobProvisioningCriteria) ([]*query.JobProvisioning, error)
+	GetJobProvisioner(name, scope string) (*query.JobProvisioning, error)
+	SelectCodebasesUsingJobProvisioner(name, scope string) ([]string, error)
+	SelectStagesUsingJobProvisioner(name, scope string) ([]string, error)
+	DeleteJobProvisioner(name, scope string) error
 }
 
 type JobProvisioning struct {
@@ -30,3 +50,48 @@ func (JobProvisioning) GetAllJobProvisioners(criteria query.JobProvisioningCrite
 
 	return jobsProvisioning, nil
 }
+
+func (JobProvisioning) GetJobProvisioner(name, scope string) (*query.JobProvisioning, error) {
+	o := orm.NewOrm()
+	p := query.JobProvisioning{}
+
+	err := o.QueryTable(new(query.JobProvisioning)).
+		Filter("name", name).
+		Filter("scope", scope).
+		One(&p)
+	if err != nil {
+		if err == orm.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (JobProvisioning) SelectCodebasesUsingJobProvisioner(name, scope string) ([]string, error) {
+	o := orm.NewOrm()
+	var codebases []string
+	if _, err := o.Raw(selectCodebasesUsingJobProvisioner, name, scope).QueryRows(&codebases); err != nil {
+		return nil, err
+	}
+	return codebases, nil
+}
+
+func (JobProvisioning) SelectStagesUsingJobProvisioner(name, scope string) ([]string, error) {
+	o := orm.NewOrm()
+	var stages []string
+	if _, err := o.Raw(selectStagesUsingJobProvisioner, name, scope).QueryRows(&stages); err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
+
+func (JobProvisioning) DeleteJobProvisioner(name, scope string) error {
+	o := orm.NewOrm()
+	_, err := o.QueryTable(new(query.JobProvisioning)).
+		Filter("name", name).
+		Filter("scope", scope).
+		Delete()
+	return err
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"edp-admin-console/models/query"
+	"fmt"
+	"github.com/astaxie/beego/orm"
+	"strings"
+)
+
+const (
+	selectLabels = "select %[1]v_id as entity_id, key, value " +
+		"from %[1]v_label " +
+		"where %[1]v_id = ? " +
+		"order by key;"
+	selectEntitiesLabels = "select %[1]v_id as entity_id, key, value " +
+		"from %[1]v_label " +
+		"where %[1]v_id in (%[2]v) " +
+		"order by key;"
+	selectLabeledIds = "select %[1]v_id as entity_id " +
+		"from %[1]v_label " +
+		"where %[2]v " +
+		"group by %[1]v_id " +
+		"having count(*) = ?;"
+	deleteLabels = "delete from %[1]v_label where %[1]v_id = ?;"
+	insertLabel  = "insert into %[1]v_label(%[1]v_id, key, value) values (?, ?, ?);"
+)
+
+type ILabelRepository interface {
+	ReplaceLabels(kind string, id int, labels map[string]string) error
+}
+
+type LabelRepository struct {
+}
+
+func (LabelRepository) ReplaceLabels(kind string, id int, labels map[string]string) error {
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return err
+	}
+
+	if _, err := o.Raw(fmt.Sprintf(deleteLabels, kind), id).Exec(); err != nil {
+		_ = o.Rollback()
+		return err
+	}
+
+	for k, v := range labels {
+		if _, err := o.Raw(fmt.Sprintf(insertLabel, kind), id, k, v).Exec(); err != nil {
+			_ = o.Rollback()
+			return err
+		}
+	}
+
+	return o.Commit()
+}
+
+func loadRelatedLabels(kind string, id int) (map[string]string, error) {
+	o := orm.NewOrm()
+	var labels []query.Label
+	if _, err := o.Raw(fmt.Sprintf(selectLabels, kind), id).QueryRows(&labels); err != nil {
+		return nil, err
+	}
+
+	res := map[string]string{}
+	for _, l := range labels {
+		res[l.Key] = l.Value
+	}
+	return res, nil
+}
+
+// loadEntitiesLabels selects labels of several entities by one query, labels are grouped by entity id
+func loadEntitiesLabels(kind string, ids []int) (map[int]map[string]string, error) {
+	res := map[int]map[string]string{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	var args []interface{}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+
+	o := orm.NewOrm()
+	var labels []query.Label
+	if _, err := o.Raw(fmt.Sprintf(selectEntitiesLabels, kind, placeholders), args...).QueryRows(&labels); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		res[id] = map[string]string{}
+	}
+	for _, l := range labels {
+		res[l.EntityId][l.Key] = l.Value
+	}
+	return res, nil
+}
+
+func selectIdsByLabels(kind string, labels map[string]string) ([]int, error) {
+	var conditions []string
+	var args []interface{}
+	for k, v := range labels {
+		conditions = append(conditions, "(key = ? and value = ?)")
+		args = append(args, k, v)
+	}
+	args = append(args, len(labels))
+
+	o := orm.NewOrm()
+	var rows []query.Label
+	sql := fmt.Sprintf(selectLabeledIds, kind, strings.Join(conditions, " or "))
+	if _, err := o.Raw(sql, args...).QueryRows(&rows); err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, r := range rows {
+		ids = append(ids, r.EntityId)
+	}
+	return ids, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/astaxie/beego/orm"
+	"time"
+)
+
+const (
+	selectDeploymentEvents = "select cp.name as pipeline, cs.name as stage, al.updated_at, coalesce(al.result::text, '') as result " +
+		"from action_log al " +
+		"	join cd_stage_action_log csal on al.id = csal.action_log_id " +
+		"	join cd_stage cs on csal.cd_stage_id = cs.id " +
+		"	join cd_pipeline cp on cs.cd_pipeline_id = cp.id " +
+		"where al.action = 'deploy' " +
+		"  and al.updated_at >= ? " +
+		"  and al.updated_at < ? " +
+		"order by al.updated_at;"
+	selectBuildEvents = "select c.name as codebase, al.updated_at " +
+		"from action_log al " +
+		"	join codebase_action_log cal on al.id = cal.action_log_id " +
+		"	join codebase c on cal.codebase_id = c.id " +
+		"where al.result = 'success' " +
+		"  and al.action = 'build' " +
+		"  and al.updated_at >= ? " +
+		"  and al.updated_at < ? " +
+		"order by al.updated_at;"
+	selectEventStageId = "select cs.id " +
+		"from cd_stage cs " +
+		"	join cd_pipeline cp on cs.cd_pipeline_id = cp.id " +
+		"where cp.name = ? " +
+		"  and cs.name = ? ;"
+	selectEventCodebaseId = "select id from codebase where name = ? ;"
+	insertEvent           = "insert into action_log(event, detailed_message, username, updated_at, action, action_message, result) " +
+		"values (?, '', ?, ?, ?, ?, ?) returning id;"
+	insertStageEvent    = "insert into cd_stage_action_log(cd_stage_id, action_log_id) values (?, ?);"
+	insertCodebaseEvent = "insert into codebase_action_log(codebase_id, action_log_id) values (?, ?);"
+
+	eventCreated = "created"
+	eventFailed  = "failed"
+	resultError  = "error"
+)
+
+type IMetricsRepository interface {
+	SelectDeploymentEvents(from, to time.Time) ([]query.DeploymentEvent, error)
+	SelectBuildEvents(from, to time.Time) ([]query.BuildEvent, error)
+	CreateDeploymentEvent(pipeline, stage string, event query.ActionLog) (*int, error)
+	CreateBuildEvent(codebase string, event query.ActionLog) (*int, error)
+}
+
+type MetricsRepository struct {
+}
+
+func (MetricsRepository) SelectDeploymentEvents(from, to time.Time) ([]query.DeploymentEvent, error) {
+	o := orm.NewOrm()
+	var events []query.DeploymentEvent
+	if _, err := o.Raw(selectDeploymentEvents, from, to).QueryRows(&events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (MetricsRepository) SelectBuildEvents(from, to time.Time) ([]query.BuildEvent, error) {
+	o := orm.NewOrm()
+	var events []query.BuildEvent
+	if _, err := o.Raw(selectBuildEvents, from, to).QueryRows(&events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+//CreateDeploymentEvent adds action to log of the stage, nil is returned if the stage doesn't exist
+func (MetricsRepository) CreateDeploymentEvent(pipeline, stage string, event query.ActionLog) (*int, error) {
+	return createEvent(insertStageEvent, selectEventStageId, []interface{}{pipeline, stage}, event)
+}
+
+//CreateBuildEvent adds action to log of the codebase, nil is returned if the codebase doesn't exist
+func (MetricsRepository) CreateBuildEvent(codebase string, event query.ActionLog) (*int, error) {
+	return createEvent(insertCodebaseEvent, selectEventCodebaseId, []interface{}{codebase}, event)
+}
+
+func createEvent(insertLink, selectOwner string, ownerArgs []interface{}, event query.ActionLog) (*int, error) {
+	o := orm.NewOrm()
+	if err := o.Begin(); err != nil {
+		return nil, err
+	}
+
+	var ownerId int
+	err := o.Raw(selectOwner, ownerArgs...).QueryRow(&ownerId)
+	if err == orm.ErrNoRows {
+		_ = o.Rollback()
+		return nil, nil
+	}
+	if err != nil {
+		_ = o.Rollback()
+		return nil, err
+	}
+
+	status := eventCreated
+	if event.Result == resultError {
+		status = eventFailed
+	}
+	var id int
+	err = o.Raw(insertEvent, status, event.UserName, event.LastTimeUpdate, event.Action, event.Message, event.Result).
+		QueryRow(&id)
+	if err != nil {
+		_ = o.Rollback()
+		return nil, err
+	}
+
+	if _, err := o.Raw(insertLink, ownerId, id).Exec(); err != nil {
+		_ = o.Rollback()
+		return nil, err
+	}
+
+	if err := o.Commit(); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -20,7 +20,8 @@ func (m MockCdPipeline) GetCDPipelineByName(pipelineName string) (*query.CDPipel
 	return &p, args.Error(1)
 }
 func (m MockCdPipeline) GetCDPipelines(criteria query.CDPipelineCriteria) ([]*query.CDPipeline, error) {
-	panic("implement me!!!")
+	args := m.Called(criteria)
+	return args.Get(0).([]*query.CDPipeline), args.Error(1)
 }
 func (m MockCdPipeline) GetStage(cdPipelineName, stageName string) (*models.StageView, error) {
 	panic("implement me!!!")
@@ -32,7 +33,11 @@ func (m MockCdPipeline) GetQualityGates(stageId int64) ([]query.QualityGate, err
 	panic("implement me!!!")
 }
 func (m MockCdPipeline) GetCDPipelinesUsingApplication(codebaseName string) ([]string, error) {
-	panic("implement me!!!")
+	args := m.Called(codebaseName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
 }
 func (m MockCdPipeline) GetCDPipelinesUsingAutotest(codebaseName string) ([]string, error) {
 	panic("implement me!!!")
@@ -47,10 +52,18 @@ func (m MockCdPipeline) SelectStageOrder(pipeName, stageName string) (*int, erro
 	panic("implement me!!!")
 }
 func (m MockCdPipeline) SelectCDPipelinesUsingInputStageAsSource(pipeName, stageName string) ([]string, error) {
-	panic("implement me!!!")
+	args := m.Called(pipeName, stageName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
 }
 func (m MockCdPipeline) GetCDPipelinesUsingApplicationAndBranch(codebase, branch string) ([]string, error) {
-	panic("implement me!!!")
+	args := m.Called(codebase, branch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
 }
 func (m MockCdPipeline) GetCDPipelinesUsingAutotestAndBranch(codebase, branch string) ([]string, error) {
 	panic("implement me!!!")
@@ -64,3 +77,11 @@ func (m MockCdPipeline) GetAllCodebaseDockerStreams() ([]string, error) {
 func (m MockCdPipeline) SelectCountStages(pipeName string) (*int, error) {
 	panic("implement me!!!")
 }
+func (m MockCdPipeline) SelectCodebaseDockerStreamName(codebase, branch string) (*string, error) {
+	args := m.Called(codebase, branch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	n := args.String(0)
+	return &n, args.Error(1)
+}
@@ -3,6 +3,7 @@ package mock
 import (
 	"edp-admin-console/models/query"
 	"github.com/stretchr/testify/mock"
+	"time"
 )
 
 type MockCodebase struct {
@@ -15,11 +16,11 @@ func (m MockCodebase) GetCodebasesByCriteria(criteria query.CodebaseCriteria) ([
 }
 
 func (m MockCodebase) FindCodebaseByName(name string) bool {
-	panic("implement me")
+	return m.Called(name).Bool(0)
 }
 
 func (m MockCodebase) FindCodebaseByProjectPath(gitProjectPath *string) bool {
-	panic("implement me")
+	return m.Called(*gitProjectPath).Bool(0)
 }
 
 func (m MockCodebase) GetCodebaseByName(name string) (*query.Codebase, error) {
@@ -46,3 +47,16 @@ func (m MockCodebase) ExistCodebaseAndBranch(cbName, brName string) bool {
 func (m MockCodebase) SelectApplicationToPromote(cdPipelineId int) ([]*query.ApplicationsToPromote, error) {
 	panic("implement me")
 }
+
+func (m MockCodebase) UpdateArchivedAt(name string, archivedAt *time.Time) error {
+	return m.Called(name, archivedAt).Error(0)
+}
+
+func (m MockCodebase) SelectCodebasesArchivedBefore(t time.Time) ([]string, error) {
+	args := m.Called(t)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m MockCodebase) DeleteCodebase(name string) error {
+	return m.Called(name).Error(0)
+}
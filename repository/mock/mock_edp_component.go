@@ -16,5 +16,10 @@ func (m MockEDPComponent) GetEDPComponent(componentType string) (*query.EDPCompo
 }
 
 func (m MockEDPComponent) GetEDPComponents() ([]*query.EDPComponent, error) {
-	panic("implement me")
+	args := m.Called()
+	return args.Get(0).([]*query.EDPComponent), args.Error(1)
+}
+
+func (m MockEDPComponent) DeleteEDPComponent(componentType string) error {
+	return m.Called(componentType).Error(0)
 }
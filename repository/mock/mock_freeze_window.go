@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFreezeWindow struct {
+	mock.Mock
+}
+
+func (m MockFreezeWindow) SelectStageId(pipelineName, stageName string) (*int, error) {
+	args := m.Called(pipelineName, stageName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	id := args.Int(0)
+	return &id, args.Error(1)
+}
+
+func (m MockFreezeWindow) SelectFreezeWindows(stageId int) ([]*query.FreezeWindow, error) {
+	args := m.Called(stageId)
+	return args.Get(0).([]*query.FreezeWindow), args.Error(1)
+}
+
+func (m MockFreezeWindow) CreateFreezeWindow(window *query.FreezeWindow) error {
+	args := m.Called(window)
+	return args.Error(0)
+}
+
+func (m MockFreezeWindow) DeleteFreezeWindow(stageId, id int) (int64, error) {
+	args := m.Called(stageId, id)
+	return int64(args.Int(0)), args.Error(1)
+}
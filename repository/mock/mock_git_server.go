@@ -10,11 +10,24 @@ type MockGitServer struct {
 }
 
 func (m MockGitServer) GetGitServersByCriteria(criteria query.GitServerCriteria) ([]*query.GitServer, error) {
-	panic("implement me")
+	args := m.Called(criteria)
+	return args.Get(0).([]*query.GitServer), args.Error(1)
 }
 
 func (m MockGitServer) GetGitServerByName(name string) (*query.GitServer, error) {
 	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	gs := args.Get(0).(query.GitServer)
 	return &gs, args.Error(1)
 }
+
+func (m MockGitServer) GetAllGitServers() ([]*query.GitServer, error) {
+	args := m.Called()
+	return args.Get(0).([]*query.GitServer), args.Error(1)
+}
+
+func (m MockGitServer) UpdateDisabled(name string, disabled bool) error {
+	return m.Called(name, disabled).Error(0)
+}
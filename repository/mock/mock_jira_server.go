@@ -16,3 +16,17 @@ func (m MockJiraServer) GetJiraServers() ([]*query.JiraServer, error) {
 	}
 	return args.Get(0).([]*query.JiraServer), args.Error(1)
 }
+
+func (m MockJiraServer) GetJiraServer(name string) (*query.JiraServer, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	s := args.Get(0).(query.JiraServer)
+	return &s, args.Error(1)
+}
+
+func (m MockJiraServer) SelectCountCodebases(name string) (int, error) {
+	args := m.Called(name)
+	return args.Int(0), args.Error(1)
+}
@@ -16,3 +16,26 @@ func (m MockJobProvision) GetAllJobProvisioners(criteria query.JobProvisioningCr
 	}
 	return args.Get(0).([]*query.JobProvisioning), args.Error(1)
 }
+
+func (m MockJobProvision) GetJobProvisioner(name, scope string) (*query.JobProvisioning, error) {
+	args := m.Called(name, scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	p := args.Get(0).(query.JobProvisioning)
+	return &p, args.Error(1)
+}
+
+func (m MockJobProvision) SelectCodebasesUsingJobProvisioner(name, scope string) ([]string, error) {
+	args := m.Called(name, scope)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m MockJobProvision) SelectStagesUsingJobProvisioner(name, scope string) ([]string, error) {
+	args := m.Called(name, scope)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m MockJobProvision) DeleteJobProvisioner(name, scope string) error {
+	return m.Called(name, scope).Error(0)
+}
@@ -0,0 +1,13 @@
+package mock
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLabel struct {
+	mock.Mock
+}
+
+func (m MockLabel) ReplaceLabels(kind string, id int, labels map[string]string) error {
+	return m.Called(kind, id, labels).Error(0)
+}
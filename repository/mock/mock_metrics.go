@@ -0,0 +1,39 @@
+package mock
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type MockMetrics struct {
+	mock.Mock
+}
+
+func (m MockMetrics) SelectDeploymentEvents(from, to time.Time) ([]query.DeploymentEvent, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]query.DeploymentEvent), args.Error(1)
+}
+
+func (m MockMetrics) SelectBuildEvents(from, to time.Time) ([]query.BuildEvent, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]query.BuildEvent), args.Error(1)
+}
+
+func (m MockMetrics) CreateDeploymentEvent(pipeline, stage string, event query.ActionLog) (*int, error) {
+	args := m.Called(pipeline, stage, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	id := args.Int(0)
+	return &id, args.Error(1)
+}
+
+func (m MockMetrics) CreateBuildEvent(codebase string, event query.ActionLog) (*int, error) {
+	args := m.Called(codebase, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	id := args.Int(0)
+	return &id, args.Error(1)
+}
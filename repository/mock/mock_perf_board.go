@@ -32,3 +32,16 @@ func (m MockPerfBoard) GetCodebaseDataSources(codebaseId int) ([]string, error)
 	}
 	return args.Get(0).([]string), args.Error(1)
 }
+
+func (m MockPerfBoard) GetPerfServer(name string) (*query.PerfServer, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*query.PerfServer), args.Error(1)
+}
+
+func (m MockPerfBoard) SelectCountCodebases(name string) (int, error) {
+	args := m.Called(name)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPipelineTemplate struct {
+	mock.Mock
+}
+
+func (m MockPipelineTemplate) GetTemplates() ([]*query.CDPipelineTemplate, error) {
+	panic("implement me")
+}
+
+func (m MockPipelineTemplate) GetTemplate(name string) (*query.CDPipelineTemplate, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	t := args.Get(0).(query.CDPipelineTemplate)
+	return &t, args.Error(1)
+}
+
+func (m MockPipelineTemplate) CreateTemplate(template *query.CDPipelineTemplate) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+func (m MockPipelineTemplate) UpdateTemplate(template *query.CDPipelineTemplate) error {
+	panic("implement me")
+}
+
+func (m MockPipelineTemplate) DeleteTemplate(name string) error {
+	panic("implement me")
+}
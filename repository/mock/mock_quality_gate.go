@@ -0,0 +1,47 @@
+package mock
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockQualityGate struct {
+	mock.Mock
+}
+
+func (m MockQualityGate) SelectStageId(pipelineName, stageName string) (*int, error) {
+	args := m.Called(pipelineName, stageName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	id := args.Int(0)
+	return &id, args.Error(1)
+}
+
+func (m MockQualityGate) SelectManualGate(stageId int, stepName string) (*query.QualityGate, error) {
+	args := m.Called(stageId, stepName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	g := args.Get(0).(query.QualityGate)
+	return &g, args.Error(1)
+}
+
+func (m MockQualityGate) CreateDecision(decision *query.QualityGateDecision) error {
+	args := m.Called(decision)
+	return args.Error(0)
+}
+
+func (m MockQualityGate) SelectLastDecision(stageId int, stepName string) (*query.QualityGateDecision, error) {
+	args := m.Called(stageId, stepName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	d := args.Get(0).(query.QualityGateDecision)
+	return &d, args.Error(1)
+}
+
+func (m MockQualityGate) SelectLastDecisions(stageId int) ([]*query.QualityGateDecision, error) {
+	args := m.Called(stageId)
+	return args.Get(0).([]*query.QualityGateDecision), args.Error(1)
+}
@@ -0,0 +1,15 @@
+package mock
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSearch struct {
+	mock.Mock
+}
+
+func (m MockSearch) SelectSearchHits(term, pattern string, limit int) ([]query.SearchHit, error) {
+	args := m.Called(term, pattern, limit)
+	return args.Get(0).([]query.SearchHit), args.Error(1)
+}
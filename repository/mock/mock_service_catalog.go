@@ -0,0 +1,47 @@
+package mock
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockServiceCatalog struct {
+	mock.Mock
+}
+
+func (m MockServiceCatalog) GetAllServices() ([]query.ThirdPartyService, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]query.ThirdPartyService), args.Error(1)
+}
+
+func (m MockServiceCatalog) GetService(name string) (*query.ThirdPartyService, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	s := args.Get(0).(query.ThirdPartyService)
+	return &s, args.Error(1)
+}
+
+func (m MockServiceCatalog) CreateService(service *query.ThirdPartyService) error {
+	return m.Called(service).Error(0)
+}
+
+func (m MockServiceCatalog) UpdateService(service *query.ThirdPartyService) error {
+	return m.Called(service).Error(0)
+}
+
+func (m MockServiceCatalog) DeleteService(name string) error {
+	return m.Called(name).Error(0)
+}
+
+func (m MockServiceCatalog) SelectPipelinesUsingService(name string) ([]string, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -16,3 +16,21 @@ func (m MockSlave) GetAllSlaves() ([]*query.JenkinsSlave, error) {
 	}
 	return args.Get(0).([]*query.JenkinsSlave), args.Error(1)
 }
+
+func (m MockSlave) GetSlave(name string) (*query.JenkinsSlave, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	s := args.Get(0).(query.JenkinsSlave)
+	return &s, args.Error(1)
+}
+
+func (m MockSlave) SelectCodebasesUsingSlave(name string) ([]string, error) {
+	args := m.Called(name)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m MockSlave) DeleteSlave(name string) error {
+	return m.Called(name).Error(0)
+}
@@ -9,6 +9,8 @@ type IPerfServer interface {
 	GetPerfServers() ([]*query.PerfServer, error)
 	GetPerfServerName(id int) (*query.PerfServer, error)
 	GetCodebaseDataSources(codebaseId int) ([]string, error)
+	GetPerfServer(name string) (*query.PerfServer, error)
+	SelectCountCodebases(name string) (int, error)
 }
 
 type PerfServer struct {
@@ -19,6 +21,11 @@ const selectCodebaseDataSources = `select type
 											 left join codebase_perf_data_sources cpds on pds.id = cpds.data_source_id
 									where cpds.codebase_id = ?;`
 
+const selectCountCodebases = `select count(c.id)
+								from codebase c
+										 left join perf_server ps on c.perf_server_id = ps.id
+								where ps.name = ?;`
+
 func (PerfServer) GetPerfServers() ([]*query.PerfServer, error) {
 	o := orm.NewOrm()
 	var servers []*query.PerfServer
@@ -52,3 +59,27 @@ func (PerfServer) GetCodebaseDataSources(codebaseId int) ([]string, error) {
 	}
 	return ds, nil
 }
+
+func (PerfServer) GetPerfServer(name string) (*query.PerfServer, error) {
+	o := orm.NewOrm()
+	ps := query.PerfServer{}
+	err := o.QueryTable(new(query.PerfServer)).
+		Filter("name", name).
+		One(&ps)
+	if err != nil {
+		if err == orm.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ps, nil
+}
+
+func (PerfServer) SelectCountCodebases(name string) (int, error) {
+	o := orm.NewOrm()
+	var c int
+	if err := o.Raw(selectCountCodebases, name).QueryRow(&c); err != nil {
+		return 0, err
+	}
+	return c, nil
+}
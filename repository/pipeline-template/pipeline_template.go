@@ -0,0 +1,62 @@
+package pipeline_template
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/astaxie/beego/orm"
+)
+
+type IPipelineTemplateRepository interface {
+	GetTemplates() ([]*query.CDPipelineTemplate, error)
+	GetTemplate(name string) (*query.CDPipelineTemplate, error)
+	CreateTemplate(template *query.CDPipelineTemplate) error
+	UpdateTemplate(template *query.CDPipelineTemplate) error
+	DeleteTemplate(name string) error
+}
+
+type PipelineTemplateRepository struct {
+}
+
+func (PipelineTemplateRepository) GetTemplates() ([]*query.CDPipelineTemplate, error) {
+	o := orm.NewOrm()
+	var templates []*query.CDPipelineTemplate
+	_, err := o.QueryTable(new(query.CDPipelineTemplate)).
+		OrderBy("name").
+		All(&templates)
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (PipelineTemplateRepository) GetTemplate(name string) (*query.CDPipelineTemplate, error) {
+	o := orm.NewOrm()
+	t := query.CDPipelineTemplate{Name: name}
+	err := o.Read(&t, "Name")
+	if err == orm.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (PipelineTemplateRepository) CreateTemplate(template *query.CDPipelineTemplate) error {
+	o := orm.NewOrm()
+	_, err := o.Insert(template)
+	return err
+}
+
+func (PipelineTemplateRepository) UpdateTemplate(template *query.CDPipelineTemplate) error {
+	o := orm.NewOrm()
+	_, err := o.Update(template, "Description", "RawStages")
+	return err
+}
+
+func (PipelineTemplateRepository) DeleteTemplate(name string) error {
+	o := orm.NewOrm()
+	_, err := o.QueryTable(new(query.CDPipelineTemplate)).
+		Filter("name", name).
+		Delete()
+	return err
+}
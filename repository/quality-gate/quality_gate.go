@@ -0,0 +1,93 @@
+package quality_gate
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/astaxie/beego/orm"
+)
+
+const (
+	selectStageId = "select cs.id " +
+		"from cd_stage cs " +
+		"		left join cd_pipeline cp on cs.cd_pipeline_id = cp.id " +
+		"where cp.name = ? " +
+		"  and cs.name = ? ;"
+	selectLastDecisions = "select distinct on (qgd.step_name) qgd.* " +
+		"from quality_gate_decision qgd " +
+		"where qgd.cd_stage_id = ? " +
+		"order by qgd.step_name, qgd.date desc ;"
+)
+
+type IQualityGateRepository interface {
+	SelectStageId(pipelineName, stageName string) (*int, error)
+	SelectManualGate(stageId int, stepName string) (*query.QualityGate, error)
+	CreateDecision(decision *query.QualityGateDecision) error
+	SelectLastDecision(stageId int, stepName string) (*query.QualityGateDecision, error)
+	SelectLastDecisions(stageId int) ([]*query.QualityGateDecision, error)
+}
+
+type QualityGateRepository struct {
+}
+
+func (QualityGateRepository) SelectStageId(pipelineName, stageName string) (*int, error) {
+	o := orm.NewOrm()
+	var id int
+	err := o.Raw(selectStageId, pipelineName, stageName).QueryRow(&id)
+	if err == orm.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (QualityGateRepository) SelectManualGate(stageId int, stepName string) (*query.QualityGate, error) {
+	o := orm.NewOrm()
+	var gate query.QualityGate
+	err := o.QueryTable(new(query.QualityGate)).
+		Filter("cd_stage_id", stageId).
+		Filter("step_name", stepName).
+		Filter("quality_gate", "manual").
+		One(&gate)
+	if err == orm.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gate, nil
+}
+
+func (QualityGateRepository) CreateDecision(decision *query.QualityGateDecision) error {
+	o := orm.NewOrm()
+	_, err := o.Insert(decision)
+	return err
+}
+
+func (QualityGateRepository) SelectLastDecision(stageId int, stepName string) (*query.QualityGateDecision, error) {
+	o := orm.NewOrm()
+	var d query.QualityGateDecision
+	err := o.QueryTable(new(query.QualityGateDecision)).
+		Filter("cd_stage_id", stageId).
+		Filter("step_name", stepName).
+		OrderBy("-date").
+		Limit(1).
+		One(&d)
+	if err == orm.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (QualityGateRepository) SelectLastDecisions(stageId int) ([]*query.QualityGateDecision, error) {
+	o := orm.NewOrm()
+	var decisions []*query.QualityGateDecision
+	_, err := o.Raw(selectLastDecisions, stageId).QueryRows(&decisions)
+	if err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
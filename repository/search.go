@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"edp-admin-console/models/query"
+	"github.com/astaxie/beego/orm"
+)
+
+const selectSearchHits = "with q as (select ?::text as term, ?::text as pattern) " +
+	"select kind, name, parent, rank " +
+	"from ( " +
+	"	select 'codebase' as kind, c.name, c.type as parent, " +
+	"		greatest(public.similarity(c.name, q.term), " +
+	"			public.similarity(coalesce(c.description, ''), q.term), " +
+	"			public.similarity(coalesce(c.git_project_path, ''), q.term)) as rank " +
+	"	from codebase c, q " +
+	"	where c.archived_at is null " +
+	"	  and (c.name ilike q.pattern or c.name operator(public.%) q.term " +
+	"		or c.description ilike q.pattern or c.git_project_path ilike q.pattern) " +
+	"	union all " +
+	"	select 'branch', cb.name, c.name, public.similarity(cb.name, q.term) " +
+	"	from codebase_branch cb " +
+	"		join codebase c on cb.codebase_id = c.id, q " +
+	"	where c.archived_at is null " +
+	"	  and (cb.name ilike q.pattern or cb.name operator(public.%) q.term) " +
+	"	union all " +
+	"	select 'docker_stream', cds.oc_image_stream_name, c.name, public.similarity(cds.oc_image_stream_name, q.term) " +
+	"	from codebase_docker_stream cds " +
+	"		join codebase_branch cb on cds.codebase_branch_id = cb.id " +
+	"		join codebase c on cb.codebase_id = c.id, q " +
+	"	where c.archived_at is null " +
+	"	  and cds.oc_image_stream_name ilike q.pattern " +
+	"	union all " +
+	"	select 'cd_pipeline', cp.name, '', public.similarity(cp.name, q.term) " +
+	"	from cd_pipeline cp, q " +
+	"	where cp.name ilike q.pattern or cp.name operator(public.%) q.term " +
+	"	union all " +
+	"	select 'stage', cs.name, cp.name, public.similarity(cs.name, q.term) " +
+	"	from cd_stage cs " +
+	"		join cd_pipeline cp on cs.cd_pipeline_id = cp.id, q " +
+	"	where cs.name ilike q.pattern or cs.name operator(public.%) q.term " +
+	") hits " +
+	"order by rank desc, name " +
+	"limit ?;"
+
+type ISearchRepository interface {
+	SelectSearchHits(term, pattern string, limit int) ([]query.SearchHit, error)
+}
+
+type SearchRepository struct {
+}
+
+func (SearchRepository) SelectSearchHits(term, pattern string, limit int) ([]query.SearchHit, error) {
+	o := orm.NewOrm()
+	var hits []query.SearchHit
+	if _, err := o.Raw(selectSearchHits, term, pattern, limit).QueryRows(&hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
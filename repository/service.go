@@ -5,8 +5,22 @@ import (
 	"github.com/astaxie/beego/orm"
 )
 
+const (
+	selectPipelinesUsingService = "select p.name " +
+		"from cd_pipeline p " +
+		"		left join cd_pipeline_third_party_service ps on p.id = ps.cd_pipeline_id " +
+		"		left join third_party_service s on ps.service_id = s.id " +
+		"where s.name = ? " +
+		"order by p.name ;"
+)
+
 type IServiceCatalogRepository interface {
 	GetAllServices() ([]query.ThirdPartyService, error)
+	GetService(name string) (*query.ThirdPartyService, error)
+	CreateService(service *query.ThirdPartyService) error
+	UpdateService(service *query.ThirdPartyService) error
+	DeleteService(name string) error
+	SelectPipelinesUsingService(name string) ([]string, error)
 }
 
 type ServiceCatalogRepository struct {
@@ -27,3 +41,47 @@ func (ServiceCatalogRepository) GetAllServices() ([]query.ThirdPartyService, err
 
 	return services, nil
 }
+
+func (ServiceCatalogRepository) GetService(name string) (*query.ThirdPartyService, error) {
+	o := orm.NewOrm()
+	service := query.ThirdPartyService{Name: name}
+
+	err := o.Read(&service, "Name")
+	if err == orm.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
+
+func (ServiceCatalogRepository) CreateService(service *query.ThirdPartyService) error {
+	o := orm.NewOrm()
+	_, err := o.Insert(service)
+	return err
+}
+
+func (ServiceCatalogRepository) UpdateService(service *query.ThirdPartyService) error {
+	o := orm.NewOrm()
+	_, err := o.Update(service, "Version", "Description", "Url", "Icon", "Parameters", "SourceType", "SourceReference")
+	return err
+}
+
+func (ServiceCatalogRepository) DeleteService(name string) error {
+	o := orm.NewOrm()
+	_, err := o.QueryTable(new(query.ThirdPartyService)).
+		Filter("name", name).
+		Delete()
+	return err
+}
+
+func (ServiceCatalogRepository) SelectPipelinesUsingService(name string) ([]string, error) {
+	o := orm.NewOrm()
+	var pipelines []string
+	if _, err := o.Raw(selectPipelinesUsingService, name).QueryRows(&pipelines); err != nil {
+		return nil, err
+	}
+	return pipelines, nil
+}
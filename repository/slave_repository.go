@@ -5,8 +5,19 @@ import (
 	"github.com/astaxie/beego/orm"
 )
 
+const (
+	selectCodebasesUsingSlave = "select c.name " +
+		"from codebase c " +
+		"		left join jenkins_slave js on c.jenkins_slave_id = js.id " +
+		"where js.name = ? " +
+		"order by c.name ;"
+)
+
 type ISlaveRepository interface {
 	GetAllSlaves() ([]*query.JenkinsSlave, error)
+	GetSlave(name string) (*query.JenkinsSlave, error)
+	SelectCodebasesUsingSlave(name string) ([]string, error)
+	DeleteSlave(name string) error
 }
 
 type SlaveRepository struct {
@@ -25,3 +36,37 @@ func (s SlaveRepository) GetAllSlaves() ([]*query.JenkinsSlave, error) {
 
 	return jenkinsSlaves, nil
 }
+
+func (s SlaveRepository) GetSlave(name string) (*query.JenkinsSlave, error) {
+	o := orm.NewOrm()
+	slave := query.JenkinsSlave{}
+
+	err := o.QueryTable(new(query.JenkinsSlave)).
+		Filter("name", name).
+		One(&slave)
+	if err != nil {
+		if err == orm.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &slave, nil
+}
+
+func (s SlaveRepository) SelectCodebasesUsingSlave(name string) ([]string, error) {
+	o := orm.NewOrm()
+	var codebases []string
+	if _, err := o.Raw(selectCodebasesUsingSlave, name).QueryRows(&codebases); err != nil {
+		return nil, err
+	}
+	return codebases, nil
+}
+
+func (s SlaveRepository) DeleteSlave(name string) error {
+	o := orm.NewOrm()
+	_, err := o.QueryTable(new(query.JenkinsSlave)).
+		Filter("name", name).
+		Delete()
+	return err
+}
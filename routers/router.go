@@ -25,17 +25,31 @@ import (
 	"edp-admin-console/k8s"
 	"edp-admin-console/repository"
 	edpComponentRepo "edp-admin-console/repository/edp-component"
+	fwRepo "edp-admin-console/repository/freeze-window"
 	jirarepo "edp-admin-console/repository/jira-server"
 	perfRepo "edp-admin-console/repository/perfboard"
+	ptRepo "edp-admin-console/repository/pipeline-template"
+	qgRepo "edp-admin-console/repository/quality-gate"
 	"edp-admin-console/service"
 	"edp-admin-console/service/cd_pipeline"
 	cbs "edp-admin-console/service/codebasebranch"
+	"edp-admin-console/service/dependency"
 	edpComponentService "edp-admin-console/service/edp-component"
+	"edp-admin-console/service/export"
+	fws "edp-admin-console/service/freeze-window"
+	"edp-admin-console/service/gitref"
+	"edp-admin-console/service/graph"
 	jiraservice "edp-admin-console/service/jira-server"
+	"edp-admin-console/service/label"
 	"edp-admin-console/service/logger"
+	"edp-admin-console/service/metrics"
 	"edp-admin-console/service/perfboard"
+	pts "edp-admin-console/service/pipeline-template"
+	qgs "edp-admin-console/service/quality-gate"
+	"edp-admin-console/service/search"
 	"edp-admin-console/util"
 	"fmt"
+	"time"
 
 	"github.com/astaxie/beego"
 	"go.uber.org/zap"
@@ -51,6 +65,7 @@ const (
 	deploymentScript      = "deploymentScript"
 	ciTools               = "ciTools"
 	perfDataSources       = "perfDataSources"
+	archiveRetentionDays  = "archiveRetentionDays"
 
 	CreateStrategy = "Create"
 )
@@ -72,6 +87,8 @@ func init() {
 		beego.InsertFilter(fmt.Sprintf("%s/api/v1/edp/*", context.BasePath), beego.BeforeRouter, filters.AuthRestFilter)
 		beego.InsertFilter(fmt.Sprintf("%s/admin/edp/*", context.BasePath), beego.BeforeRouter, filters.RoleAccessControlFilter)
 		beego.InsertFilter(fmt.Sprintf("%s/api/v1/edp/*", context.BasePath), beego.BeforeRouter, filters.RoleAccessControlRestFilter)
+		beego.InsertFilter(fmt.Sprintf("%s/api/v1/repository/available", context.BasePath), beego.BeforeRouter, filters.AuthRestFilter)
+		beego.InsertFilter(fmt.Sprintf("%s/api/v1/repository/available", context.BasePath), beego.BeforeRouter, filters.RoleAccessControlRestFilter)
 	} else {
 		beego.InsertFilter(fmt.Sprintf("%s/*", context.BasePath), beego.BeforeRouter, filters.StubAuthFilter)
 	}
@@ -97,14 +114,71 @@ func init() {
 	ecr := edpComponentRepo.EDPComponent{}
 	jsr := jirarepo.JiraServer{}
 	psr := perfRepo.PerfServer{}
-
-	thirdPartyService := service.ThirdPartyService{IServiceCatalogRepository: serviceRepository}
-	gitServerService := service.GitServerService{IGitServerRepository: gitServerRepository}
-	ss := service.SlaveService{ISlaveRepository: sr}
-	ps := service.JobProvisioning{IJobProvisioningRepository: pr}
-	js := jiraservice.JiraServer{IJiraServer: jsr}
-	pbs := perfboard.PerfBoard{PerfRepo: psr}
-	ecs := edpComponentService.EDPComponentService{IEDPComponent: ecr}
+	ptr := ptRepo.PipelineTemplateRepository{}
+	qgr := qgRepo.QualityGateRepository{}
+	fwr := fwRepo.FreezeWindowRepository{}
+	lr := repository.LabelRepository{}
+	searchRepository := repository.SearchRepository{}
+	metricsRepository := repository.MetricsRepository{}
+
+	thirdPartyService := service.ThirdPartyService{Clients: clients, IServiceCatalogRepository: serviceRepository}
+	gitServerService := service.GitServerService{Clients: clients, IGitServerRepository: gitServerRepository}
+	ss := service.SlaveService{Clients: clients, ISlaveRepository: sr}
+	ps := service.JobProvisioning{Clients: clients, IJobProvisioningRepository: pr}
+	js := jiraservice.JiraServer{Clients: clients, IJiraServer: jsr, ICodebaseRepository: codebaseRepository}
+	perfDataSources := util.GetValuesFromConfig(perfDataSources)
+	if perfDataSources == nil {
+		log.Fatal("perfDataSources config variable is empty.")
+	}
+	pbs := perfboard.PerfBoard{Clients: clients, PerfRepo: psr, DataSources: perfDataSources}
+	ecs := edpComponentService.EDPComponentService{Clients: clients, IEDPComponent: ecr}
+	pipelineTemplateService := pts.PipelineTemplateService{ITemplateRepository: ptr}
+	freezeWindowService := fws.FreezeWindowService{IFreezeWindowRepository: fwr}
+	qualityGateService := qgs.QualityGateService{
+		Clients:                clients,
+		IQualityGateRepository: qgr,
+		FreezeWindowService:    freezeWindowService,
+	}
+	labelService := label.LabelService{
+		Clients:               clients,
+		ILabelRepository:      lr,
+		ICodebaseRepository:   codebaseRepository,
+		ICDPipelineRepository: pipelineRepository,
+	}
+	gitRefService := gitref.GitRefService{
+		Clients:              clients,
+		ICodebaseRepository:  codebaseRepository,
+		IGitServerRepository: gitServerRepository,
+		EDPComponent:         ecs,
+		Credentials: util.GitCredentials{
+			Username: beego.AppConfig.String("dependencyScanUser"),
+			Password: beego.AppConfig.String("dependencyScanPassword"),
+			Token:    beego.AppConfig.String("dependencyScanToken"),
+		},
+	}
+	dependencyService := dependency.DependencyService{
+		ICodebaseRepository:   codebaseRepository,
+		ICDPipelineRepository: pipelineRepository,
+		GitRefService:         gitRefService,
+	}
+	graphService := graph.GraphService{
+		ICodebaseRepository:   codebaseRepository,
+		ICDPipelineRepository: pipelineRepository,
+	}
+	searchService := search.SearchService{
+		ISearchRepository:     searchRepository,
+		ICDPipelineRepository: pipelineRepository,
+	}
+	exportService := export.ExportService{
+		ICodebaseRepository:   codebaseRepository,
+		ICDPipelineRepository: pipelineRepository,
+	}
+	metricsService := metrics.MetricsService{
+		IMetricsRepository:    metricsRepository,
+		ICodebaseRepository:   codebaseRepository,
+		ICDPipelineRepository: pipelineRepository,
+		TeamLabel:             beego.AppConfig.DefaultString("doraTeamLabel", "team"),
+	}
 	edpService := service.EDPTenantService{Clients: clients}
 	clusterService := service.ClusterService{Clients: clients}
 	branchService := cbs.CodebaseBranchService{
@@ -112,25 +186,42 @@ func init() {
 		IReleaseBranchRepository: branchRepository,
 		ICDPipelineRepository:    pipelineRepository,
 		ICodebaseRepository:      codebaseRepository,
+		GitRefService:            gitRefService,
 		CodebaseBranchValidation: map[string]func(string, string) ([]string, error){
 			"application": pipelineRepository.GetCDPipelinesUsingApplicationAndBranch,
 			"autotests":   pipelineRepository.GetCDPipelinesUsingAutotestAndBranch,
 			"library":     pipelineRepository.GetCDPipelinesUsingLibraryAndBranch,
 		},
 	}
+	ciTools := util.GetValuesFromConfig(ciTools)
+	if ciTools == nil {
+		log.Fatal("ciTools config variable is empty.")
+	}
+
 	codebaseService := service.CodebaseService{
 		Clients:               clients,
 		ICodebaseRepository:   codebaseRepository,
 		ICDPipelineRepository: pipelineRepository,
 		BranchService:         branchService,
 		PerfService:           pbs,
+		GitServerService:      gitServerService,
+		SlaveService:          ss,
+		JobProvisioning:       ps,
+		JiraServer:            js,
+		CiTools:               ciTools,
 	}
+	if retention := beego.AppConfig.DefaultInt(archiveRetentionDays, 0); retention > 0 {
+		go codebaseService.ScheduleArchivePurge(time.Duration(retention) * 24 * time.Hour)
+	}
+	go codebaseService.ScheduleTempSecretSweep()
 	pipelineService := cd_pipeline.CDPipelineService{
 		Clients:               clients,
 		ICDPipelineRepository: pipelineRepository,
 		CodebaseService:       codebaseService,
 		BranchService:         branchService,
 		EDPComponent:          ecs,
+		FreezeWindowService:   freezeWindowService,
+		ThirdPartyService:     thirdPartyService,
 	}
 
 	beego.ErrorController(&controllers.ErrorController{})
@@ -162,16 +253,6 @@ func init() {
 		log.Fatal("deploymentScript config variable is empty.")
 	}
 
-	ciTools := util.GetValuesFromConfig(ciTools)
-	if ciTools == nil {
-		log.Fatal("ciTools config variable is empty.")
-	}
-
-	perfDataSources := util.GetValuesFromConfig(perfDataSources)
-	if perfDataSources == nil {
-		log.Fatal("perfDataSources config variable is empty.")
-	}
-
 	is := make([]string, len(integrationStrategies))
 	copy(is, integrationStrategies)
 
@@ -184,6 +265,7 @@ func init() {
 		JobProvisioning:  ps,
 		JiraServer:       js,
 		PerfService:      pbs,
+		Dependency:       dependencyService,
 
 		IntegrationStrategies: is,
 		BuildTools:            buildTools,
@@ -243,6 +325,13 @@ func init() {
 		BranchService:    branchService,
 		GitServerService: gitServerService,
 		EDPComponent:     ecs,
+		SlaveService:     ss,
+		JobProvisioning:  ps,
+		JiraServer:       js,
+		PerfService:      pbs,
+		LabelService:     labelService,
+		CiTools:          ciTools,
+		PerfDataSources:  perfDataSources,
 	}
 
 	cpc := cdPipeController.CDPipelineController{
@@ -253,6 +342,15 @@ func init() {
 		ThirdPartyService: thirdPartyService,
 		EDPComponent:      ecs,
 		JobProvisioning:   ps,
+		PipelineTemplate:  pipelineTemplateService,
+		QualityGate:       qualityGateService,
+		FreezeWindow:      freezeWindowService,
+		Label:             labelService,
+	}
+
+	cptc := cdPipeController.CDPipelineTemplateController{
+		PipelineService:         pipelineService,
+		PipelineTemplateService: pipelineTemplateService,
 	}
 
 	cbc := controllers.BranchController{
@@ -269,6 +367,39 @@ func init() {
 		PipelineService: pipelineService,
 	}
 
+	sc := controllers.SearchController{
+		SearchService: searchService,
+	}
+
+	mc := controllers.MetricsController{
+		MetricsService: metricsService,
+	}
+
+	exc := controllers.ExportController{
+		ExportService: exportService,
+	}
+
+	gsc := controllers.GitServerController{
+		GitServerService: gitServerService,
+	}
+
+	jsc := controllers.JiraServerController{
+		JiraServer: js,
+	}
+
+	psc := controllers.PerfServerController{
+		PerfBoard: pbs,
+	}
+
+	jc := controllers.JenkinsController{
+		SlaveService:    ss,
+		JobProvisioning: ps,
+	}
+
+	ecc := controllers.EDPComponentController{
+		EDPComponent: ecs,
+	}
+
 	adminEdpNamespace := beego.NewNamespace(fmt.Sprintf("%s/admin/edp", context.BasePath),
 		beego.NSRouter("/overview", &ec, "get:GetEDPComponents"),
 		beego.NSRouter("/application/overview", &appc, "get:GetApplicationsOverviewPage"),
@@ -281,6 +412,14 @@ func init() {
 		beego.NSRouter("/cd-pipeline", &cpc, "post:CreateCDPipeline"),
 		beego.NSRouter("/cd-pipeline/:name/update", &cpc, "post:UpdateCDPipeline"),
 		beego.NSRouter("/cd-pipeline/:pipelineName/overview", &cpc, "get:GetCDPipelineOverviewPage"),
+		beego.NSRouter("/cd-pipeline/:name/clone", &cpc, "get:GetCloneCDPipelinePage"),
+		beego.NSRouter("/cd-pipeline/:name/clone", &cpc, "post:CloneCDPipeline"),
+		beego.NSRouter("/cd-pipeline/:pipelineName/stage/:stageName/gates/:step/decision", &cpc, "post:MakeQualityGateDecision"),
+		beego.NSRouter("/cd-pipeline/:pipelineName/freeze-window", &cpc, "post:CreateFreezeWindow"),
+		beego.NSRouter("/cd-pipeline/:pipelineName/freeze-window/delete", &cpc, "post:DeleteFreezeWindow"),
+		beego.NSRouter("/cd-pipeline-template/overview", &cptc, "get:GetTemplatesPage"),
+		beego.NSRouter("/cd-pipeline-template", &cptc, "post:CreateTemplate"),
+		beego.NSRouter("/cd-pipeline-template/delete", &cptc, "post:DeleteTemplate"),
 		beego.NSRouter("/autotest/overview", &autc, "get:GetAutotestsOverviewPage"),
 		beego.NSRouter("/autotest/create", &autc, "get:GetCreateAutotestsPage"),
 		beego.NSRouter("/autotest", &autc, "post:CreateAutotests"),
@@ -290,17 +429,86 @@ func init() {
 		beego.NSRouter("/codebase/branch/delete", &cbc, "post:Delete"),
 		beego.NSRouter("/codebase/:name/update", &cc, "get:GetEditCodebasePage"),
 		beego.NSRouter("/codebase/:name/update", &cc, "post:Update"),
+		beego.NSRouter("/codebase/import", &cc, "get:GetImportCodebasesPage"),
+		beego.NSRouter("/codebase/import", &cc, "post:ImportCodebases"),
+		beego.NSRouter("/codebase/archive", &cc, "get:GetArchivedCodebasesPage"),
+		beego.NSRouter("/codebase/archive", &cc, "post:Archive"),
+		beego.NSRouter("/codebase/restore", &cc, "post:Restore"),
+		beego.NSRouter("/codebase/temp-secret", &cc, "get:GetTempSecretsPage"),
+		beego.NSRouter("/codebase/temp-secret/delete", &cc, "post:DeleteTempSecret"),
+		beego.NSRouter("/codebase/temp-secret/sweep", &cc, "post:SweepTempSecrets"),
 		beego.NSRouter("/stage", &cpc, "post:DeleteCDStage"),
 		beego.NSRouter("/cd-pipeline/delete", &cpc, "post:DeleteCDPipeline"),
 		beego.NSRouter("/codebase/:codebaseName/branch", &cbc, "post:CreateCodebaseBranch"),
+		beego.NSRouter("/codebase/release", &cbc, "post:CutReleases"),
 
 		beego.NSRouter("/library/overview", &lc, "get:GetLibraryListPage"),
 		beego.NSRouter("/library/create", &lc, "get:GetCreatePage"),
 		beego.NSRouter("/library", &lc, "post:Create"),
+		beego.NSRouter("/library/:libraryName/impact", &lc, "get:GetLibraryImpactPage"),
 
 		beego.NSRouter("/service/overview", &tpsc, "get:GetServicePage"),
+		beego.NSRouter("/service/create", &tpsc, "get:GetCreateServicePage"),
+		beego.NSRouter("/service", &tpsc, "post:CreateService"),
+		beego.NSRouter("/service/delete", &tpsc, "post:DeleteService"),
+		beego.NSRouter("/service/:name/update", &tpsc, "get:GetEditServicePage"),
+		beego.NSRouter("/service/:name/update", &tpsc, "post:UpdateService"),
 
 		beego.NSRouter("/diagram/overview", &dc, "get:GetDiagramPage"),
+
+		beego.NSRouter("/search", &sc, "get:GetSearchPage"),
+
+		beego.NSRouter("/metrics/dora", &mc, "get:GetDoraMetricsPage"),
+
+		beego.NSRouter("/application/export", &exc, "get:ExportApplications"),
+		beego.NSRouter("/library/export", &exc, "get:ExportLibraries"),
+		beego.NSRouter("/autotest/export", &exc, "get:ExportAutotests"),
+		beego.NSRouter("/cd-pipeline/export", &exc, "get:ExportCDPipelines"),
+
+		beego.NSRouter("/git-server/overview", &gsc, "get:GetGitServersPage"),
+		beego.NSRouter("/git-server/create", &gsc, "get:GetCreateGitServerPage"),
+		beego.NSRouter("/git-server", &gsc, "post:CreateGitServer"),
+		beego.NSRouter("/git-server/:name/update", &gsc, "get:GetEditGitServerPage"),
+		beego.NSRouter("/git-server/:name/update", &gsc, "post:UpdateGitServer"),
+		beego.NSRouter("/git-server/:name/test", &gsc, "post:TestConnection"),
+		beego.NSRouter("/git-server/:name/disable", &gsc, "post:DisableGitServer"),
+		beego.NSRouter("/git-server/:name/enable", &gsc, "post:EnableGitServer"),
+
+		beego.NSRouter("/jira-server/overview", &jsc, "get:GetJiraServersPage"),
+		beego.NSRouter("/jira-server/create", &jsc, "get:GetCreateJiraServerPage"),
+		beego.NSRouter("/jira-server", &jsc, "post:CreateJiraServer"),
+		beego.NSRouter("/jira-server/delete", &jsc, "post:DeleteJiraServer"),
+		beego.NSRouter("/jira-server/ticket-pattern", &jsc, "post:CheckTicketPattern"),
+		beego.NSRouter("/jira-server/:name/update", &jsc, "get:GetEditJiraServerPage"),
+		beego.NSRouter("/jira-server/:name/update", &jsc, "post:UpdateJiraServer"),
+		beego.NSRouter("/jira-server/:name/test", &jsc, "post:TestConnection"),
+
+		beego.NSRouter("/perf-server/overview", &psc, "get:GetPerfServersPage"),
+		beego.NSRouter("/perf-server/create", &psc, "get:GetCreatePerfServerPage"),
+		beego.NSRouter("/perf-server", &psc, "post:CreatePerfServer"),
+		beego.NSRouter("/perf-server/delete", &psc, "post:DeletePerfServer"),
+		beego.NSRouter("/perf-server/:name/update", &psc, "get:GetEditPerfServerPage"),
+		beego.NSRouter("/perf-server/:name/update", &psc, "post:UpdatePerfServer"),
+		beego.NSRouter("/perf-server/:name/test", &psc, "post:TestConnection"),
+
+		beego.NSRouter("/jenkins/overview", &jc, "get:GetJenkinsPage"),
+		beego.NSRouter("/jenkins/slave", &jc, "post:CreateSlave"),
+		beego.NSRouter("/jenkins/slave/delete", &jc, "post:DeleteSlave"),
+		beego.NSRouter("/jenkins/slave/:name/update", &jc, "get:GetEditSlavePage"),
+		beego.NSRouter("/jenkins/slave/:name/update", &jc, "post:UpdateSlave"),
+		beego.NSRouter("/jenkins/job-provisioner", &jc, "post:CreateJobProvisioner"),
+		beego.NSRouter("/jenkins/job-provisioner/delete", &jc, "post:DeleteJobProvisioner"),
+		beego.NSRouter("/jenkins/job-provisioner/:scope/:name/update", &jc, "get:GetEditJobProvisionerPage"),
+		beego.NSRouter("/jenkins/job-provisioner/:scope/:name/update", &jc, "post:UpdateJobProvisioner"),
+
+		beego.NSRouter("/edp-component/overview", &ecc, "get:GetEDPComponentsPage"),
+		beego.NSRouter("/edp-component/create", &ecc, "get:GetCreateEDPComponentPage"),
+		beego.NSRouter("/edp-component", &ecc, "post:CreateEDPComponent"),
+		beego.NSRouter("/edp-component/delete", &ecc, "post:DeleteEDPComponent"),
+		beego.NSRouter("/edp-component/:type/update", &ecc, "get:GetEditEDPComponentPage"),
+		beego.NSRouter("/edp-component/:type/update", &ecc, "post:UpdateEDPComponent"),
+		beego.NSRouter("/edp-component/:type/show", &ecc, "post:ShowEDPComponent"),
+		beego.NSRouter("/edp-component/:type/hide", &ecc, "post:HideEDPComponent"),
 	)
 	beego.AddNamespace(adminEdpNamespace)
 
@@ -308,19 +516,97 @@ func init() {
 		beego.NSRouter("/codebase", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "post:CreateCodebase"),
 		beego.NSRouter("/codebase", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "get:GetCodebases"),
 		beego.NSRouter("/codebase/:codebaseName", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "get:GetCodebase"),
+		beego.NSRouter("/codebase/:codebaseName", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "put:UpdateCodebase"),
+		beego.NSRouter("/codebase/import", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "post:BulkImportCodebases"),
+		beego.NSRouter("/codebase/release", &controllers.CodebaseReleaseRestController{BranchService: branchService}, "post:CutReleases"),
+		beego.NSRouter("/codebase/:codebaseName/release", &controllers.CodebaseReleaseRestController{BranchService: branchService}, "post:CutRelease"),
+		beego.NSRouter("/codebase/:codebaseName/labels", &controllers.LabelRestController{LabelService: labelService}, "put:UpdateCodebaseLabels"),
+		beego.NSRouter("/codebase/:codebaseName/archive", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "post:ArchiveCodebase"),
+		beego.NSRouter("/codebase/:codebaseName/restore", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "post:RestoreCodebase"),
+		beego.NSRouter("/temp-secret", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "get:GetTempSecrets"),
+		beego.NSRouter("/temp-secret/sweep", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "post:SweepTempSecrets"),
+		beego.NSRouter("/temp-secret/:name", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "delete:DeleteTempSecret"),
 		beego.NSRouter("/vcs", &ec, "get:GetVcsIntegrationValue"),
+		beego.NSRouter("/cd-pipeline", &controllers.CDPipelineRestController{CDPipelineService: pipelineService}, "get:GetCDPipelines"),
 		beego.NSRouter("/cd-pipeline/:name", &controllers.CDPipelineRestController{CDPipelineService: pipelineService}, "get:GetCDPipelineByName"),
+		beego.NSRouter("/cd-pipeline/:name/labels", &controllers.LabelRestController{LabelService: labelService}, "put:UpdateCDPipelineLabels"),
 		beego.NSRouter("/cd-pipeline/:pipelineName/stage/:stageName", &controllers.CDPipelineRestController{CDPipelineService: pipelineService}, "get:GetStage"),
-		beego.NSRouter("/cd-pipeline", &controllers.CDPipelineRestController{CDPipelineService: pipelineService}, "post:CreateCDPipeline"),
+		beego.NSRouter("/cd-pipeline", &controllers.CDPipelineRestController{CDPipelineService: pipelineService, PipelineTemplateService: pipelineTemplateService}, "post:CreateCDPipeline"),
 		beego.NSRouter("/cd-pipeline/:name", &controllers.CDPipelineRestController{CDPipelineService: pipelineService}, "put:UpdateCDPipeline"),
+		beego.NSRouter("/cd-pipeline/:name", &controllers.CDPipelineRestController{CDPipelineService: pipelineService}, "delete:DeleteCDPipeline"),
+		beego.NSRouter("/cd-pipeline/:name/clone", &controllers.CDPipelineRestController{CDPipelineService: pipelineService}, "post:CloneCDPipeline"),
 		beego.NSRouter("/codebase", &controllers.CodebaseRestController{CodebaseService: codebaseService}, "delete:Delete"),
 		beego.NSRouter("/stage", &controllers.CDPipelineRestController{CDPipelineService: pipelineService}, "delete:DeleteCDStage"),
+		beego.NSRouter("/cd-pipeline-template", &controllers.CDPipelineTemplateRestController{PipelineTemplateService: pipelineTemplateService}, "get:GetTemplates"),
+		beego.NSRouter("/cd-pipeline-template", &controllers.CDPipelineTemplateRestController{PipelineTemplateService: pipelineTemplateService}, "post:CreateTemplate"),
+		beego.NSRouter("/cd-pipeline-template/:name", &controllers.CDPipelineTemplateRestController{PipelineTemplateService: pipelineTemplateService}, "get:GetTemplate"),
+		beego.NSRouter("/cd-pipeline-template/:name", &controllers.CDPipelineTemplateRestController{PipelineTemplateService: pipelineTemplateService}, "put:UpdateTemplate"),
+		beego.NSRouter("/cd-pipeline-template/:name", &controllers.CDPipelineTemplateRestController{PipelineTemplateService: pipelineTemplateService}, "delete:DeleteTemplate"),
+		beego.NSRouter("/cd-pipeline/:pipelineName/stage/:stageName/gates/:step/decision", &controllers.QualityGateRestController{QualityGateService: qualityGateService}, "post:MakeDecision"),
+		beego.NSRouter("/cd-pipeline/:pipelineName/stage/:stageName/gates/:step/decision", &controllers.QualityGateRestController{QualityGateService: qualityGateService}, "get:GetDecision"),
+		beego.NSRouter("/cd-pipeline/:pipelineName/stage/:stageName/freeze", &controllers.FreezeWindowRestController{FreezeWindowService: freezeWindowService}, "get:GetFreezeStatus"),
+		beego.NSRouter("/cd-pipeline/:pipelineName/stage/:stageName/freeze-window", &controllers.FreezeWindowRestController{FreezeWindowService: freezeWindowService}, "get:GetFreezeWindows"),
+		beego.NSRouter("/cd-pipeline/:pipelineName/stage/:stageName/freeze-window", &controllers.FreezeWindowRestController{FreezeWindowService: freezeWindowService}, "post:CreateFreezeWindow"),
+		beego.NSRouter("/cd-pipeline/:pipelineName/stage/:stageName/freeze-window/:id", &controllers.FreezeWindowRestController{FreezeWindowService: freezeWindowService}, "delete:DeleteFreezeWindow"),
+		beego.NSRouter("/git-ref/:codebaseName", &controllers.GitRefRestController{GitRefService: gitRefService}, "get:GetCodebaseRefs"),
+		beego.NSRouter("/git-ref/:codebaseName/commits", &controllers.GitRefRestController{GitRefService: gitRefService}, "get:GetCodebaseCommits"),
+		beego.NSRouter("/repository/available", &controllers.RepositoryRestController{AppService: codebaseService}, "post:IsGitRepoAvailable"),
+		beego.NSRouter("/repository/refs", &controllers.RepositoryRestController{AppService: codebaseService, GitRefService: gitRefService}, "post:GetRepositoryRefs"),
+		beego.NSRouter("/dependency", &controllers.DependencyRestController{DependencyService: dependencyService}, "get:GetDependencyGraph"),
+		beego.NSRouter("/dependency/:libraryName/impact", &controllers.DependencyRestController{DependencyService: dependencyService}, "get:GetLibraryImpact"),
+		beego.NSRouter("/graph", &controllers.GraphRestController{GraphService: graphService}, "get:GetGraph"),
+		beego.NSRouter("/search", &controllers.SearchRestController{SearchService: searchService}, "get:Search"),
+		beego.NSRouter("/metrics/dora", &controllers.MetricsRestController{MetricsService: metricsService}, "get:GetDoraMetrics"),
+		beego.NSRouter("/metrics/events/deploy", &controllers.MetricsRestController{MetricsService: metricsService}, "post:CreateDeploymentEvent"),
+		beego.NSRouter("/metrics/events/build", &controllers.MetricsRestController{MetricsService: metricsService}, "post:CreateBuildEvent"),
+		beego.NSRouter("/git-server", &controllers.GitServerRestController{GitServerService: gitServerService}, "get:GetGitServers"),
+		beego.NSRouter("/git-server", &controllers.GitServerRestController{GitServerService: gitServerService}, "post:CreateGitServer"),
+		beego.NSRouter("/git-server/:name", &controllers.GitServerRestController{GitServerService: gitServerService}, "get:GetGitServer"),
+		beego.NSRouter("/git-server/:name", &controllers.GitServerRestController{GitServerService: gitServerService}, "put:UpdateGitServer"),
+		beego.NSRouter("/git-server/:name/test", &controllers.GitServerRestController{GitServerService: gitServerService}, "post:TestConnection"),
+		beego.NSRouter("/git-server/:name/disable", &controllers.GitServerRestController{GitServerService: gitServerService}, "post:DisableGitServer"),
+		beego.NSRouter("/git-server/:name/enable", &controllers.GitServerRestController{GitServerService: gitServerService}, "post:EnableGitServer"),
+		beego.NSRouter("/jira-server", &controllers.JiraServerRestController{JiraServer: js}, "get:GetJiraServers"),
+		beego.NSRouter("/jira-server", &controllers.JiraServerRestController{JiraServer: js}, "post:CreateJiraServer"),
+		beego.NSRouter("/jira-server/:name", &controllers.JiraServerRestController{JiraServer: js}, "get:GetJiraServer"),
+		beego.NSRouter("/jira-server/:name", &controllers.JiraServerRestController{JiraServer: js}, "put:UpdateJiraServer"),
+		beego.NSRouter("/jira-server/:name", &controllers.JiraServerRestController{JiraServer: js}, "delete:DeleteJiraServer"),
+		beego.NSRouter("/jira-server/:name/test", &controllers.JiraServerRestController{JiraServer: js}, "post:TestConnection"),
+
+		beego.NSRouter("/perf-server", &controllers.PerfServerRestController{PerfBoard: pbs}, "get:GetPerfServers"),
+		beego.NSRouter("/perf-server", &controllers.PerfServerRestController{PerfBoard: pbs}, "post:CreatePerfServer"),
+		beego.NSRouter("/perf-server/:name", &controllers.PerfServerRestController{PerfBoard: pbs}, "get:GetPerfServer"),
+		beego.NSRouter("/perf-server/:name", &controllers.PerfServerRestController{PerfBoard: pbs}, "put:UpdatePerfServer"),
+		beego.NSRouter("/perf-server/:name", &controllers.PerfServerRestController{PerfBoard: pbs}, "delete:DeletePerfServer"),
+		beego.NSRouter("/perf-server/:name/test", &controllers.PerfServerRestController{PerfBoard: pbs}, "post:TestConnection"),
+		beego.NSRouter("/codebase/:codebaseName/ticket-pattern", &controllers.JiraServerRestController{JiraServer: js}, "get:CheckTicketPattern"),
+		beego.NSRouter("/jenkins/slave", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "get:GetSlaves"),
+		beego.NSRouter("/jenkins/slave", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "post:CreateSlave"),
+		beego.NSRouter("/jenkins/slave/:name", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "get:GetSlave"),
+		beego.NSRouter("/jenkins/slave/:name", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "put:UpdateSlave"),
+		beego.NSRouter("/jenkins/slave/:name", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "delete:DeleteSlave"),
+		beego.NSRouter("/jenkins/job-provisioner", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "get:GetJobProvisioners"),
+		beego.NSRouter("/jenkins/job-provisioner", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "post:CreateJobProvisioner"),
+		beego.NSRouter("/jenkins/job-provisioner/:scope/:name", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "get:GetJobProvisioner"),
+		beego.NSRouter("/jenkins/job-provisioner/:scope/:name", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "put:UpdateJobProvisioner"),
+		beego.NSRouter("/jenkins/job-provisioner/:scope/:name", &controllers.JenkinsRestController{SlaveService: ss, JobProvisioning: ps}, "delete:DeleteJobProvisioner"),
+		beego.NSRouter("/service", &controllers.ThirdPartyServiceRestController{ThirdPartyService: thirdPartyService}, "get:GetServices"),
+		beego.NSRouter("/service", &controllers.ThirdPartyServiceRestController{ThirdPartyService: thirdPartyService}, "post:CreateService"),
+		beego.NSRouter("/service/:name", &controllers.ThirdPartyServiceRestController{ThirdPartyService: thirdPartyService}, "get:GetService"),
+		beego.NSRouter("/service/:name", &controllers.ThirdPartyServiceRestController{ThirdPartyService: thirdPartyService}, "put:UpdateService"),
+		beego.NSRouter("/service/:name", &controllers.ThirdPartyServiceRestController{ThirdPartyService: thirdPartyService}, "delete:DeleteService"),
+		beego.NSRouter("/edp-component", &controllers.EDPComponentRestController{EDPComponent: ecs}, "get:GetEDPComponents"),
+		beego.NSRouter("/edp-component", &controllers.EDPComponentRestController{EDPComponent: ecs}, "post:CreateEDPComponent"),
+		beego.NSRouter("/edp-component/:type", &controllers.EDPComponentRestController{EDPComponent: ecs}, "get:GetEDPComponent"),
+		beego.NSRouter("/edp-component/:type", &controllers.EDPComponentRestController{EDPComponent: ecs}, "put:UpdateEDPComponent"),
+		beego.NSRouter("/edp-component/:type", &controllers.EDPComponentRestController{EDPComponent: ecs}, "delete:DeleteEDPComponent"),
 	)
 	beego.AddNamespace(apiV1EdpNamespace)
 
 	apiV1Namespace := beego.NewNamespace(fmt.Sprintf("%s/api/v1", context.BasePath),
 		beego.NSRouter("/storage-class", &controllers.OpenshiftRestController{ClusterService: clusterService}, "get:GetAllStorageClasses"),
-		beego.NSRouter("/repository/available", &controllers.RepositoryRestController{}, "post:IsGitRepoAvailable"),
+		//deprecated path of /api/v1/edp/repository/available which is kept for existing clients
+		beego.NSRouter("/repository/available", &controllers.RepositoryRestController{AppService: codebaseService}, "post:IsGitRepoAvailable"),
 	)
 	beego.AddNamespace(apiV1Namespace)
 }
@@ -21,14 +21,17 @@ import (
 	"edp-admin-console/k8s"
 	"edp-admin-console/models"
 	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
 	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository"
 	"edp-admin-console/service"
 	cbs "edp-admin-console/service/codebasebranch"
 	ec "edp-admin-console/service/edp-component"
+	fws "edp-admin-console/service/freeze-window"
 	"edp-admin-console/service/logger"
 	"edp-admin-console/service/platform"
+	"edp-admin-console/util"
 	"edp-admin-console/util/consts"
 	dberror "edp-admin-console/util/error/db-errors"
 	"fmt"
@@ -53,6 +56,8 @@ type CDPipelineService struct {
 	CodebaseService       service.CodebaseService
 	BranchService         cbs.CodebaseBranchService
 	EDPComponent          ec.EDPComponentService
+	FreezeWindowService   fws.FreezeWindowService
+	ThirdPartyService     service.ThirdPartyService
 }
 
 type ErrMsg struct {
@@ -94,6 +99,12 @@ func (s *CDPipelineService) CreatePipeline(cdPipeline command.CDPipelineCommand)
 		return nil, edperror.NewCDPipelineExistsError()
 	}
 
+	cdPipeline.Stages = addPipelineServices(cdPipeline.Stages, cdPipeline.ThirdPartyServices)
+	if _, err := s.ThirdPartyService.GetServiceVersions(getStageServiceNames(cdPipeline.Stages)); err != nil {
+		return nil, err
+	}
+	cdPipeline.ThirdPartyServices = getStageServiceNames(cdPipeline.Stages)
+
 	crd := &edppipelinesv1alpha1.CDPipeline{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v2.edp.epam.com/v1alpha1",
@@ -177,7 +188,12 @@ func (s *CDPipelineService) CreateStages(edpRestClient *rest.RESTClient, cdPipel
 		return nil, errors.Wrap(err, "couldn't check stages in cluster")
 	}
 
-	stagesCr, err := saveStagesIntoK8s(edpRestClient, cdPipeline.Name, cdPipeline.Stages, cdPipeline.Username)
+	versions, err := s.ThirdPartyService.GetServiceVersions(getStageServiceNames(cdPipeline.Stages))
+	if err != nil {
+		return nil, err
+	}
+
+	stagesCr, err := saveStagesIntoK8s(edpRestClient, cdPipeline.Name, cdPipeline.Stages, cdPipeline.Username, versions)
 	if err != nil {
 		return nil, err
 	}
@@ -217,6 +233,12 @@ func (s *CDPipelineService) UpdatePipeline(pipeline command.CDPipelineCommand) e
 		return edperror.NewCDPipelineDoesNotExistError()
 	}
 
+	for _, stage := range cdPipelineReadModel.Stage {
+		if err := s.FreezeWindowService.CheckStageIsNotFrozen(pipeline.Name, stage.Name); err != nil {
+			return err
+		}
+	}
+
 	pipelineCR, err := s.getCDPipelineCR(pipeline.Name)
 	if err != nil {
 		return err
@@ -226,6 +248,14 @@ func (s *CDPipelineService) UpdatePipeline(pipeline command.CDPipelineCommand) e
 		return edperror.NewCDPipelineDoesNotExistError()
 	}
 
+	if _, err := s.ThirdPartyService.GetServiceVersions(getStageServiceNames(pipeline.Stages)); err != nil {
+		return err
+	}
+	services, err := s.updateStageServices(pipeline.Name, pipeline.StageServices)
+	if err != nil {
+		return err
+	}
+
 	if pipeline.Applications != nil {
 		log.Debug("start updating Autotest",
 			zap.String("pipe name", pipelineCR.Spec.Name),
@@ -238,6 +268,7 @@ func (s *CDPipelineService) UpdatePipeline(pipeline command.CDPipelineCommand) e
 	}
 
 	pipelineCR.Spec.ApplicationsToPromote = pipeline.ApplicationToApprove
+	pipelineCR.Spec.ThirdPartyServices = mergeServiceNames(services, pipeline.Stages)
 	pipelineCR.Status.LastTimeUpdated = time.Now()
 
 	edpRestClient := s.Clients.EDPRestClient
@@ -264,6 +295,30 @@ func (s *CDPipelineService) UpdatePipeline(pipeline command.CDPipelineCommand) e
 	return nil
 }
 
+//updateStageServices sets services of the passed stages and returns names of the services used by any stage of the pipeline
+func (s *CDPipelineService) updateStageServices(pipelineName string, stageServices map[string][]string) ([]string, error) {
+	for stage, names := range stageServices {
+		if err := s.ThirdPartyService.SetStageServices(pipelineName, stage, names); err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := s.ThirdPartyService.GetStageServices(pipelineName)
+	if err != nil {
+		return nil, err
+	}
+	var services []string
+	for _, names := range current {
+		for _, name := range names {
+			if !util.Contains(services, name) {
+				services = append(services, name)
+			}
+		}
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
 func sortStagesByOrder(stages []*query.Stage) {
 	sort.Slice(stages, func(i, j int) bool {
 		return stages[i].Order < stages[j].Order
@@ -433,15 +488,20 @@ func (s *CDPipelineService) getCDPipelineCR(pipelineName string) (*edppipelinesv
 	return cdPipeline, nil
 }
 
-func createCr(cdPipelineName string, stage command.CDStageCommand) edppipelinesv1alpha1.Stage {
+func createCr(cdPipelineName string, stage command.CDStageCommand, versions map[string]string) edppipelinesv1alpha1.Stage {
+	stageVersions := map[string]string{}
+	for _, name := range stage.Services {
+		stageVersions[name] = versions[name]
+	}
 	return edppipelinesv1alpha1.Stage{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v2.edp.epam.com/v1alpha1",
 			Kind:       "Stage",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-%s", cdPipelineName, stage.Name),
-			Namespace: context.Namespace,
+			Name:        fmt.Sprintf("%s-%s", cdPipelineName, stage.Name),
+			Namespace:   context.Namespace,
+			Annotations: service.StageServiceAnnotations(stageVersions),
 		},
 		Spec: edppipelinesv1alpha1.StageSpec{
 			Name:            stage.Name,
@@ -465,11 +525,11 @@ func createCr(cdPipelineName string, stage command.CDStageCommand) edppipelinesv
 	}
 }
 
-func saveStagesIntoK8s(edpRestClient *rest.RESTClient, cdPipelineName string, stages []command.CDStageCommand, username string) ([]edppipelinesv1alpha1.Stage, error) {
+func saveStagesIntoK8s(edpRestClient *rest.RESTClient, cdPipelineName string, stages []command.CDStageCommand, username string, versions map[string]string) ([]edppipelinesv1alpha1.Stage, error) {
 	var stagesCr []edppipelinesv1alpha1.Stage
 	for _, stage := range stages {
 		stage.Username = username
-		crd := createCr(cdPipelineName, stage)
+		crd := createCr(cdPipelineName, stage, versions)
 		stageCr := edppipelinesv1alpha1.Stage{}
 		err := edpRestClient.Post().
 			Namespace(context.Namespace).
@@ -524,6 +584,15 @@ func (s CDPipelineService) DeleteCDStage(pipelineName, stageName string) error {
 }
 
 func (s CDPipelineService) canStageBeDeleted(pipelineName, stageName string) error {
+	if err := s.FreezeWindowService.CheckStageIsNotFrozen(pipelineName, stageName); err != nil {
+		if ferr, ok := err.(*edperror.StageFrozenError); ok {
+			return dberror.RemoveStageRestriction{
+				Status:  dberror.StatusRemoveStageRestriction,
+				Message: ferr.Error(),
+			}
+		}
+		return err
+	}
 	mso, err := s.ICDPipelineRepository.SelectMaxOrderBetweenStages(pipelineName)
 	if err != nil {
 		return err
@@ -586,6 +655,56 @@ func (s CDPipelineService) DeleteCDPipeline(name string) error {
 	return nil
 }
 
+func (s CDPipelineService) GetCDPipelineDeletionPlan(name string) (*dto.CDPipelineDeletionPlan, error) {
+	p, err := s.ICDPipelineRepository.GetCDPipelineByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get %v cd pipeline from DB", name)
+	}
+	if p == nil {
+		return nil, edperror.NewCDPipelineDoesNotExistError()
+	}
+	sortStagesByOrder(p.Stage)
+	createPlatformNames(p.Stage, p.Name)
+
+	folder := fmt.Sprintf("%v-cd-pipeline", name)
+	plan := &dto.CDPipelineDeletionPlan{
+		Name:         name,
+		Stages:       []dto.StageDeletionPlan{},
+		JenkinsJobs:  []string{folder},
+		Dependents:   []dto.CDStageDependents{},
+		FrozenStages: []string{},
+	}
+	for _, stage := range p.Stage {
+		job := fmt.Sprintf("%v/%v", folder, stage.Name)
+		plan.Stages = append(plan.Stages, dto.StageDeletionPlan{
+			Name:       stage.Name,
+			Namespace:  stage.PlatformProjectName,
+			JenkinsJob: job,
+		})
+		plan.JenkinsJobs = append(plan.JenkinsJobs, job)
+
+		pipes, err := s.ICDPipelineRepository.SelectCDPipelinesUsingInputStageAsSource(name, stage.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't get cd pipelines using %v stage as source", stage.Name)
+		}
+		if len(pipes) > 0 {
+			plan.Dependents = append(plan.Dependents, dto.CDStageDependents{
+				Stage:     stage.Name,
+				Pipelines: pipes,
+			})
+		}
+
+		if err := s.FreezeWindowService.CheckStageIsNotFrozen(name, stage.Name); err != nil {
+			if _, ok := err.(*edperror.StageFrozenError); !ok {
+				return nil, err
+			}
+			plan.FrozenStages = append(plan.FrozenStages, stage.Name)
+		}
+	}
+	plan.Deletable = len(plan.Dependents) == 0 && len(plan.FrozenStages) == 0
+	return plan, nil
+}
+
 func (s CDPipelineService) canCDPipelineBeDeleted(name string) error {
 	p, err := s.GetCDPipelineByName(name)
 	if err != nil {
@@ -655,3 +774,178 @@ func (s CDPipelineService) GetStageCount(pipeName string) (*int, error) {
 	log.Info("stages of cd-pipeline were counted")
 	return count, nil
 }
+
+func (s CDPipelineService) CreateCloneCommand(pipeName string, clone command.CloneCDPipelineCommand) (*command.CDPipelineCommand, error) {
+	log.Debug("start building command to clone cd pipeline",
+		zap.String("source", pipeName),
+		zap.String("name", clone.Name))
+	p, err := s.GetCDPipelineByName(pipeName)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		log.Debug("source CD Pipeline doesn't exist in DB.", zap.String("name", pipeName))
+		return nil, edperror.NewCDPipelineDoesNotExistError()
+	}
+
+	apps, err := s.remapApplications(p.CodebaseBranch, clone.Applications)
+	if err != nil {
+		return nil, err
+	}
+
+	cc := &command.CDPipelineCommand{
+		Name:                 clone.Name,
+		Applications:         apps,
+		ThirdPartyServices:   getThirdPartyServiceNames(p.ThirdPartyService),
+		Stages:               convertStagesToCommands(p.Stage, clone.Username),
+		ApplicationToApprove: p.ApplicationsToPromote,
+		Username:             clone.Username,
+	}
+	log.Info("command to clone cd pipeline has been built",
+		zap.String("source", pipeName),
+		zap.Any("command", cc))
+	return cc, nil
+}
+
+func (s CDPipelineService) remapApplications(branches []*query.CodebaseBranch,
+	mapping []command.CDPipelineApplicationBranch) ([]models.CDPipelineApplicationCommand, error) {
+	bm := make(map[string]string, len(mapping))
+	for _, m := range mapping {
+		if !isApplicationUsed(branches, m.ApplicationName) {
+			return nil, edperror.NewNonValidApplicationMappingError(m.ApplicationName)
+		}
+		bm[m.ApplicationName] = m.BranchName
+	}
+
+	var apps []models.CDPipelineApplicationCommand
+	for _, b := range branches {
+		app := models.CDPipelineApplicationCommand{
+			ApplicationName: b.AppName,
+		}
+		if len(b.CodebaseDockerStream) != 0 {
+			app.InputDockerStream = b.CodebaseDockerStream[0].OcImageStreamName
+		}
+
+		if bn, ok := bm[b.AppName]; ok && bn != b.Name {
+			ds, err := s.ICDPipelineRepository.SelectCodebaseDockerStreamName(b.AppName, bn)
+			if err != nil {
+				return nil, errors.Wrapf(err, "couldn't get docker stream for %v branch of %v application", bn, b.AppName)
+			}
+			if ds == nil {
+				log.Debug("docker stream for branch wasn't found",
+					zap.String("app", b.AppName), zap.String("branch", bn))
+				return nil, edperror.NewNonValidRelatedBranchError()
+			}
+			app.InputDockerStream = *ds
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+func isApplicationUsed(branches []*query.CodebaseBranch, appName string) bool {
+	for _, b := range branches {
+		if b.AppName == appName {
+			return true
+		}
+	}
+	return false
+}
+
+func getStageServiceNames(stages []command.CDStageCommand) []string {
+	var names []string
+	for _, st := range stages {
+		for _, name := range st.Services {
+			if !util.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+func mergeServiceNames(services []string, stages []command.CDStageCommand) []string {
+	res := services
+	for _, name := range getStageServiceNames(stages) {
+		if !util.Contains(res, name) {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+//addPipelineServices selects services of the pipeline for each its stage
+func addPipelineServices(stages []command.CDStageCommand, services []string) []command.CDStageCommand {
+	res := make([]command.CDStageCommand, 0, len(stages))
+	for _, st := range stages {
+		names := append([]string{}, st.Services...)
+		for _, name := range services {
+			if !util.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		st.Services = names
+		res = append(res, st)
+	}
+	return res
+}
+
+func getThirdPartyServiceNames(services []*query.ThirdPartyService) []string {
+	var names []string
+	for _, s := range services {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func convertStagesToCommands(stages []*query.Stage, username string) []command.CDStageCommand {
+	var result []command.CDStageCommand
+	for _, s := range stages {
+		sc := command.CDStageCommand{
+			Name:         s.Name,
+			Description:  s.Description,
+			TriggerType:  s.TriggerType,
+			Order:        s.Order,
+			Source:       ConvertStageSource(s.Source),
+			QualityGates: convertQualityGates(s.QualityGates),
+			Username:     username,
+		}
+		if s.JobProvisioning != nil {
+			sc.JobProvisioning = s.JobProvisioning.Name
+		}
+		result = append(result, sc)
+	}
+	return result
+}
+
+//ConvertStageSource converts source of stage from DB to the source of Stage custom resource
+func ConvertStageSource(source query.Source) edppipelinesv1alpha1.Source {
+	if source.Library == nil {
+		return edppipelinesv1alpha1.Source{
+			Type: "default",
+		}
+	}
+	return edppipelinesv1alpha1.Source{
+		Type: "library",
+		Library: edppipelinesv1alpha1.Library{
+			Name:   source.Library.Name,
+			Branch: source.Library.Branch,
+		},
+	}
+}
+
+func convertQualityGates(gates []query.QualityGate) []edppipelinesv1alpha1.QualityGate {
+	var result []edppipelinesv1alpha1.QualityGate
+	for _, g := range gates {
+		qg := edppipelinesv1alpha1.QualityGate{
+			QualityGateType: g.QualityGateType,
+			StepName:        g.StepName,
+		}
+		if g.Autotest != nil && g.Branch != nil {
+			qg.AutotestName = util.GetStringP(g.Autotest.Name)
+			qg.BranchName = util.GetStringP(g.Branch.Name)
+		}
+		result = append(result, qg)
+	}
+	return result
+}
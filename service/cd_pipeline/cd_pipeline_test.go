@@ -0,0 +1,175 @@
+package cd_pipeline
+
+import (
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	fws "edp-admin-console/service/freeze-window"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getSourceBranches() []*query.CodebaseBranch {
+	return []*query.CodebaseBranch{
+		{
+			Name:    "master",
+			AppName: "app-a",
+			CodebaseDockerStream: []*query.CodebaseDockerStream{
+				{OcImageStreamName: "app-a-master"},
+			},
+		},
+		{
+			Name:    "master",
+			AppName: "app-b",
+			CodebaseDockerStream: []*query.CodebaseDockerStream{
+				{OcImageStreamName: "app-b-master"},
+			},
+		},
+	}
+}
+
+func TestRemapApplicationsMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	mRepo := new(mock.MockCdPipeline)
+	s := CDPipelineService{
+		ICDPipelineRepository: mRepo,
+	}
+
+	mRepo.On("SelectCodebaseDockerStreamName", "app-b", "release-1.0").Return("app-b-release-1.0", nil)
+
+	apps, err := s.remapApplications(getSourceBranches(), []command.CDPipelineApplicationBranch{
+		{ApplicationName: "app-a", BranchName: "master"},
+		{ApplicationName: "app-b", BranchName: "release-1.0"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, apps, 2)
+	assert.Equal(t, "app-a-master", apps[0].InputDockerStream)
+	assert.Equal(t, "app-b-release-1.0", apps[1].InputDockerStream)
+}
+
+func TestRemapApplicationsMethod_ShouldReturnMappingError(t *testing.T) {
+	s := CDPipelineService{
+		ICDPipelineRepository: new(mock.MockCdPipeline),
+	}
+
+	apps, err := s.remapApplications(getSourceBranches(), []command.CDPipelineApplicationBranch{
+		{ApplicationName: "app-c", BranchName: "master"},
+	})
+	assert.Error(t, err)
+	assert.IsType(t, &edperror.NonValidApplicationMappingError{}, err)
+	assert.Nil(t, apps)
+}
+
+func TestRemapApplicationsMethod_ShouldReturnBranchError(t *testing.T) {
+	mRepo := new(mock.MockCdPipeline)
+	s := CDPipelineService{
+		ICDPipelineRepository: mRepo,
+	}
+
+	mRepo.On("SelectCodebaseDockerStreamName", "app-a", "fake-branch").Return(nil, nil)
+
+	apps, err := s.remapApplications(getSourceBranches(), []command.CDPipelineApplicationBranch{
+		{ApplicationName: "app-a", BranchName: "fake-branch"},
+	})
+	assert.Error(t, err)
+	assert.IsType(t, &edperror.NonValidRelatedBranchError{}, err)
+	assert.Nil(t, apps)
+}
+
+func TestConvertStagesToCommands_ShouldBeExecutedSuccessfully(t *testing.T) {
+	stages := []*query.Stage{
+		{
+			Name:        "sit",
+			Description: "sit stage",
+			TriggerType: "manual",
+			Order:       0,
+			Source:      query.Source{Type: "default"},
+			QualityGates: []query.QualityGate{
+				{QualityGateType: "manual", StepName: "approve"},
+				{
+					QualityGateType: "autotests",
+					StepName:        "tests",
+					Autotest:        &query.Codebase{Name: "autotest"},
+					Branch:          &query.CodebaseBranch{Name: "master"},
+				},
+			},
+			JobProvisioning: &query.JobProvisioning{Name: "default"},
+		},
+		{
+			Name:        "qa",
+			Description: "qa stage",
+			TriggerType: "auto",
+			Order:       1,
+			Source: query.Source{
+				Type:    "library",
+				Library: &query.SourceLibrary{Name: "pipelines", Branch: "master"},
+			},
+		},
+	}
+
+	cmds := convertStagesToCommands(stages, "fake-user")
+	assert.Len(t, cmds, 2)
+
+	assert.Equal(t, "sit", cmds[0].Name)
+	assert.Equal(t, "default", cmds[0].Source.Type)
+	assert.Equal(t, "default", cmds[0].JobProvisioning)
+	assert.Equal(t, "fake-user", cmds[0].Username)
+	assert.Len(t, cmds[0].QualityGates, 2)
+	assert.Nil(t, cmds[0].QualityGates[0].AutotestName)
+	assert.Equal(t, "autotest", *cmds[0].QualityGates[1].AutotestName)
+	assert.Equal(t, "master", *cmds[0].QualityGates[1].BranchName)
+
+	assert.Equal(t, "library", cmds[1].Source.Type)
+	assert.Equal(t, "pipelines", cmds[1].Source.Library.Name)
+	assert.Equal(t, 1, cmds[1].Order)
+	assert.Empty(t, cmds[1].JobProvisioning)
+}
+
+func TestGetCDPipelineDeletionPlanMethod_ShouldReturnDependents(t *testing.T) {
+	mRepo := new(mock.MockCdPipeline)
+	mFreeze := new(mock.MockFreezeWindow)
+	s := CDPipelineService{
+		ICDPipelineRepository: mRepo,
+		FreezeWindowService:   fws.FreezeWindowService{IFreezeWindowRepository: mFreeze},
+	}
+
+	mRepo.On("GetCDPipelineByName", "stub-pipeline").Return(query.CDPipeline{
+		Name: "stub-pipeline",
+		Stage: []*query.Stage{
+			{Id: 2, Name: "qa", Order: 1},
+			{Id: 1, Name: "sit", Order: 0},
+		},
+	}, nil)
+	mRepo.On("SelectCDPipelinesUsingInputStageAsSource", "stub-pipeline", "sit").Return([]string{"other-pipeline"}, nil)
+	mRepo.On("SelectCDPipelinesUsingInputStageAsSource", "stub-pipeline", "qa").Return(nil, nil)
+	mFreeze.On("SelectStageId", "stub-pipeline", "sit").Return(1, nil)
+	mFreeze.On("SelectStageId", "stub-pipeline", "qa").Return(2, nil)
+	mFreeze.On("SelectFreezeWindows", 1).Return([]*query.FreezeWindow{}, nil)
+	mFreeze.On("SelectFreezeWindows", 2).Return([]*query.FreezeWindow{}, nil)
+
+	plan, err := s.GetCDPipelineDeletionPlan("stub-pipeline")
+	assert.NoError(t, err)
+	assert.False(t, plan.Deletable)
+	assert.Len(t, plan.Stages, 2)
+	assert.Equal(t, "sit", plan.Stages[0].Name)
+	assert.Equal(t, "stub-pipeline-cd-pipeline/sit", plan.Stages[0].JenkinsJob)
+	assert.Equal(t, []string{"stub-pipeline-cd-pipeline", "stub-pipeline-cd-pipeline/sit", "stub-pipeline-cd-pipeline/qa"}, plan.JenkinsJobs)
+	assert.Len(t, plan.Dependents, 1)
+	assert.Equal(t, "sit", plan.Dependents[0].Stage)
+	assert.Equal(t, []string{"other-pipeline"}, plan.Dependents[0].Pipelines)
+	assert.Empty(t, plan.FrozenStages)
+}
+
+func TestGetCDPipelineDeletionPlanMethod_ShouldReturnNotFoundError(t *testing.T) {
+	mRepo := new(mock.MockCdPipeline)
+	s := CDPipelineService{
+		ICDPipelineRepository: mRepo,
+	}
+
+	mRepo.On("GetCDPipelineByName", "stub-pipeline").Return(nil, nil)
+
+	plan, err := s.GetCDPipelineDeletionPlan("stub-pipeline")
+	assert.Nil(t, plan)
+	assert.IsType(t, &edperror.CDPipelineDoesNotExistError{}, err)
+}
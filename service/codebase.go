@@ -21,10 +21,12 @@ import (
 	"edp-admin-console/k8s"
 	"edp-admin-console/models"
 	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
 	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository"
 	cbs "edp-admin-console/service/codebasebranch"
+	jiraservice "edp-admin-console/service/jira-server"
 	"edp-admin-console/service/logger"
 	"edp-admin-console/service/perfboard"
 	"edp-admin-console/util"
@@ -35,12 +37,16 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"strings"
 	"time"
 )
 
+//credentialsSecretLabel marks secrets which are allowed to be referenced as codebase credentials
+const credentialsSecretLabel = "edp.epam.com/codebase-credentials"
+
 var clog = logger.GetLogger()
 
 type CodebaseService struct {
@@ -49,6 +55,11 @@ type CodebaseService struct {
 	ICDPipelineRepository repository.ICDPipelineRepository
 	BranchService         cbs.CodebaseBranchService
 	PerfService           perfboard.PerfBoard
+	GitServerService      GitServerService
+	SlaveService          SlaveService
+	JobProvisioning       JobProvisioning
+	JiraServer            jiraservice.JiraServer
+	CiTools               []string
 }
 
 func (s CodebaseService) CreateCodebase(codebase command.CreateCodebase) (*edpv1alpha1.Codebase, error) {
@@ -76,6 +87,23 @@ func (s CodebaseService) CreateCodebase(codebase command.CreateCodebase) (*edpv1
 		return nil, edperror.NewCodebaseWithGitUrlPathAlreadyExistsError()
 	}
 
+	if codebase.Strategy == consts.ImportStrategy {
+		if err := s.validateImportGitServer(codebase.GitServer); err != nil {
+			return nil, err
+		}
+	}
+
+	if codebase.Perf != nil {
+		if err := s.PerfService.ValidateDataSources(codebase.Perf.Name, codebase.Perf.DataSources); err != nil {
+			return nil, err
+		}
+	}
+
+	repoSecret, vcsSecret, err := s.getCredentialsSecrets(codebase)
+	if err != nil {
+		return nil, err
+	}
+
 	edpClient := s.Clients.EDPRestClient
 	coreClient := s.Clients.CoreClient
 
@@ -102,7 +130,8 @@ func (s CodebaseService) CreateCodebase(codebase command.CreateCodebase) (*edpv1
 	}
 	clog.Debug("CR was generated. Waiting to save ...", zap.String("name", c.Name))
 
-	if err := createTempSecrets(context.Namespace, codebase, coreClient); err != nil {
+	tempSecrets, err := createTempSecrets(context.Namespace, codebase, repoSecret, vcsSecret, coreClient)
+	if err != nil {
 		return nil, err
 	}
 
@@ -110,8 +139,10 @@ func (s CodebaseService) CreateCodebase(codebase command.CreateCodebase) (*edpv1
 	err = edpClient.Post().Namespace(context.Namespace).Resource(consts.CodebasePlural).Body(c).Do().Into(result)
 	if err != nil {
 		clog.Error("an error has occurred while creating codebase resource in cluster", zap.Error(err))
+		deleteTempSecrets(context.Namespace, tempSecrets, coreClient)
 		return &edpv1alpha1.Codebase{}, err
 	}
+	setTempSecretsOwner(context.Namespace, tempSecrets, result, coreClient)
 
 	p := setCodebaseBranchCr(codebase.Versioning.Type, codebase.Username, codebase.Versioning.StartFrom, codebase.DefaultBranch)
 
@@ -188,36 +219,51 @@ func createSecret(namespace string, secret *v1.Secret, coreClient *coreV1Client.
 	return createdSecret, nil
 }
 
-func createTempSecrets(namespace string, codebase command.CreateCodebase, coreClient *coreV1Client.CoreV1Client) error {
-	if codebase.Repository != nil && (codebase.Repository.Login != "" && codebase.Repository.Password != "") {
+func createTempSecrets(namespace string, codebase command.CreateCodebase, repoSecret, vcsSecret *v1.Secret,
+	coreClient *coreV1Client.CoreV1Client) ([]string, error) {
+	var created []string
+	if codebase.Repository != nil && (repoSecret != nil || (codebase.Repository.Login != "" && codebase.Repository.Password != "")) {
 		repoSecretName := fmt.Sprintf("repository-codebase-%s-temp", codebase.Name)
-		tempRepoSecret := getSecret(repoSecretName, codebase.Repository.Login, codebase.Repository.Password)
+		tempRepoSecret := getSecret(repoSecretName, codebase.Name, codebase.Repository.Login, codebase.Repository.Password)
+		if repoSecret != nil {
+			tempRepoSecret = copySecret(repoSecretName, codebase.Name, repoSecret)
+		}
 
 		if _, err := createSecret(namespace, tempRepoSecret, coreClient); err != nil {
 			clog.Error("an error has occurred while creating repository secret", zap.Error(err))
-			return err
+			return nil, err
 		}
+		created = append(created, repoSecretName)
 		clog.Info("repository secret for codebase was created", zap.String("codebase", codebase.Name))
 	}
 
 	if codebase.Vcs != nil {
 		vcsSecretName := fmt.Sprintf("vcs-autouser-codebase-%s-temp", codebase.Name)
-		tempVcsSecret := getSecret(vcsSecretName, codebase.Vcs.Login, codebase.Vcs.Password)
+		tempVcsSecret := getSecret(vcsSecretName, codebase.Name, codebase.Vcs.Login, codebase.Vcs.Password)
+		if vcsSecret != nil {
+			tempVcsSecret = copySecret(vcsSecretName, codebase.Name, vcsSecret)
+		}
 
 		if _, err := createSecret(namespace, tempVcsSecret, coreClient); err != nil {
 			clog.Error("an error has occurred while creating vcs secret", zap.Error(err))
-			return err
+			deleteTempSecrets(namespace, created, coreClient)
+			return nil, err
 		}
+		created = append(created, vcsSecretName)
 		clog.Info("VCS secret for codebase was created", zap.String("codebase", codebase.Name))
 	}
 
-	return nil
+	return created, nil
 }
 
-func getSecret(name string, username string, password string) *v1.Secret {
+func getSecret(name, codebaseName, username, password string) *v1.Secret {
 	return &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
+			Labels: map[string]string{
+				tempSecretLabel:         "true",
+				tempSecretCodebaseLabel: codebaseName,
+			},
 		},
 		StringData: map[string]string{
 			"username": username,
@@ -226,6 +272,124 @@ func getSecret(name string, username string, password string) *v1.Secret {
 	}
 }
 
+//copySecret copies credentials of the source secret to the temporary one, operator reads only username and password
+func copySecret(name, codebaseName string, source *v1.Secret) *v1.Secret {
+	data := map[string][]byte{
+		v1.BasicAuthUsernameKey: source.Data[v1.BasicAuthUsernameKey],
+		v1.BasicAuthPasswordKey: source.Data[v1.BasicAuthPasswordKey],
+	}
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				tempSecretLabel:         "true",
+				tempSecretCodebaseLabel: codebaseName,
+			},
+		},
+		Data: data,
+	}
+}
+
+func (s CodebaseService) CheckRepository(url, secretName string) (*dto.RepositoryAvailability, error) {
+	secret, err := s.getCredentialsSecret(secretName)
+	if err != nil {
+		return nil, err
+	}
+	res := util.CheckGitRepo(url, getGitCredentials(secret))
+	return &res, nil
+}
+
+func (s CodebaseService) GetRepositoryRefs(url, secretName string) (*dto.GitRefs, error) {
+	secret, err := s.getCredentialsSecret(secretName)
+	if err != nil {
+		return nil, err
+	}
+	return util.ListGitRefs(url, getGitCredentials(secret))
+}
+
+func (s CodebaseService) getCredentialsSecrets(codebase command.CreateCodebase) (*v1.Secret, *v1.Secret, error) {
+	var repoSecret, vcsSecret *v1.Secret
+	if codebase.Repository != nil && codebase.Repository.SecretName != "" {
+		secret, err := s.getCredentialsSecret(codebase.Repository.SecretName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := validateOperatorCredentials(secret); err != nil {
+			return nil, nil, err
+		}
+		if res := util.CheckGitRepo(codebase.Repository.Url, getGitCredentials(secret)); !res.Available {
+			return nil, nil, edperror.NewNonValidCredentialsSecretError(
+				fmt.Sprintf("repository %v isn't available with secret %v (%v): %v",
+					codebase.Repository.Url, codebase.Repository.SecretName, res.Reason, res.Message))
+		}
+		repoSecret = secret
+	}
+
+	if codebase.Vcs != nil && codebase.Vcs.SecretName != "" {
+		secret, err := s.getCredentialsSecret(codebase.Vcs.SecretName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := validateOperatorCredentials(secret); err != nil {
+			return nil, nil, err
+		}
+		vcsSecret = secret
+	}
+	return repoSecret, vcsSecret, nil
+}
+
+func (s CodebaseService) getCredentialsSecret(name string) (*v1.Secret, error) {
+	secret, err := s.Clients.CoreClient.Secrets(context.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, edperror.NewNonValidCredentialsSecretError(fmt.Sprintf("secret %v doesn't exist", name))
+		}
+		return nil, errors.Wrapf(err, "couldn't get secret %v", name)
+	}
+	if err := validateCredentialsSecret(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func validateCredentialsSecret(secret *v1.Secret) error {
+	if secret.Labels[credentialsSecretLabel] != "true" {
+		return edperror.NewNonValidCredentialsSecretError(
+			fmt.Sprintf("secret %v isn't allowed for codebases, it should be labelled with %v=true",
+				secret.Name, credentialsSecretLabel))
+	}
+	d := secret.Data
+	if len(d[v1.SSHAuthPrivateKey]) > 0 || len(d["token"]) > 0 ||
+		(len(d[v1.BasicAuthUsernameKey]) > 0 && len(d[v1.BasicAuthPasswordKey]) > 0) {
+		return nil
+	}
+	return edperror.NewNonValidCredentialsSecretError(
+		fmt.Sprintf("secret %v should contain either username and password, token or %v keys",
+			secret.Name, v1.SSHAuthPrivateKey))
+}
+
+//validateOperatorCredentials checks that the secret can be passed to the operator,
+//token and ssh key are used only by the console to check repository
+func validateOperatorCredentials(secret *v1.Secret) error {
+	if len(secret.Data[v1.BasicAuthUsernameKey]) > 0 && len(secret.Data[v1.BasicAuthPasswordKey]) > 0 {
+		return nil
+	}
+	return edperror.NewNonValidCredentialsSecretError(
+		fmt.Sprintf("secret %v should contain username and password keys to create codebase", secret.Name))
+}
+
+func getGitCredentials(secret *v1.Secret) util.GitCredentials {
+	d := secret.Data
+	return util.GitCredentials{
+		Username:   string(d[v1.BasicAuthUsernameKey]),
+		Password:   string(d[v1.BasicAuthPasswordKey]),
+		Token:      string(d["token"]),
+		PrivateKey: d[v1.SSHAuthPrivateKey],
+		Passphrase: string(d["passphrase"]),
+		KnownHosts: d["known_hosts"],
+	}
+}
+
 func convertData(codebase command.CreateCodebase) edpv1alpha1.CodebaseSpec {
 	cs := edpv1alpha1.CodebaseSpec{
 		Lang:                 codebase.Lang,
@@ -348,6 +512,17 @@ func (s CodebaseService) Delete(name, codebaseType string) error {
 	return nil
 }
 
+func (s CodebaseService) validateImportGitServer(name string) error {
+	gs, err := s.GitServerService.GetGitServer(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get git server %v", name)
+	}
+	if gs == nil || !gs.Available || gs.Disabled {
+		return edperror.NewNonValidGitServerError(fmt.Sprintf("git server %v isn't available", name))
+	}
+	return nil
+}
+
 func (s CodebaseService) getCdPipelinesUsingCodebase(name, codebaseType string) ([]string, error) {
 	if consts.Application == codebaseType {
 		cdp, err := s.ICDPipelineRepository.GetCDPipelinesUsingApplication(name)
@@ -404,16 +579,33 @@ func setCodebaseBranchCr(vt string, username string, version *string, defaultBra
 
 func (s *CodebaseService) Update(command command.UpdateCodebaseCommand) (*edpv1alpha1.Codebase, error) {
 	log.Debug("start executing Update method fort codebase", zap.String("name", command.Name))
+	cb, err := s.ICodebaseRepository.GetCodebaseByName(command.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "an error has occurred while getting %v codebase from db", command.Name)
+	}
+	if cb == nil {
+		return nil, edperror.NewCodebaseDoesNotExistError(command.Name)
+	}
+
+	if err := s.validateUpdate(cb, command); err != nil {
+		return nil, err
+	}
+
 	c, err := util.GetCodebaseCR(s.Clients.EDPRestClient, command.Name)
 	if err != nil {
 		return nil, errors.Wrapf(err, "couldn't get codebase from cluster %v", command.Name)
 	}
+	if c == nil {
+		return nil, edperror.NewCodebaseDoesNotExistError(command.Name)
+	}
 
 	c.Spec.CommitMessagePattern = &command.CommitMessageRegex
 	c.Spec.TicketNamePattern = &command.TicketNameRegex
+	applyCodebaseUpdate(&c.Spec, command)
 	log.Debug("new values",
 		zap.String("commitMessagePattern", *c.Spec.CommitMessagePattern),
-		zap.String("ticketNamePattern", *c.Spec.TicketNamePattern))
+		zap.String("ticketNamePattern", *c.Spec.TicketNamePattern),
+		zap.Any("spec", c.Spec))
 
 	if err := s.executeUpdateRequest(c); err != nil {
 		return nil, err
@@ -422,6 +614,165 @@ func (s *CodebaseService) Update(command command.UpdateCodebaseCommand) (*edpv1a
 	return c, nil
 }
 
+func (s *CodebaseService) validateUpdate(cb *query.Codebase, command command.UpdateCodebaseCommand) error {
+	if command.Lang != nil && !strings.EqualFold(*command.Lang, cb.Language) {
+		return edperror.NewImmutableCodebaseFieldError("language")
+	}
+	if command.Strategy != nil && !strings.EqualFold(*command.Strategy, cb.Strategy) {
+		return edperror.NewImmutableCodebaseFieldError("strategy")
+	}
+	if command.Type != nil && !strings.EqualFold(*command.Type, string(cb.Type)) {
+		return edperror.NewImmutableCodebaseFieldError("type")
+	}
+
+	if command.GitServer != nil {
+		gs, err := s.GitServerService.GetGitServer(*command.GitServer)
+		if err != nil {
+			return errors.Wrapf(err, "couldn't get git server %v", *command.GitServer)
+		}
+		if gs == nil || !gs.Available || (gs.Disabled && (cb.GitServer == nil || *cb.GitServer != gs.Name)) {
+			return edperror.NewNonValidCodebaseUpdateError(fmt.Sprintf("git server %v isn't available", *command.GitServer))
+		}
+	}
+
+	if command.JenkinsSlave != nil {
+		slaves, err := s.SlaveService.GetAllSlaves()
+		if err != nil {
+			return err
+		}
+		if !containsSlave(slaves, *command.JenkinsSlave) {
+			return edperror.NewNonValidCodebaseUpdateError(fmt.Sprintf("jenkins slave %v doesn't exist", *command.JenkinsSlave))
+		}
+	}
+
+	if command.JobProvisioning != nil {
+		ps, err := s.JobProvisioning.GetAllJobProvisioners(query.JobProvisioningCriteria{Scope: util.GetStringP("ci")})
+		if err != nil {
+			return err
+		}
+		if !containsJobProvisioner(ps, *command.JobProvisioning) {
+			return edperror.NewNonValidCodebaseUpdateError(fmt.Sprintf("job provisioner %v doesn't exist", *command.JobProvisioning))
+		}
+	}
+
+	if command.JiraServer != nil && *command.JiraServer != "" {
+		servers, err := s.JiraServer.GetJiraServers()
+		if err != nil {
+			return err
+		}
+		if !containsJiraServer(servers, *command.JiraServer) {
+			return edperror.NewNonValidCodebaseUpdateError(fmt.Sprintf("jira server %v isn't available", *command.JiraServer))
+		}
+	}
+
+	if command.CiTool != nil && !util.Contains(s.CiTools, *command.CiTool) {
+		return edperror.NewNonValidCodebaseUpdateError(fmt.Sprintf("ci tool %v isn't supported", *command.CiTool))
+	}
+
+	if command.Route != nil && cb.Type != query.App {
+		return edperror.NewNonValidCodebaseUpdateError("route can be specified only for application")
+	}
+
+	if command.Perf != nil && command.Perf.Name != "" {
+		if err := s.validatePerf(*command.Perf); err != nil {
+			return err
+		}
+	}
+
+	if command.DefaultBranch != nil && !containsBranch(cb.CodebaseBranch, *command.DefaultBranch) {
+		return edperror.NewNonValidCodebaseUpdateError(fmt.Sprintf("branch %v doesn't exist in codebase %v", *command.DefaultBranch, cb.Name))
+	}
+	return nil
+}
+
+func (s *CodebaseService) validatePerf(perf command.Perf) error {
+	if err := s.PerfService.ValidateDataSources(perf.Name, perf.DataSources); err != nil {
+		if e, ok := err.(*edperror.NonValidPerfServerError); ok {
+			return edperror.NewNonValidCodebaseUpdateError(e.Message)
+		}
+		return err
+	}
+	return nil
+}
+
+func applyCodebaseUpdate(spec *edpv1alpha1.CodebaseSpec, command command.UpdateCodebaseCommand) {
+	if command.Description != nil {
+		spec.Description = command.Description
+	}
+	if command.GitServer != nil {
+		spec.GitServer = *command.GitServer
+	}
+	if command.JiraServer != nil {
+		spec.JiraServer = command.JiraServer
+		if *command.JiraServer == "" {
+			spec.JiraServer = nil
+		}
+	}
+	if command.JenkinsSlave != nil {
+		spec.JenkinsSlave = command.JenkinsSlave
+	}
+	if command.JobProvisioning != nil {
+		spec.JobProvisioning = command.JobProvisioning
+	}
+	if command.CiTool != nil {
+		spec.CiTool = *command.CiTool
+	}
+	if command.Route != nil {
+		spec.Route = &edpv1alpha1.Route{
+			Site: command.Route.Site,
+			Path: command.Route.Path,
+		}
+	}
+	if command.Perf != nil {
+		spec.Perf = nil
+		if command.Perf.Name != "" {
+			spec.Perf = &edpv1alpha1.Perf{
+				Name:        command.Perf.Name,
+				DataSources: command.Perf.DataSources,
+			}
+		}
+	}
+	if command.DefaultBranch != nil {
+		spec.DefaultBranch = *command.DefaultBranch
+	}
+}
+
+func containsSlave(slaves []*query.JenkinsSlave, name string) bool {
+	for _, s := range slaves {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsJobProvisioner(provisioners []*query.JobProvisioning, name string) bool {
+	for _, p := range provisioners {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsJiraServer(servers []*query.JiraServer, name string) bool {
+	for _, s := range servers {
+		if s.Name == name && s.Available {
+			return true
+		}
+	}
+	return false
+}
+
+func containsBranch(branches []*query.CodebaseBranch, name string) bool {
+	for _, b := range branches {
+		if b.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *CodebaseService) executeUpdateRequest(c *edpv1alpha1.Codebase) error {
 	err := s.Clients.EDPRestClient.Put().
 		Namespace(context.Namespace).
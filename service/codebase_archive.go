@@ -0,0 +1,185 @@
+/*
+ * Copyright 2020 EPAM Systems.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"edp-admin-console/context"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/util"
+	"edp-admin-console/util/consts"
+	dberror "edp-admin-console/util/error/db-errors"
+	"fmt"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"strings"
+	"time"
+)
+
+const (
+	archivedAnnotation   = "edp.epam.com/archived"
+	archivePurgeInterval = time.Hour
+)
+
+// Archive hides codebase from overviews and pipeline creation keeping its DB history.
+// Jenkins and Git resources of the codebase are left intact, the annotation only marks Codebase CR as archived
+func (s CodebaseService) Archive(name string) error {
+	clog.Debug("start archiving codebase", zap.String("name", name))
+	cb, err := s.getCodebaseToArchive(name)
+	if err != nil {
+		return err
+	}
+	if cb.ArchivedAt != nil {
+		return edperror.NewNonValidArchiveError(name, "codebase is already archived")
+	}
+
+	cdp, err := s.getCdPipelinesUsingCodebase(name, string(cb.Type))
+	if err != nil {
+		return err
+	}
+	if cdp != nil {
+		p := strings.Join(cdp, ",")
+		return dberror.CodebaseIsUsedByCDPipeline{
+			Status:   dberror.StatusReasonCodebaseIsUsedByCDPipeline,
+			Message:  fmt.Sprintf("%v %v is used by %v CD Pipeline(s). couldn't archive.", cb.Type, name, p),
+			Codebase: name,
+			Pipeline: p,
+		}
+	}
+
+	now := time.Now().UTC()
+	if err := s.patchArchivedAnnotation(name, now.Format(time.RFC3339)); err != nil {
+		return err
+	}
+	if err := s.ICodebaseRepository.UpdateArchivedAt(name, &now); err != nil {
+		return errors.Wrapf(err, "couldn't archive codebase %v in DB", name)
+	}
+	clog.Info("codebase has been archived", zap.String("name", name))
+	return nil
+}
+
+func (s CodebaseService) Restore(name string) error {
+	clog.Debug("start restoring codebase", zap.String("name", name))
+	cb, err := s.getCodebaseToArchive(name)
+	if err != nil {
+		return err
+	}
+	if cb.ArchivedAt == nil {
+		return edperror.NewNonValidArchiveError(name, "codebase isn't archived")
+	}
+
+	if err := s.patchArchivedAnnotation(name, nil); err != nil {
+		return err
+	}
+	if err := s.ICodebaseRepository.UpdateArchivedAt(name, nil); err != nil {
+		return errors.Wrapf(err, "couldn't restore codebase %v in DB", name)
+	}
+	clog.Info("codebase has been restored", zap.String("name", name))
+	return nil
+}
+
+// PurgeArchivedCodebases deletes codebases archived longer than retention and unused by CD pipelines.
+// DB row of codebase which CR is already absent is deleted directly as the operator won't do it
+func (s CodebaseService) PurgeArchivedCodebases(retention time.Duration) ([]string, error) {
+	names, err := s.ICodebaseRepository.SelectCodebasesArchivedBefore(time.Now().UTC().Add(-retention))
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get archived codebases")
+	}
+
+	var purged []string
+	for _, n := range names {
+		cb, err := s.getCodebaseToArchive(n)
+		if err != nil {
+			return purged, err
+		}
+		cdp, err := s.getCdPipelinesUsingCodebase(n, string(cb.Type))
+		if err != nil {
+			return purged, err
+		}
+		if cdp != nil {
+			clog.Info("archived codebase is used by CD pipelines and isn't purged",
+				zap.String("name", n), zap.Strings("pipelines", cdp))
+			continue
+		}
+
+		if err := s.deleteCodebase(n); err != nil {
+			if !k8serrors.IsNotFound(errors.Cause(err)) {
+				return purged, err
+			}
+			clog.Info("archived codebase doesn't exist in cluster. deleting it from DB", zap.String("name", n))
+			if err := s.ICodebaseRepository.DeleteCodebase(n); err != nil {
+				return purged, errors.Wrapf(err, "couldn't delete codebase %v from DB", n)
+			}
+		}
+		purged = append(purged, n)
+	}
+	if len(purged) > 0 {
+		clog.Info("archived codebases have been purged", zap.Strings("names", purged))
+	}
+	return purged, nil
+}
+
+func (s CodebaseService) ScheduleArchivePurge(retention time.Duration) {
+	t := time.NewTicker(archivePurgeInterval)
+	defer t.Stop()
+	for range t.C {
+		if _, err := s.PurgeArchivedCodebases(retention); err != nil {
+			clog.Error("couldn't purge archived codebases", zap.Error(err))
+		}
+	}
+}
+
+func (s CodebaseService) getCodebaseToArchive(name string) (*query.Codebase, error) {
+	cb, err := s.ICodebaseRepository.GetCodebaseByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get codebase %v", name)
+	}
+	if cb == nil {
+		return nil, edperror.NewCodebaseDoesNotExistError(name)
+	}
+	return cb, nil
+}
+
+func (s CodebaseService) patchArchivedAnnotation(name string, value interface{}) error {
+	bytes, err := util.EncodeStructToBytes(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				archivedAnnotation: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.Clients.EDPRestClient.Patch(types.MergePatchType).
+		Namespace(context.Namespace).
+		Resource(consts.CodebasePlural).
+		Name(name).
+		Body(bytes).
+		Do().Error()
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			clog.Info("codebase doesn't exist in cluster. archive state is saved only in DB", zap.String("name", name))
+			return nil
+		}
+		return errors.Wrapf(err, "couldn't update archive state of codebase %v in cluster", name)
+	}
+	return nil
+}
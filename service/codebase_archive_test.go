@@ -0,0 +1,87 @@
+package service
+
+import (
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	dberror "edp-admin-console/util/error/db-errors"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestArchiveMethod_ShouldRefuseArchivedCodebase(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	cs := CodebaseService{ICodebaseRepository: mCodebase}
+
+	at := time.Now()
+	mCodebase.On("GetCodebaseByName", fakeName).Return(query.Codebase{Name: fakeName, ArchivedAt: &at}, nil)
+
+	err := cs.Archive(fakeName)
+	assert.IsType(t, &edperror.NonValidArchiveError{}, err)
+}
+
+func TestArchiveMethod_ShouldRefuseCodebaseUsedByCDPipeline(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	mPipeline := new(mock.MockCdPipeline)
+	cs := CodebaseService{
+		ICodebaseRepository:   mCodebase,
+		ICDPipelineRepository: mPipeline,
+	}
+
+	mCodebase.On("GetCodebaseByName", fakeName).Return(query.Codebase{Name: fakeName, Type: query.App}, nil)
+	mPipeline.On("GetCDPipelinesUsingApplication", fakeName).Return([]string{"fake-pipeline"}, nil)
+
+	err := cs.Archive(fakeName)
+	assert.IsType(t, dberror.CodebaseIsUsedByCDPipeline{}, err)
+}
+
+func TestRestoreMethod_ShouldRefuseActiveCodebase(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	cs := CodebaseService{ICodebaseRepository: mCodebase}
+
+	mCodebase.On("GetCodebaseByName", fakeName).Return(query.Codebase{Name: fakeName}, nil)
+
+	err := cs.Restore(fakeName)
+	assert.IsType(t, &edperror.NonValidArchiveError{}, err)
+}
+
+func TestRestoreMethod_ShouldReturnNotFoundError(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	cs := CodebaseService{ICodebaseRepository: mCodebase}
+
+	mCodebase.On("GetCodebaseByName", fakeName).Return(nil, nil)
+
+	err := cs.Restore(fakeName)
+	assert.IsType(t, &edperror.CodebaseDoesNotExistError{}, err)
+}
+
+func TestPurgeArchivedCodebasesMethod_ShouldSkipWhenNothingExpired(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	cs := CodebaseService{ICodebaseRepository: mCodebase}
+
+	mCodebase.On("SelectCodebasesArchivedBefore", tmock.AnythingOfType("time.Time")).Return([]string{}, nil)
+
+	purged, err := cs.PurgeArchivedCodebases(24 * time.Hour)
+	assert.NoError(t, err)
+	assert.Empty(t, purged)
+}
+
+func TestPurgeArchivedCodebasesMethod_ShouldSkipCodebaseUsedByCDPipeline(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	mPipeline := new(mock.MockCdPipeline)
+	cs := CodebaseService{
+		ICodebaseRepository:   mCodebase,
+		ICDPipelineRepository: mPipeline,
+	}
+
+	mCodebase.On("SelectCodebasesArchivedBefore", tmock.AnythingOfType("time.Time")).Return([]string{fakeName}, nil)
+	mCodebase.On("GetCodebaseByName", fakeName).Return(query.Codebase{Name: fakeName, Type: query.App}, nil)
+	mPipeline.On("GetCDPipelinesUsingApplication", fakeName).Return([]string{"fake-pipeline"}, nil)
+
+	purged, err := cs.PurgeArchivedCodebases(24 * time.Hour)
+	assert.NoError(t, err)
+	assert.Empty(t, purged)
+	mCodebase.AssertNotCalled(t, "DeleteCodebase", fakeName)
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright 2020 EPAM Systems.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/util"
+	"encoding/csv"
+	"fmt"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var codebaseNameRegexp = regexp.MustCompile("^[a-z][a-z0-9-]*[a-z0-9]$")
+
+func (s CodebaseService) BulkImport(cmd command.BulkImportCodebaseCommand) (*dto.BulkImportReport, error) {
+	clog.Info("start bulk import of codebases", zap.String("git server", cmd.GitServer),
+		zap.String("path prefix", cmd.PathPrefix))
+
+	projects, err := getImportProjects(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return nil, edperror.NewNonValidBulkImportError("at least one project should be specified")
+	}
+
+	if err := s.validateImportOptions(cmd); err != nil {
+		return nil, err
+	}
+
+	codebases := createImportCommands(cmd, projects)
+	report := s.validateImportCommands(codebases)
+	if !report.Valid {
+		clog.Info("bulk import request contains invalid projects. nothing is created")
+		return report, nil
+	}
+
+	for i, c := range codebases {
+		if _, err := s.CreateCodebase(c); err != nil {
+			clog.Error("couldn't import codebase", zap.String("name", c.Name), zap.Error(err))
+			report.Items[i].Status = dto.ImportFailed
+			report.Items[i].Message = err.Error()
+			continue
+		}
+		report.Items[i].Status = dto.ImportCreated
+	}
+	clog.Info("bulk import of codebases has been finished", zap.Int("count", len(codebases)))
+	return report, nil
+}
+
+func getImportProjects(cmd command.BulkImportCodebaseCommand) ([]command.BulkImportProject, error) {
+	if strings.TrimSpace(cmd.Csv) == "" {
+		return cmd.Projects, nil
+	}
+	projects, err := parseImportProjects(strings.NewReader(cmd.Csv))
+	if err != nil {
+		return nil, edperror.NewNonValidBulkImportError(fmt.Sprintf("couldn't parse csv: %v", err))
+	}
+	return append(cmd.Projects, projects...), nil
+}
+
+func parseImportProjects(r io.Reader) ([]command.BulkImportProject, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var projects []command.BulkImportProject
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return projects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) == 0 || record[0] == "" || strings.EqualFold(record[0], "path") {
+			continue
+		}
+		projects = append(projects, convertImportRecord(record))
+	}
+}
+
+func convertImportRecord(record []string) command.BulkImportProject {
+	field := func(i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	p := command.BulkImportProject{
+		Path:      field(0),
+		Lang:      field(1),
+		BuildTool: field(3),
+	}
+	if f := field(2); f != "" {
+		p.Framework = &f
+	}
+	if vt := field(4); vt != "" {
+		p.Versioning = &command.Versioning{Type: vt}
+		if sf := field(5); sf != "" {
+			p.Versioning.StartFrom = &sf
+		}
+	}
+	return p
+}
+
+func createImportCommands(cmd command.BulkImportCodebaseCommand, projects []command.BulkImportProject) []command.CreateCodebase {
+	var codebases []command.CreateCodebase
+	for _, p := range projects {
+		gitUrlPath := path.Join("/", cmd.PathPrefix, p.Path)
+		c := command.CreateCodebase{
+			Name:            path.Base(gitUrlPath),
+			DefaultBranch:   cmd.DefaultBranch,
+			Strategy:        "import",
+			Lang:            cmd.Lang,
+			Framework:       cmd.Framework,
+			BuildTool:       cmd.BuildTool,
+			Type:            cmd.Type,
+			Username:        cmd.Username,
+			GitServer:       cmd.GitServer,
+			Versioning:      cmd.Versioning,
+			GitUrlPath:      &gitUrlPath,
+			JenkinsSlave:    cmd.JenkinsSlave,
+			JobProvisioning: cmd.JobProvisioning,
+			JiraServer:      cmd.JiraServer,
+			CiTool:          cmd.CiTool,
+		}
+		if p.Lang != "" {
+			c.Lang = p.Lang
+		}
+		if p.Framework != nil {
+			c.Framework = p.Framework
+		}
+		if p.BuildTool != "" {
+			c.BuildTool = p.BuildTool
+		}
+		if p.Versioning != nil {
+			c.Versioning = *p.Versioning
+		}
+		codebases = append(codebases, c)
+	}
+	return codebases
+}
+
+func (s CodebaseService) validateImportOptions(cmd command.BulkImportCodebaseCommand) error {
+	gs, err := s.GitServerService.GetGitServer(cmd.GitServer)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get git server %v", cmd.GitServer)
+	}
+	if gs == nil || !gs.Available || gs.Disabled {
+		return edperror.NewNonValidBulkImportError(fmt.Sprintf("git server %v isn't available", cmd.GitServer))
+	}
+
+	if !util.Contains(s.CiTools, cmd.CiTool) {
+		return edperror.NewNonValidBulkImportError(fmt.Sprintf("ci tool %v isn't supported", cmd.CiTool))
+	}
+
+	if cmd.JenkinsSlave != nil {
+		slaves, err := s.SlaveService.GetAllSlaves()
+		if err != nil {
+			return err
+		}
+		if !containsSlave(slaves, *cmd.JenkinsSlave) {
+			return edperror.NewNonValidBulkImportError(fmt.Sprintf("jenkins slave %v doesn't exist", *cmd.JenkinsSlave))
+		}
+	}
+
+	if cmd.JobProvisioning != nil {
+		ps, err := s.JobProvisioning.GetAllJobProvisioners(query.JobProvisioningCriteria{Scope: util.GetStringP("ci")})
+		if err != nil {
+			return err
+		}
+		if !containsJobProvisioner(ps, *cmd.JobProvisioning) {
+			return edperror.NewNonValidBulkImportError(fmt.Sprintf("job provisioner %v doesn't exist", *cmd.JobProvisioning))
+		}
+	}
+	return nil
+}
+
+func (s CodebaseService) validateImportCommands(codebases []command.CreateCodebase) *dto.BulkImportReport {
+	report := &dto.BulkImportReport{Valid: true}
+	names := map[string]bool{}
+	for _, c := range codebases {
+		item := dto.BulkImportItem{
+			Path:   *c.GitUrlPath,
+			Name:   c.Name,
+			Status: dto.ImportValid,
+		}
+		if msg := s.validateImportCommand(c, names); msg != "" {
+			item.Status = dto.ImportInvalid
+			item.Message = msg
+			report.Valid = false
+		}
+		names[c.Name] = true
+		report.Items = append(report.Items, item)
+	}
+	return report
+}
+
+func (s CodebaseService) validateImportCommand(c command.CreateCodebase, names map[string]bool) string {
+	if !codebaseNameRegexp.MatchString(c.Name) {
+		return fmt.Sprintf("%v isn't a valid codebase name", c.Name)
+	}
+	if c.Lang == "" || c.BuildTool == "" || c.Versioning.Type == "" {
+		return "language, build tool and versioning type should be specified"
+	}
+	if names[c.Name] {
+		return fmt.Sprintf("codebase %v is specified more than once", c.Name)
+	}
+	if s.findCodebaseByName(c.Name) {
+		return fmt.Sprintf("codebase %v already exists", c.Name)
+	}
+	if s.findCodebaseByProjectPath(c.GitUrlPath) {
+		return fmt.Sprintf("codebase with %v project path already exists", *c.GitUrlPath)
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParseImportProjectsMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	csv := "path,lang,framework,buildTool,versioning,startFrom\n" +
+		"# skipped comment\n" +
+		"stub-service-a, java, java11, maven, edp, 1.0.0\n" +
+		"stub-service-b\n"
+
+	projects, err := parseImportProjects(strings.NewReader(csv))
+	assert.NoError(t, err)
+	assert.Len(t, projects, 2)
+	assert.Equal(t, "stub-service-a", projects[0].Path)
+	assert.Equal(t, "java11", *projects[0].Framework)
+	assert.Equal(t, "edp", projects[0].Versioning.Type)
+	assert.Equal(t, "1.0.0", *projects[0].Versioning.StartFrom)
+	assert.Equal(t, "stub-service-b", projects[1].Path)
+	assert.Nil(t, projects[1].Versioning)
+}
+
+func TestCreateImportCommandsMethod_ShouldApplyOverrides(t *testing.T) {
+	cmd := command.BulkImportCodebaseCommand{
+		GitServer:  "stub-gitlab",
+		PathPrefix: "/stub-group",
+		Type:       "application",
+		Lang:       "java",
+		BuildTool:  "maven",
+		Versioning: command.Versioning{Type: "default"},
+	}
+
+	codebases := createImportCommands(cmd, []command.BulkImportProject{
+		{Path: "stub-service-a"},
+		{Path: "sub/stub-service-b", Lang: "javascript", BuildTool: "npm", Versioning: &command.Versioning{Type: "edp"}},
+	})
+	assert.Len(t, codebases, 2)
+	assert.Equal(t, "/stub-group/stub-service-a", *codebases[0].GitUrlPath)
+	assert.Equal(t, "stub-service-a", codebases[0].Name)
+	assert.Equal(t, "import", codebases[0].Strategy)
+	assert.Equal(t, "maven", codebases[0].BuildTool)
+	assert.Equal(t, "/stub-group/sub/stub-service-b", *codebases[1].GitUrlPath)
+	assert.Equal(t, "stub-service-b", codebases[1].Name)
+	assert.Equal(t, "npm", codebases[1].BuildTool)
+	assert.Equal(t, "edp", codebases[1].Versioning.Type)
+}
+
+func TestBulkImportMethod_ShouldReportInvalidProjects(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	mGitServer := new(mock.MockGitServer)
+	cs := CodebaseService{
+		ICodebaseRepository: mCodebase,
+		GitServerService:    GitServerService{IGitServerRepository: mGitServer},
+		CiTools:             []string{"Jenkins"},
+	}
+
+	mGitServer.On("GetGitServerByName", "stub-gitlab").Return(query.GitServer{Name: "stub-gitlab", Available: true}, nil)
+	mCodebase.On("FindCodebaseByName", "stub-service-a").Return(false)
+	mCodebase.On("FindCodebaseByName", "stub-service-b").Return(true)
+	mCodebase.On("FindCodebaseByProjectPath", "/stub-group/stub-service-a").Return(false)
+
+	report, err := cs.BulkImport(command.BulkImportCodebaseCommand{
+		GitServer:  "stub-gitlab",
+		PathPrefix: "/stub-group",
+		Type:       "application",
+		Lang:       "java",
+		BuildTool:  "maven",
+		Versioning: command.Versioning{Type: "default"},
+		CiTool:     "Jenkins",
+		Projects: []command.BulkImportProject{
+			{Path: "stub-service-a"},
+			{Path: "stub-service-b"},
+			{Path: "stub-service-a"},
+			{Path: "Stub_Service"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Items, 4)
+	assert.Equal(t, dto.ImportValid, report.Items[0].Status)
+	assert.Equal(t, dto.ImportInvalid, report.Items[1].Status)
+	assert.Equal(t, dto.ImportInvalid, report.Items[2].Status)
+	assert.Equal(t, dto.ImportInvalid, report.Items[3].Status)
+}
+
+func TestBulkImportMethod_ShouldRefuseUnavailableGitServer(t *testing.T) {
+	mGitServer := new(mock.MockGitServer)
+	cs := CodebaseService{
+		GitServerService: GitServerService{IGitServerRepository: mGitServer},
+	}
+
+	mGitServer.On("GetGitServerByName", "stub-gitlab").Return(query.GitServer{Name: "stub-gitlab"}, nil)
+
+	report, err := cs.BulkImport(command.BulkImportCodebaseCommand{
+		GitServer: "stub-gitlab",
+		Projects:  []command.BulkImportProject{{Path: "stub-service-a"}},
+	})
+	assert.Nil(t, report)
+	assert.IsType(t, &edperror.NonValidBulkImportError{}, err)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/util"
+	"edp-admin-console/util/consts"
+	"encoding/json"
+	edpv1alpha1 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"regexp"
+	"time"
+)
+
+const (
+	tempSecretLabel         = "edp.epam.com/temp-secret"
+	tempSecretCodebaseLabel = "edp.epam.com/codebase"
+	tempSecretGracePeriod   = 15 * time.Minute
+	tempSecretSweepInterval = time.Hour
+)
+
+var tempSecretNameRegexp = regexp.MustCompile("^(?:repository|vcs-autouser)-codebase-(.+)-temp$")
+
+//GetTempSecrets gets labelled temporary secrets and the legacy ones recognized by name,
+//a secret which state couldn't be resolved is skipped
+func (s CodebaseService) GetTempSecrets() ([]dto.TempSecret, error) {
+	secrets, err := s.listTempSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	res := []dto.TempSecret{}
+	states := map[string]*edpv1alpha1.Codebase{}
+	for _, sc := range secrets {
+		cn, ok := getTempSecretCodebase(sc)
+		if !ok {
+			continue
+		}
+		cr, ok := states[cn]
+		if !ok {
+			cr, err = util.GetCodebaseCR(s.Clients.EDPRestClient, cn)
+			if err != nil {
+				clog.Error("couldn't get codebase of temporary secret",
+					zap.String("secret", sc.Name), zap.String("codebase", cn), zap.Error(err))
+				continue
+			}
+			states[cn] = cr
+		}
+		res = append(res, dto.TempSecret{
+			Name:      sc.Name,
+			Codebase:  cn,
+			State:     tempSecretState(cr != nil, cr != nil && cr.Status.Available, time.Since(sc.CreationTimestamp.Time)),
+			Owned:     len(sc.OwnerReferences) > 0,
+			CreatedAt: sc.CreationTimestamp.Time,
+		})
+	}
+	return res, nil
+}
+
+func (s CodebaseService) listTempSecrets() ([]v1.Secret, error) {
+	secrets := s.Clients.CoreClient.Secrets(context.Namespace)
+	labelled, err := secrets.List(metav1.ListOptions{LabelSelector: tempSecretLabel + "=true"})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get temporary secrets")
+	}
+	// secrets created before they got labels are recognized by name
+	legacy, err := secrets.List(metav1.ListOptions{LabelSelector: "!" + tempSecretLabel})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get unlabelled secrets")
+	}
+	res := labelled.Items
+	for _, sc := range legacy.Items {
+		if tempSecretNameRegexp.MatchString(sc.Name) {
+			res = append(res, sc)
+		}
+	}
+	return res, nil
+}
+
+func (s CodebaseService) DeleteTempSecret(name string) error {
+	secrets := s.Clients.CoreClient.Secrets(context.Namespace)
+	sc, err := secrets.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return edperror.NewTempSecretDoesNotExistError(name)
+		}
+		return errors.Wrapf(err, "couldn't get secret %v", name)
+	}
+	if _, ok := getTempSecretCodebase(*sc); !ok {
+		return edperror.NewTempSecretDoesNotExistError(name)
+	}
+
+	if err := secrets.Delete(name, &metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrapf(err, "couldn't delete secret %v", name)
+	}
+	clog.Info("temporary secret has been deleted", zap.String("name", name))
+	return nil
+}
+
+func (s CodebaseService) SweepTempSecrets() ([]string, error) {
+	secrets, err := s.GetTempSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	var swept []string
+	for _, sc := range secrets {
+		if sc.State == dto.TempSecretPending {
+			continue
+		}
+		err := s.Clients.CoreClient.Secrets(context.Namespace).Delete(sc.Name, &metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			clog.Error("couldn't delete temporary secret", zap.String("name", sc.Name), zap.Error(err))
+			continue
+		}
+		swept = append(swept, sc.Name)
+	}
+	if len(swept) > 0 {
+		clog.Info("temporary secrets have been swept", zap.Strings("names", swept))
+	}
+	return swept, nil
+}
+
+func (s CodebaseService) ScheduleTempSecretSweep() {
+	t := time.NewTicker(tempSecretSweepInterval)
+	defer t.Stop()
+	for range t.C {
+		if _, err := s.SweepTempSecrets(); err != nil {
+			clog.Error("couldn't sweep temporary secrets", zap.Error(err))
+		}
+	}
+}
+
+func tempSecretState(codebaseExists, codebaseAvailable bool, age time.Duration) string {
+	if codebaseAvailable {
+		return dto.TempSecretConsumed
+	}
+	if !codebaseExists && age > tempSecretGracePeriod {
+		return dto.TempSecretOrphaned
+	}
+	return dto.TempSecretPending
+}
+
+func getTempSecretCodebase(secret v1.Secret) (string, bool) {
+	if secret.Labels[tempSecretLabel] == "true" && secret.Labels[tempSecretCodebaseLabel] != "" {
+		return secret.Labels[tempSecretCodebaseLabel], true
+	}
+	// secrets created before they got labels are recognized by name
+	m := tempSecretNameRegexp.FindStringSubmatch(secret.Name)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+func deleteTempSecrets(namespace string, names []string, coreClient *coreV1Client.CoreV1Client) {
+	for _, n := range names {
+		err := coreClient.Secrets(namespace).Delete(n, &metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			clog.Error("couldn't delete temporary secret", zap.String("name", n), zap.Error(err))
+			continue
+		}
+		clog.Info("temporary secret has been deleted", zap.String("name", n))
+	}
+}
+
+func setTempSecretsOwner(namespace string, names []string, owner *edpv1alpha1.Codebase, coreClient *coreV1Client.CoreV1Client) {
+	if len(names) == 0 {
+		return
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"ownerReferences": []metav1.OwnerReference{
+				{
+					APIVersion: "v2.edp.epam.com/v1alpha1",
+					Kind:       consts.CodebaseKind,
+					Name:       owner.Name,
+					UID:        owner.UID,
+				},
+			},
+		},
+	})
+	if err != nil {
+		clog.Error("couldn't encode owner of temporary secrets", zap.Error(err))
+		return
+	}
+	for _, n := range names {
+		if _, err := coreClient.Secrets(namespace).Patch(n, types.MergePatchType, patch); err != nil {
+			clog.Error("couldn't set owner of temporary secret", zap.String("name", n), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/dto"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetTempSecretCodebaseMethod_ShouldRecognizeTempSecrets(t *testing.T) {
+	labelled := getSecret("repository-codebase-stub-name-temp", "stub-name", "user", "password")
+	cn, ok := getTempSecretCodebase(*labelled)
+	assert.True(t, ok)
+	assert.Equal(t, "stub-name", cn)
+
+	cn, ok = getTempSecretCodebase(v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "vcs-autouser-codebase-legacy-app-temp"}})
+	assert.True(t, ok)
+	assert.Equal(t, "legacy-app", cn)
+
+	_, ok = getTempSecretCodebase(v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "stub-name-jira-credentials"}})
+	assert.False(t, ok)
+}
+
+func TestTempSecretStateMethod_ShouldKeepSecretsUntilConsumed(t *testing.T) {
+	assert.Equal(t, dto.TempSecretPending, tempSecretState(true, false, 24*time.Hour))
+	assert.Equal(t, dto.TempSecretPending, tempSecretState(false, false, time.Minute))
+	assert.Equal(t, dto.TempSecretConsumed, tempSecretState(true, true, time.Minute))
+	assert.Equal(t, dto.TempSecretOrphaned, tempSecretState(false, false, time.Hour))
+}
+
+func TestGetTempSecretsMethod_ShouldSkipSecretsWithUnresolvedCodebase(t *testing.T) {
+	context.Namespace = "stub-namespace"
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/stub-namespace/secrets":
+			if r.URL.Query().Get("labelSelector") == "edp.epam.com/temp-secret=true" {
+				_, _ = w.Write([]byte(`{"items":[
+					{"metadata":{"name":"repository-codebase-stub-app-temp","labels":{"edp.epam.com/temp-secret":"true","edp.epam.com/codebase":"stub-app"}}},
+					{"metadata":{"name":"repository-codebase-broken-temp","labels":{"edp.epam.com/temp-secret":"true","edp.epam.com/codebase":"broken"}}}]}`))
+				return
+			}
+			assert.Equal(t, "!edp.epam.com/temp-secret", r.URL.Query().Get("labelSelector"))
+			_, _ = w.Write([]byte(`{"items":[
+				{"metadata":{"name":"vcs-autouser-codebase-stub-app-temp"}},
+				{"metadata":{"name":"stub-app-jira-credentials"}}]}`))
+		case "/apis/v2.edp.epam.com/v1alpha1/namespaces/stub-namespace/codebases/stub-app":
+			_, _ = w.Write([]byte(`{"metadata":{"name":"stub-app"},"status":{"available":true}}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer s.Close()
+	client, err := k8s.CreateEDPRestClient(s.URL)
+	assert.NoError(t, err)
+	core, err := coreV1Client.NewForConfig(&rest.Config{Host: s.URL})
+	assert.NoError(t, err)
+	cs := CodebaseService{Clients: k8s.ClientSet{CoreClient: core, EDPRestClient: client}}
+
+	secrets, err := cs.GetTempSecrets()
+	assert.NoError(t, err)
+	assert.Len(t, secrets, 2)
+	assert.Equal(t, "repository-codebase-stub-app-temp", secrets[0].Name)
+	assert.Equal(t, "vcs-autouser-codebase-stub-app-temp", secrets[1].Name)
+	assert.Equal(t, dto.TempSecretConsumed, secrets[1].State)
+}
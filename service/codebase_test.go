@@ -1,17 +1,39 @@
 package service
 
 import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
 	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository/mock"
+	jiraservice "edp-admin-console/service/jira-server"
 	"edp-admin-console/service/perfboard"
+	"edp-admin-console/util"
 	"errors"
+	edpv1alpha1 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
 const fakeName = "fake-name"
 
+func createPerfServerStub(perfServer string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/perfservers/epam-perf") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(perfServer))
+	}))
+}
+
 func TestGetCodebaseByNameMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
 	mCodebase := new(mock.MockCodebase)
 	cs := CodebaseService{
@@ -139,3 +161,142 @@ func TestConvertDataMethod_ShouldConvertSuccessfully(t *testing.T) {
 	c := convertData(codebase)
 	assert.Equal(t, fakeName, c.Perf.Name)
 }
+
+func TestValidateUpdateMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	context.Namespace = "stub-namespace"
+	mSlave := new(mock.MockSlave)
+	mJira := new(mock.MockJiraServer)
+	s := createPerfServerStub(`{"metadata":{"name":"epam-perf","annotations":{"perf.edp.epam.com/data-sources":"Sonar,Jenkins"}}}`)
+	defer s.Close()
+	client, err := k8s.CreateEDPRestClient(s.URL)
+	assert.NoError(t, err)
+	cs := CodebaseService{
+		SlaveService: SlaveService{ISlaveRepository: mSlave},
+		JiraServer:   jiraservice.JiraServer{IJiraServer: mJira},
+		PerfService: perfboard.PerfBoard{
+			Clients:     k8s.ClientSet{EDPRestClient: client},
+			DataSources: []string{"Sonar", "Jenkins", "GitLab"},
+		},
+		CiTools: []string{"Jenkins", "GitLab CI"},
+	}
+
+	mSlave.On("GetAllSlaves").Return([]*query.JenkinsSlave{{Name: "maven"}}, nil)
+	mJira.On("GetJiraServers").Return([]*query.JiraServer{{Name: "epam-jira", Available: true}}, nil)
+
+	err = cs.validateUpdate(&query.Codebase{
+		Name:           "stub-name",
+		Language:       "java",
+		Strategy:       "create",
+		Type:           query.App,
+		CodebaseBranch: []*query.CodebaseBranch{{Name: "master"}, {Name: "develop"}},
+	}, command.UpdateCodebaseCommand{
+		Name:          "stub-name",
+		Lang:          util.GetStringP("Java"),
+		JenkinsSlave:  util.GetStringP("maven"),
+		JiraServer:    util.GetStringP("epam-jira"),
+		CiTool:        util.GetStringP("GitLab CI"),
+		Route:         &command.Route{Site: "stub-site", Path: "/"},
+		Perf:          &command.Perf{Name: "epam-perf", DataSources: []string{"Sonar"}},
+		DefaultBranch: util.GetStringP("develop"),
+	})
+	assert.NoError(t, err)
+}
+
+func TestValidateUpdateMethod_ShouldRefuseImmutableFields(t *testing.T) {
+	cs := CodebaseService{}
+
+	err := cs.validateUpdate(&query.Codebase{
+		Name:     "stub-name",
+		Language: "java",
+		Strategy: "create",
+		Type:     query.App,
+	}, command.UpdateCodebaseCommand{
+		Name:     "stub-name",
+		Strategy: util.GetStringP("import"),
+	})
+	assert.IsType(t, &edperror.ImmutableCodebaseFieldError{}, err)
+	assert.Equal(t, "strategy", err.(*edperror.ImmutableCodebaseFieldError).Field)
+}
+
+func TestValidateUpdateMethod_ShouldRefuseUnknownOptions(t *testing.T) {
+	mSlave := new(mock.MockSlave)
+	cs := CodebaseService{
+		SlaveService: SlaveService{ISlaveRepository: mSlave},
+		CiTools:      []string{"Jenkins"},
+	}
+
+	mSlave.On("GetAllSlaves").Return([]*query.JenkinsSlave{{Name: "maven"}}, nil)
+
+	cb := &query.Codebase{
+		Name:           "stub-name",
+		Type:           query.Library,
+		CodebaseBranch: []*query.CodebaseBranch{{Name: "master"}},
+	}
+	err := cs.validateUpdate(cb, command.UpdateCodebaseCommand{JenkinsSlave: util.GetStringP("npm")})
+	assert.IsType(t, &edperror.NonValidCodebaseUpdateError{}, err)
+
+	err = cs.validateUpdate(cb, command.UpdateCodebaseCommand{CiTool: util.GetStringP("GitLab CI")})
+	assert.IsType(t, &edperror.NonValidCodebaseUpdateError{}, err)
+
+	err = cs.validateUpdate(cb, command.UpdateCodebaseCommand{Route: &command.Route{Site: "stub-site"}})
+	assert.IsType(t, &edperror.NonValidCodebaseUpdateError{}, err)
+
+	err = cs.validateUpdate(cb, command.UpdateCodebaseCommand{DefaultBranch: util.GetStringP("develop")})
+	assert.IsType(t, &edperror.NonValidCodebaseUpdateError{}, err)
+}
+
+func TestApplyCodebaseUpdateMethod_ShouldResetOptionalIntegrations(t *testing.T) {
+	spec := edpv1alpha1.CodebaseSpec{
+		JiraServer: util.GetStringP("epam-jira"),
+		Perf:       &edpv1alpha1.Perf{Name: "epam-perf"},
+		CiTool:     "Jenkins",
+	}
+
+	applyCodebaseUpdate(&spec, command.UpdateCodebaseCommand{
+		JiraServer:    util.GetStringP(""),
+		Perf:          &command.Perf{},
+		DefaultBranch: util.GetStringP("develop"),
+	})
+	assert.Nil(t, spec.JiraServer)
+	assert.Nil(t, spec.Perf)
+	assert.Equal(t, "Jenkins", spec.CiTool)
+	assert.Equal(t, "develop", spec.DefaultBranch)
+}
+
+func TestValidateCredentialsSecretMethod_ShouldRequireUsernameAndPassword(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "stub-credentials",
+			Labels: map[string]string{"edp.epam.com/codebase-credentials": "true"},
+		},
+		Data: map[string][]byte{"username": []byte("user")},
+	}
+	err := validateCredentialsSecret(secret)
+	assert.IsType(t, &edperror.NonValidCredentialsSecretError{}, err)
+
+	secret.Data["password"] = []byte("password")
+	assert.NoError(t, validateCredentialsSecret(secret))
+
+	unlabelled := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "stub-credentials"}, Data: secret.Data}
+	err = validateCredentialsSecret(unlabelled)
+	assert.IsType(t, &edperror.NonValidCredentialsSecretError{}, err)
+	assert.Contains(t, err.Error(), "edp.epam.com/codebase-credentials=true")
+
+	secret.Data[v1.SSHAuthPrivateKey] = []byte("stub-key")
+	temp := copySecret("repository-codebase-stub-name-temp", "stub-name", secret)
+	assert.Equal(t, map[string][]byte{"username": []byte("user"), "password": []byte("password")}, temp.Data)
+	cn, ok := getTempSecretCodebase(*temp)
+	assert.True(t, ok)
+	assert.Equal(t, "stub-name", cn)
+}
+
+func TestValidateOperatorCredentialsMethod_ShouldRejectKeyOnlySecret(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "stub-credentials"},
+		Data:       map[string][]byte{v1.SSHAuthPrivateKey: []byte("stub-key")},
+	}
+	assert.IsType(t, &edperror.NonValidCredentialsSecretError{}, validateOperatorCredentials(secret))
+
+	secret.Data = map[string][]byte{"username": []byte("user"), "password": []byte("password")}
+	assert.NoError(t, validateOperatorCredentials(secret))
+}
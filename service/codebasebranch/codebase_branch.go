@@ -22,6 +22,7 @@ import (
 	"edp-admin-console/models/command"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository"
+	"edp-admin-console/service/gitref"
 	"edp-admin-console/service/logger"
 	"edp-admin-console/util"
 	"edp-admin-console/util/consts"
@@ -45,6 +46,7 @@ type CodebaseBranchService struct {
 	IReleaseBranchRepository repository.ICodebaseBranchRepository
 	ICDPipelineRepository    repository.ICDPipelineRepository
 	ICodebaseRepository      repository.ICodebaseRepository
+	GitRefService            gitref.GitRefService
 	CodebaseBranchValidation map[string]func(string, string) ([]string, error)
 }
 
@@ -64,6 +66,12 @@ func (s *CodebaseBranchService) CreateCodebaseBranch(branchInfo command.CreateCo
 		return nil, fmt.Errorf("CodebaseBranch %v already exists", cb)
 	}
 
+	if branchInfo.Commit != "" {
+		if err := s.GitRefService.ValidateCommit(appName, branchInfo.Commit); err != nil {
+			return nil, err
+		}
+	}
+
 	c, err := util.GetCodebaseCR(s.Clients.EDPRestClient, appName)
 	if err != nil {
 		return nil, err
@@ -128,6 +136,9 @@ func (s *CodebaseBranchService) UpdateCodebaseBranch(appName, branchName string,
 	if err != nil {
 		return err
 	}
+	if br == nil {
+		return fmt.Errorf("CodebaseBranch %v doesn't exist", branchName)
+	}
 
 	br.Spec.Version = version
 	bytes, err := util.EncodeStructToBytes(br)
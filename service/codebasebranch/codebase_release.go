@@ -0,0 +1,143 @@
+/*
+ * Copyright 2020 EPAM Systems.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codebasebranch
+
+import (
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/util"
+	"edp-admin-console/util/consts"
+	"fmt"
+	"github.com/blang/semver"
+	"go.uber.org/zap"
+)
+
+const (
+	edpVersioning   = "edp"
+	snapshotPostfix = "SNAPSHOT"
+	rcPostfix       = "RC"
+)
+
+func (s *CodebaseBranchService) CutReleases(cmd command.CutReleaseCommand) []dto.CodebaseRelease {
+	var releases []dto.CodebaseRelease
+	for _, name := range cmd.Codebases {
+		r, err := s.CutRelease(name, cmd.Bump, cmd.Username)
+		if err != nil {
+			log.Error("couldn't cut release", zap.String("codebase", name), zap.Error(err))
+			releases = append(releases, dto.CodebaseRelease{
+				Codebase: name,
+				Status:   dto.ReleaseFailed,
+				Message:  err.Error(),
+			})
+			continue
+		}
+		releases = append(releases, *r)
+	}
+	return releases
+}
+
+func (s *CodebaseBranchService) CutRelease(codebase, bump, username string) (*dto.CodebaseRelease, error) {
+	log.Debug("start cutting release", zap.String("codebase", codebase), zap.String("bump", bump))
+	cb, err := s.ICodebaseRepository.GetCodebaseByName(codebase)
+	if err != nil {
+		return nil, err
+	}
+	if cb == nil {
+		return nil, edperror.NewCodebaseDoesNotExistError(codebase)
+	}
+	if cb.VersioningType != edpVersioning {
+		return nil, edperror.NewNonValidReleaseError(codebase, "release can be cut only for edp versioning")
+	}
+
+	db := findBranch(cb.CodebaseBranch, cb.DefaultBranch)
+	if db == nil || db.Version == nil {
+		return nil, edperror.NewNonValidReleaseError(codebase,
+			fmt.Sprintf("version of default branch %v isn't found", cb.DefaultBranch))
+	}
+
+	r, err := getReleaseVersions(*db.Version, bump)
+	if err != nil {
+		return nil, edperror.NewNonValidReleaseError(codebase, err.Error())
+	}
+	r.Codebase = codebase
+
+	if s.ICodebaseRepository.ExistCodebaseAndBranch(codebase, r.ReleaseBranch) {
+		return nil, edperror.NewNonValidReleaseError(codebase, fmt.Sprintf("branch %v already exists", r.ReleaseBranch))
+	}
+
+	branch := command.CreateCodebaseBranch{
+		Name:     r.ReleaseBranch,
+		Username: username,
+		Version:  util.GetVersionOrNil(r.ReleaseVersion, rcPostfix),
+		Build:    &consts.DefaultBuildNumber,
+		Release:  true,
+	}
+	rb, err := s.CreateCodebaseBranch(branch, codebase)
+	if err != nil {
+		return nil, err
+	}
+
+	nv := util.GetVersionOrNil(r.NextVersion, snapshotPostfix)
+	if err := s.UpdateCodebaseBranch(codebase, util.ProcessNameToKubernetesConvention(db.Name), nv); err != nil {
+		//release branch is removed so the release can be cut again, otherwise it's refused as the branch already exists
+		if derr := s.deleteCodebaseBranch(rb.Name); derr != nil {
+			log.Error("couldn't delete release branch after failed bump of default branch version",
+				zap.String("codebase", codebase), zap.String("branch", r.ReleaseBranch), zap.Error(derr))
+		}
+		return nil, err
+	}
+
+	r.Status = dto.ReleaseCreated
+	log.Info("release has been cut", zap.String("codebase", codebase),
+		zap.String("branch", r.ReleaseBranch), zap.String("next version", r.NextVersion))
+	return r, nil
+}
+
+func getReleaseVersions(version, bump string) (*dto.CodebaseRelease, error) {
+	v, err := semver.Make(util.TrimSuffix(version, fmt.Sprintf("-%v", snapshotPostfix)))
+	if err != nil {
+		return nil, err
+	}
+	v.Pre = nil
+	v.Build = nil
+
+	next := v
+	if bump == command.MajorBump {
+		next.Major++
+		next.Minor = 0
+	} else {
+		next.Minor++
+	}
+	next.Patch = 0
+
+	return &dto.CodebaseRelease{
+		ReleaseBranch:  fmt.Sprintf("release/%v.%v", v.Major, v.Minor),
+		ReleaseVersion: v.String(),
+		NextVersion:    next.String(),
+	}, nil
+}
+
+func findBranch(branches []*query.CodebaseBranch, name string) *query.CodebaseBranch {
+	for _, b := range branches {
+		if b.Name == name {
+			return b
+		}
+	}
+	return nil
+}
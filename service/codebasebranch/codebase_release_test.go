@@ -0,0 +1,60 @@
+package codebasebranch
+
+import (
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetReleaseVersionsMethod_ShouldBumpMinorVersion(t *testing.T) {
+	r, err := getReleaseVersions("1.2.3-SNAPSHOT", command.MinorBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "release/1.2", r.ReleaseBranch)
+	assert.Equal(t, "1.2.3", r.ReleaseVersion)
+	assert.Equal(t, "1.3.0", r.NextVersion)
+}
+
+func TestGetReleaseVersionsMethod_ShouldBumpMajorVersion(t *testing.T) {
+	r, err := getReleaseVersions("1.2.3-SNAPSHOT", command.MajorBump)
+	assert.NoError(t, err)
+	assert.Equal(t, "release/1.2", r.ReleaseBranch)
+	assert.Equal(t, "2.0.0", r.NextVersion)
+}
+
+func TestGetReleaseVersionsMethod_ShouldReturnErrorOnInvalidVersion(t *testing.T) {
+	r, err := getReleaseVersions("stub-version", command.MinorBump)
+	assert.Error(t, err)
+	assert.Nil(t, r)
+}
+
+func TestCutReleaseMethod_ShouldRefuseDefaultVersioning(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	s := CodebaseBranchService{
+		ICodebaseRepository: mCodebase,
+	}
+
+	mCodebase.On("GetCodebaseByName", "stub-name").Return(query.Codebase{
+		Name:           "stub-name",
+		VersioningType: "default",
+	}, nil)
+
+	r, err := s.CutRelease("stub-name", command.MinorBump, "stub-user")
+	assert.Nil(t, r)
+	assert.IsType(t, &edperror.NonValidReleaseError{}, err)
+}
+
+func TestCutReleasesMethod_ShouldReportFailedCodebases(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	s := CodebaseBranchService{
+		ICodebaseRepository: mCodebase,
+	}
+
+	mCodebase.On("GetCodebaseByName", "stub-name").Return(nil, nil)
+
+	releases := s.CutReleases(command.CutReleaseCommand{Codebases: []string{"stub-name"}})
+	assert.Len(t, releases, 1)
+	assert.Equal(t, "failed", releases[0].Status)
+}
@@ -0,0 +1,252 @@
+package dependency
+
+import (
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository"
+	"edp-admin-console/service/gitref"
+	"edp-admin-console/service/logger"
+	"edp-admin-console/util"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var log = logger.GetLogger()
+
+const (
+	graphTTL          = 10 * time.Minute
+	graphBuildTimeout = 5 * time.Minute
+)
+
+var cache = struct {
+	sync.Mutex
+	graph    *dto.DependencyGraph
+	built    time.Time
+	building *graphBuild
+}{}
+
+//graphBuild is a rebuild of the graph shared by all requests which came while it's running
+type graphBuild struct {
+	done  chan struct{}
+	graph *dto.DependencyGraph
+	err   error
+}
+
+type DependencyService struct {
+	ICodebaseRepository   repository.ICodebaseRepository
+	ICDPipelineRepository repository.ICDPipelineRepository
+	GitRefService         gitref.GitRefService
+}
+
+//GetDependencyGraph returns dependencies between codebases and registered libraries.
+//The graph is built from manifests in default branches and is kept for graphTTL unless refresh is requested.
+//The graph is rebuilt in background only once at a time: an outdated graph is returned while it's rebuilt,
+//requests without a graph or with refresh wait for the running rebuild
+func (s DependencyService) GetDependencyGraph(refresh bool) (*dto.DependencyGraph, error) {
+	cache.Lock()
+	g, built := cache.graph, cache.built
+	if !refresh && g != nil && time.Since(built) < graphTTL {
+		cache.Unlock()
+		return g, nil
+	}
+	b := cache.building
+	if b == nil {
+		b = &graphBuild{done: make(chan struct{})}
+		cache.building = b
+		go s.rebuildDependencyGraph(b)
+	}
+	cache.Unlock()
+
+	if !refresh && g != nil {
+		return g, nil
+	}
+	<-b.done
+	return b.graph, b.err
+}
+
+func (s DependencyService) rebuildDependencyGraph(b *graphBuild) {
+	b.graph, b.err = s.buildDependencyGraph(time.Now().Add(graphBuildTimeout))
+	if b.err != nil {
+		log.Error("couldn't build dependency graph", zap.Error(b.err))
+	}
+
+	cache.Lock()
+	if b.err == nil {
+		cache.graph = b.graph
+		cache.built = time.Now()
+	}
+	cache.building = nil
+	cache.Unlock()
+	close(b.done)
+}
+
+//GetLibraryImpact returns codebases which directly or transitively depend on the library with their CD pipelines
+func (s DependencyService) GetLibraryImpact(library string, refresh bool) (*dto.LibraryImpact, error) {
+	cb, err := s.ICodebaseRepository.GetCodebaseByName(library)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get codebase %v", library)
+	}
+	if cb == nil || cb.Type != query.Library {
+		return nil, edperror.NewCodebaseDoesNotExistError(library)
+	}
+
+	g, err := s.GetDependencyGraph(refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := getImpact(g, library)
+	for i, c := range impact.Codebases {
+		if c.Type != string(query.App) {
+			continue
+		}
+		p, err := s.ICDPipelineRepository.GetCDPipelinesUsingApplication(c.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't get cd pipelines using %v", c.Name)
+		}
+		if p != nil {
+			impact.Codebases[i].Pipelines = p
+		}
+	}
+	return impact, nil
+}
+
+//buildDependencyGraph reads manifests of codebases until the deadline, the rest of codebases are reported as not scanned
+func (s DependencyService) buildDependencyGraph(deadline time.Time) (*dto.DependencyGraph, error) {
+	codebases, err := s.ICodebaseRepository.GetCodebasesByCriteria(query.CodebaseCriteria{})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get codebases")
+	}
+
+	manifests := map[string]map[string][]byte{}
+	scanErrors := map[string]string{}
+	for _, c := range codebases {
+		if c.Type == query.Autotests {
+			continue
+		}
+		if time.Now().After(deadline) {
+			scanErrors[c.Name] = "repository hasn't been scanned as time limit of the scan has been exceeded"
+			continue
+		}
+		m, err := s.readManifests(*c)
+		if err != nil {
+			log.Error("couldn't read manifests of codebase", zap.String("name", c.Name), zap.Error(err))
+			scanErrors[c.Name] = err.Error()
+			continue
+		}
+		manifests[c.Name] = m
+	}
+
+	g := buildGraph(codebases, manifests)
+	if len(scanErrors) > 0 {
+		g.Errors = scanErrors
+	}
+	log.Info("dependency graph has been built",
+		zap.Int("nodes", len(g.Nodes)), zap.Int("edges", len(g.Edges)))
+	return g, nil
+}
+
+func (s DependencyService) readManifests(c query.Codebase) (map[string][]byte, error) {
+	url, err := s.GitRefService.GetRepositoryUrl(c)
+	if err != nil {
+		return nil, err
+	}
+	return util.ReadGitFiles(url, c.DefaultBranch, s.GitRefService.Credentials, isManifest)
+}
+
+func buildGraph(codebases []*query.Codebase, manifests map[string]map[string][]byte) *dto.DependencyGraph {
+	libraries := map[string]string{}
+	goModules := map[string]string{}
+	g := &dto.DependencyGraph{}
+	for _, c := range codebases {
+		if c.Type == query.Library {
+			libraries[normalizeName(c.Name)] = c.Name
+			for _, p := range sortedKeys(manifests[c.Name]) {
+				if m := parseGoModule(p, manifests[c.Name][p]); m != "" {
+					goModules[m] = c.Name
+				}
+			}
+		}
+		g.Nodes = append(g.Nodes, dto.DependencyNode{Name: c.Name, Type: string(c.Type)})
+	}
+
+	for _, c := range codebases {
+		seen := map[string]bool{}
+		for _, p := range sortedKeys(manifests[c.Name]) {
+			deps, err := parseManifest(p, manifests[c.Name][p])
+			if err != nil {
+				log.Error("couldn't parse manifest", zap.String("codebase", c.Name),
+					zap.String("path", p), zap.Error(err))
+				continue
+			}
+			for _, d := range deps {
+				lib, ok := findLibrary(p, d, libraries, goModules)
+				if !ok || lib == c.Name || seen[lib] {
+					continue
+				}
+				seen[lib] = true
+				g.Edges = append(g.Edges, dto.DependencyEdge{From: c.Name, To: lib, Manifest: p})
+			}
+		}
+	}
+	return g
+}
+
+// findLibrary matches Go modules by full module path as their last segments are often ambiguous,
+// packages of other ecosystems are matched by normalized names
+func findLibrary(manifest, dependency string, libraries, goModules map[string]string) (string, bool) {
+	if path.Base(manifest) == goManifest {
+		lib, ok := goModules[dependency]
+		return lib, ok
+	}
+	lib, ok := libraries[normalizeName(dependency)]
+	return lib, ok
+}
+
+func getImpact(g *dto.DependencyGraph, library string) *dto.LibraryImpact {
+	types := map[string]string{}
+	for _, n := range g.Nodes {
+		types[n.Name] = n.Type
+	}
+	dependents := map[string][]string{}
+	for _, e := range g.Edges {
+		dependents[e.To] = append(dependents[e.To], e.From)
+	}
+
+	impact := &dto.LibraryImpact{Library: library, Codebases: []dto.ImpactedCodebase{}}
+	visited := map[string]bool{library: true}
+	queue := []string{library}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, d := range dependents[current] {
+			if visited[d] {
+				continue
+			}
+			visited[d] = true
+			queue = append(queue, d)
+			impact.Codebases = append(impact.Codebases, dto.ImpactedCodebase{
+				Name:      d,
+				Type:      types[d],
+				Direct:    current == library,
+				Pipelines: []string{},
+			})
+		}
+	}
+	return impact
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
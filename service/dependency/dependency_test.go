@@ -0,0 +1,114 @@
+package dependency
+
+import (
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseManifestMethod_ShouldReturnDependenciesOfAllManifests(t *testing.T) {
+	pom := `<project>
+	<dependencies>
+		<dependency><groupId>com.epam</groupId><artifactId>stub-lib</artifactId></dependency>
+	</dependencies>
+</project>`
+	deps, err := parseManifest("pom.xml", []byte(pom))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"stub-lib"}, deps)
+
+	deps, err = parseManifest("ui/package.json", []byte(`{"dependencies": {"@epam/stub-js-lib": "1.0.0"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"@epam/stub-js-lib"}, deps)
+
+	gomod := "module stub\n\nrequire github.com/epam/stub-go-lib/v2 v2.0.0\n" +
+		"require (\n\tgithub.com/pkg/errors v0.8.1 // indirect\n)\n"
+	deps, err = parseManifest("go.mod", []byte(gomod))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"github.com/epam/stub-go-lib/v2", "github.com/pkg/errors"}, deps)
+
+	csproj := `<Project>
+	<ItemGroup>
+		<PackageReference Include="Stub.Net.Lib" Version="1.0.0" />
+		<ProjectReference Include="..\Stub.Shared\Stub.Shared.csproj" />
+	</ItemGroup>
+</Project>`
+	deps, err = parseManifest("src/Stub.csproj", []byte(csproj))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Stub.Net.Lib", "Stub.Shared"}, deps)
+}
+
+func TestIsManifestMethod_ShouldSkipVendoredDirectories(t *testing.T) {
+	assert.True(t, isManifest("pom.xml"))
+	assert.True(t, isManifest("module/package.json"))
+	assert.True(t, isManifest("src/Stub.csproj"))
+	assert.False(t, isManifest("node_modules/stub/package.json"))
+	assert.False(t, isManifest("ui/node_modules/stub/package.json"))
+	assert.False(t, isManifest("README.md"))
+}
+
+func TestGetImpactMethod_ShouldReturnTransitiveDependents(t *testing.T) {
+	codebases := []*query.Codebase{
+		{Name: "stub-app", Type: query.App},
+		{Name: "stub-other-app", Type: query.App},
+		{Name: "stub-lib", Type: query.Library},
+		{Name: "stub-net-lib", Type: query.Library},
+	}
+	manifests := map[string]map[string][]byte{
+		"stub-app": {
+			"pom.xml": []byte(`<project><dependencies><dependency><artifactId>stub-lib</artifactId></dependency></dependencies></project>`),
+		},
+		"stub-lib": {
+			"Stub.csproj": []byte(`<Project><ItemGroup><PackageReference Include="Stub.Net.Lib" /></ItemGroup></Project>`),
+		},
+	}
+
+	g := buildGraph(codebases, manifests)
+	assert.Len(t, g.Nodes, 4)
+	assert.Len(t, g.Edges, 2)
+
+	impact := getImpact(g, "stub-net-lib")
+	assert.Len(t, impact.Codebases, 2)
+	assert.Equal(t, "stub-lib", impact.Codebases[0].Name)
+	assert.True(t, impact.Codebases[0].Direct)
+	assert.Equal(t, "stub-app", impact.Codebases[1].Name)
+	assert.False(t, impact.Codebases[1].Direct)
+}
+
+func TestBuildGraphMethod_ShouldMatchGoModulesByPath(t *testing.T) {
+	codebases := []*query.Codebase{
+		{Name: "stub-app", Type: query.App},
+		{Name: "stub-go-lib", Type: query.Library},
+	}
+	manifests := map[string]map[string][]byte{
+		"stub-app": {
+			"go.mod": []byte("module github.com/epam/stub-app\n\nrequire (\n" +
+				"\tgithub.com/other/stub-go-lib v1.0.0\n\tgithub.com/epam/stub-go-lib/v2 v2.0.0\n)\n"),
+		},
+		"stub-go-lib": {
+			"go.mod": []byte("module github.com/epam/stub-go-lib/v2\n"),
+		},
+	}
+
+	g := buildGraph(codebases, manifests)
+	assert.Len(t, g.Edges, 1)
+	assert.Equal(t, "stub-go-lib", g.Edges[0].To)
+	assert.Equal(t, "go.mod", g.Edges[0].Manifest)
+}
+
+func TestBuildDependencyGraphMethod_ShouldSkipCodebasesAfterDeadline(t *testing.T) {
+	mRepo := new(mock.MockCodebase)
+	mRepo.On("GetCodebasesByCriteria", query.CodebaseCriteria{}).Return([]*query.Codebase{
+		{Name: "stub-app", Type: query.App},
+		{Name: "stub-lib", Type: query.Library},
+		{Name: "stub-autotest", Type: query.Autotests},
+	}, nil)
+	s := DependencyService{ICodebaseRepository: mRepo}
+
+	g, err := s.buildDependencyGraph(time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+	assert.Len(t, g.Errors, 2)
+	assert.Contains(t, g.Errors, "stub-app")
+	assert.Contains(t, g.Errors, "stub-lib")
+}
@@ -0,0 +1,162 @@
+package dependency
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	pomManifest     = "pom.xml"
+	npmManifest     = "package.json"
+	goManifest      = "go.mod"
+	dotnetExtension = ".csproj"
+)
+
+var (
+	skippedDirs      = []string{"node_modules/", "vendor/", "target/", "bin/", "obj/"}
+	nameSeparators   = strings.NewReplacer("_", "-", ".", "-")
+	goRequireComment = regexp.MustCompile(`//.*$`)
+)
+
+type pomProject struct {
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+	DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+type pomDependency struct {
+	ArtifactId string `xml:"artifactId"`
+}
+
+type npmPackage struct {
+	Dependencies     map[string]string `json:"dependencies"`
+	DevDependencies  map[string]string `json:"devDependencies"`
+	PeerDependencies map[string]string `json:"peerDependencies"`
+}
+
+type dotnetProject struct {
+	PackageReferences []dotnetReference `xml:"ItemGroup>PackageReference"`
+	ProjectReferences []dotnetReference `xml:"ItemGroup>ProjectReference"`
+}
+
+type dotnetReference struct {
+	Include string `xml:"Include,attr"`
+}
+
+func isManifest(p string) bool {
+	for _, d := range skippedDirs {
+		if strings.HasPrefix(p, d) || strings.Contains(p, "/"+d) {
+			return false
+		}
+	}
+	b := path.Base(p)
+	return b == pomManifest || b == npmManifest || b == goManifest || strings.HasSuffix(b, dotnetExtension)
+}
+
+func parseManifest(p string, content []byte) ([]string, error) {
+	b := path.Base(p)
+	switch {
+	case b == pomManifest:
+		return parsePom(content)
+	case b == npmManifest:
+		return parsePackageJson(content)
+	case b == goManifest:
+		return parseGoMod(content), nil
+	case strings.HasSuffix(b, dotnetExtension):
+		return parseCsproj(content)
+	}
+	return nil, nil
+}
+
+func parsePom(content []byte) ([]string, error) {
+	var p pomProject
+	if err := xml.Unmarshal(content, &p); err != nil {
+		return nil, err
+	}
+
+	var deps []string
+	for _, d := range append(p.Dependencies, p.DependencyManagement...) {
+		deps = append(deps, strings.TrimSpace(d.ArtifactId))
+	}
+	return deps, nil
+}
+
+func parsePackageJson(content []byte) ([]string, error) {
+	var p npmPackage
+	if err := json.Unmarshal(content, &p); err != nil {
+		return nil, err
+	}
+
+	var deps []string
+	for _, m := range []map[string]string{p.Dependencies, p.DevDependencies, p.PeerDependencies} {
+		for name := range m {
+			deps = append(deps, name)
+		}
+	}
+	return deps, nil
+}
+
+func parseGoMod(content []byte) []string {
+	var deps []string
+	block := false
+	for _, l := range strings.Split(string(content), "\n") {
+		l = strings.TrimSpace(goRequireComment.ReplaceAllString(l, ""))
+		switch {
+		case l == "require (":
+			block = true
+			continue
+		case block && l == ")":
+			block = false
+			continue
+		case strings.HasPrefix(l, "require "):
+			l = strings.TrimSpace(strings.TrimPrefix(l, "require "))
+		case !block:
+			continue
+		}
+
+		if f := strings.Fields(l); len(f) > 0 {
+			deps = append(deps, f[0])
+		}
+	}
+	return deps
+}
+
+// parseGoModule returns path of the module declared in go.mod or empty string for other manifests
+func parseGoModule(p string, content []byte) string {
+	if path.Base(p) != goManifest {
+		return ""
+	}
+	for _, l := range strings.Split(string(content), "\n") {
+		l = strings.TrimSpace(goRequireComment.ReplaceAllString(l, ""))
+		if strings.HasPrefix(l, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(l, "module ")), `"`)
+		}
+	}
+	return ""
+}
+
+func parseCsproj(content []byte) ([]string, error) {
+	var p dotnetProject
+	if err := xml.Unmarshal(content, &p); err != nil {
+		return nil, err
+	}
+
+	var deps []string
+	for _, r := range p.PackageReferences {
+		deps = append(deps, r.Include)
+	}
+	for _, r := range p.ProjectReferences {
+		n := path.Base(strings.ReplaceAll(r.Include, "\\", "/"))
+		deps = append(deps, strings.TrimSuffix(n, dotnetExtension))
+	}
+	return deps, nil
+}
+
+//normalizeName converts a package name of Maven, npm or .NET manifest to the form of codebase names
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	parts := strings.Split(name, "/")
+	return nameSeparators.Replace(parts[len(parts)-1])
+}
@@ -1,20 +1,39 @@
 package edp_component
 
 import (
+	"bytes"
 	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	ec "edp-admin-console/repository/edp-component"
 	"edp-admin-console/service/logger"
 	"edp-admin-console/util"
 	"edp-admin-console/util/consts"
 	dberror "edp-admin-console/util/error/db-errors"
+	"encoding/base64"
+	edpv1alpha1 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"strconv"
 )
 
 var log = logger.GetLogger()
 
+const (
+	ordinalAnnotation = "edp.epam.com/ordinal"
+	maxIconSize       = 64 * 1024
+)
+
+var coreComponents = []string{consts.Jenkins, consts.Gerrit, consts.Openshift, consts.Kubernetes, consts.DockerRegistry}
+
 type EDPComponentService struct {
+	Clients       k8s.ClientSet
 	IEDPComponent ec.IEDPComponentRepository
 }
 
@@ -35,7 +54,8 @@ func (s EDPComponentService) GetEDPComponent(componentType string) (*query.EDPCo
 	return c, nil
 }
 
-//GetEDPComponents gets all EDP components from DB
+//GetEDPComponents gets all EDP components from DB ordered by ordinal which is kept in custom resources.
+//Components are left in DB order by type if custom resources can't be read
 func (s EDPComponentService) GetEDPComponents() ([]*query.EDPComponent, error) {
 	log.Debug("start fetching EDP Components...")
 	c, err := s.IEDPComponent.GetEDPComponents()
@@ -44,6 +64,13 @@ func (s EDPComponentService) GetEDPComponents() ([]*query.EDPComponent, error) {
 	}
 	log.Info("edp components have been fetched", zap.Any("length", len(c)))
 
+	list, err := s.getEDPComponentCRs()
+	if err != nil {
+		log.Warn("couldn't get ordinals of edp components, components are ordered by type", zap.Error(err))
+	} else {
+		setOrdinals(c, list)
+	}
+
 	for i, v := range c {
 		modifyPlatformLinks(v.Url, v.Type, c[i])
 	}
@@ -56,3 +83,272 @@ func modifyPlatformLinks(url, componentType string, c *query.EDPComponent) {
 		c.Url = util.CreateNativeProjectLink(url, context.Namespace)
 	}
 }
+
+//GetEDPComponentsDetails gets all EDP components from cluster ordered by ordinal and type
+func (s EDPComponentService) GetEDPComponentsDetails() ([]dto.EDPComponent, error) {
+	list, err := s.getEDPComponentCRs()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.EDPComponent, 0, len(list.Items))
+	for i := range list.Items {
+		res = append(res, toEDPComponentDto(&list.Items[i]))
+	}
+	sortEDPComponents(res)
+	log.Info("edp components have been fetched from cluster", zap.Int("length", len(res)))
+	return res, nil
+}
+
+//GetEDPComponentDetails gets EDP component by type from cluster
+func (s EDPComponentService) GetEDPComponentDetails(componentType string) (*dto.EDPComponent, error) {
+	cr, err := s.getEDPComponentCR(componentType)
+	if err != nil {
+		return nil, err
+	}
+	if cr == nil {
+		return nil, edperror.NewEDPComponentDoesNotExistError(componentType)
+	}
+	c := toEDPComponentDto(cr)
+	return &c, nil
+}
+
+//CreateEDPComponent creates EDP component custom resource, its ordinal is kept in the annotation
+func (s EDPComponentService) CreateEDPComponent(cmd command.EDPComponentCommand) error {
+	log.Debug("start creating edp component", zap.String("type", cmd.Type))
+	cr, err := s.getEDPComponentCR(cmd.Type)
+	if err != nil {
+		return err
+	}
+	if cr != nil {
+		return edperror.NewEDPComponentExistsError(cmd.Type)
+	}
+	if cmd.Icon == "" {
+		return edperror.NewNonValidEDPComponentError("icon should be specified")
+	}
+	if err := validateIcon(cmd.Icon); err != nil {
+		return err
+	}
+
+	c := &edpv1alpha1.EDPComponent{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v2.edp.epam.com/v1alpha1",
+			Kind:       consts.EDPComponentKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cmd.Type,
+			Namespace:   context.Namespace,
+			Annotations: map[string]string{ordinalAnnotation: strconv.Itoa(cmd.Ordinal)},
+		},
+		Spec: edpv1alpha1.EDPComponentSpec{
+			Type:    cmd.Type,
+			Url:     cmd.Url,
+			Icon:    cmd.Icon,
+			Visible: cmd.Visible,
+		},
+	}
+	err = s.Clients.EDPRestClient.Post().
+		Namespace(context.Namespace).
+		Resource(consts.EDPComponentPlural).
+		Body(c).
+		Do().
+		Into(&edpv1alpha1.EDPComponent{})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't create edp component %v", cmd.Type)
+	}
+	log.Info("edp component has been created", zap.String("type", cmd.Type), zap.String("user", cmd.Username))
+	return nil
+}
+
+//UpdateEDPComponent updates EDP component custom resource, the icon is kept if it isn't passed
+func (s EDPComponentService) UpdateEDPComponent(cmd command.EDPComponentCommand) error {
+	log.Debug("start updating edp component", zap.String("type", cmd.Type))
+	cr, err := s.getEDPComponentCR(cmd.Type)
+	if err != nil {
+		return err
+	}
+	if cr == nil {
+		return edperror.NewEDPComponentDoesNotExistError(cmd.Type)
+	}
+	if cmd.Icon != "" {
+		if err := validateIcon(cmd.Icon); err != nil {
+			return err
+		}
+		cr.Spec.Icon = cmd.Icon
+	}
+
+	cr.Spec.Url = cmd.Url
+	cr.Spec.Visible = cmd.Visible
+	setOrdinal(cr, cmd.Ordinal)
+	if err := s.updateEDPComponentCR(cr); err != nil {
+		return err
+	}
+	log.Info("edp component has been updated", zap.String("type", cmd.Type), zap.String("user", cmd.Username))
+	return nil
+}
+
+//SetVisible shows or hides EDP component on the overview page
+func (s EDPComponentService) SetVisible(componentType string, visible bool) error {
+	cr, err := s.getEDPComponentCR(componentType)
+	if err != nil {
+		return err
+	}
+	if cr == nil {
+		return edperror.NewEDPComponentDoesNotExistError(componentType)
+	}
+	cr.Spec.Visible = visible
+	if err := s.updateEDPComponentCR(cr); err != nil {
+		return err
+	}
+	log.Info("edp component visibility has been changed",
+		zap.String("type", componentType), zap.Bool("visible", visible))
+	return nil
+}
+
+//DeleteEDPComponent deletes EDP component from cluster and DB, core platform components can't be deleted
+func (s EDPComponentService) DeleteEDPComponent(componentType string) error {
+	if isCoreComponent(componentType) {
+		return edperror.NewNonValidEDPComponentError(
+			"edp component " + componentType + " is a core platform component and can't be deleted")
+	}
+	cr, err := s.getEDPComponentCR(componentType)
+	if err != nil {
+		return err
+	}
+	if cr == nil {
+		return edperror.NewEDPComponentDoesNotExistError(componentType)
+	}
+
+	err = s.Clients.EDPRestClient.Delete().
+		Namespace(context.Namespace).
+		Resource(consts.EDPComponentPlural).
+		Name(componentType).
+		Do().
+		Error()
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrapf(err, "couldn't delete edp component %v from cluster", componentType)
+	}
+	if err := s.IEDPComponent.DeleteEDPComponent(componentType); err != nil {
+		return errors.Wrapf(err, "couldn't delete edp component %v from DB", componentType)
+	}
+	log.Info("edp component has been deleted", zap.String("type", componentType))
+	return nil
+}
+
+func (s EDPComponentService) getEDPComponentCRs() (*edpv1alpha1.EDPComponentList, error) {
+	list := &edpv1alpha1.EDPComponentList{}
+	err := s.Clients.EDPRestClient.Get().
+		Namespace(context.Namespace).
+		Resource(consts.EDPComponentPlural).
+		Do().
+		Into(list)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get edp components from cluster")
+	}
+	return list, nil
+}
+
+func (s EDPComponentService) getEDPComponentCR(componentType string) (*edpv1alpha1.EDPComponent, error) {
+	r := &edpv1alpha1.EDPComponent{}
+	err := s.Clients.EDPRestClient.Get().
+		Namespace(context.Namespace).
+		Resource(consts.EDPComponentPlural).
+		Name(componentType).
+		Do().
+		Into(r)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "couldn't get edp component %v from cluster", componentType)
+	}
+	return r, nil
+}
+
+func (s EDPComponentService) updateEDPComponentCR(cr *edpv1alpha1.EDPComponent) error {
+	err := s.Clients.EDPRestClient.Put().
+		Namespace(context.Namespace).
+		Resource(consts.EDPComponentPlural).
+		Name(cr.Name).
+		Body(cr).
+		Do().
+		Into(&edpv1alpha1.EDPComponent{})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update edp component %v", cr.Name)
+	}
+	return nil
+}
+
+func toEDPComponentDto(cr *edpv1alpha1.EDPComponent) dto.EDPComponent {
+	return dto.EDPComponent{
+		Type:    cr.Spec.Type,
+		Url:     cr.Spec.Url,
+		Icon:    cr.Spec.Icon,
+		Visible: cr.Spec.Visible,
+		Ordinal: getOrdinal(cr),
+		Core:    isCoreComponent(cr.Spec.Type),
+	}
+}
+
+func getOrdinal(cr *edpv1alpha1.EDPComponent) int {
+	o, err := strconv.Atoi(cr.Annotations[ordinalAnnotation])
+	if err != nil {
+		return 0
+	}
+	return o
+}
+
+func setOrdinals(c []*query.EDPComponent, list *edpv1alpha1.EDPComponentList) {
+	ordinals := make(map[string]int, len(list.Items))
+	for i := range list.Items {
+		ordinals[list.Items[i].Spec.Type] = getOrdinal(&list.Items[i])
+	}
+	for _, v := range c {
+		v.Ordinal = ordinals[v.Type]
+	}
+	sort.SliceStable(c, func(i, j int) bool {
+		if c[i].Ordinal != c[j].Ordinal {
+			return c[i].Ordinal < c[j].Ordinal
+		}
+		return c[i].Type < c[j].Type
+	})
+}
+
+func setOrdinal(cr *edpv1alpha1.EDPComponent, ordinal int) {
+	if cr.Annotations == nil {
+		cr.Annotations = map[string]string{}
+	}
+	cr.Annotations[ordinalAnnotation] = strconv.Itoa(ordinal)
+}
+
+func sortEDPComponents(c []dto.EDPComponent) {
+	sort.SliceStable(c, func(i, j int) bool {
+		if c[i].Ordinal != c[j].Ordinal {
+			return c[i].Ordinal < c[j].Ordinal
+		}
+		return c[i].Type < c[j].Type
+	})
+}
+
+func isCoreComponent(componentType string) bool {
+	for _, c := range coreComponents {
+		if c == componentType {
+			return true
+		}
+	}
+	return false
+}
+
+func validateIcon(icon string) error {
+	b, err := base64.StdEncoding.DecodeString(icon)
+	if err != nil {
+		return edperror.NewNonValidEDPComponentError("icon should be base64 encoded")
+	}
+	if len(b) > maxIconSize {
+		return edperror.NewNonValidEDPComponentError("icon size shouldn't exceed 64KB")
+	}
+	if !bytes.Contains(b, []byte("<svg")) {
+		return edperror.NewNonValidEDPComponentError("icon should be an SVG image")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package edp_component
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"edp-admin-console/util/consts"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDeleteEDPComponentMethod_ShouldRefuseCoreComponent(t *testing.T) {
+	s := EDPComponentService{}
+
+	err := s.DeleteEDPComponent(consts.Jenkins)
+	assert.IsType(t, &edperror.NonValidEDPComponentError{}, err)
+}
+
+func TestSortEDPComponents_ShouldOrderByOrdinalThenType(t *testing.T) {
+	c := []dto.EDPComponent{
+		{Type: "sonar", Ordinal: 1},
+		{Type: "nexus", Ordinal: 1},
+		{Type: "jenkins", Ordinal: 0},
+	}
+
+	sortEDPComponents(c)
+	assert.Equal(t, "jenkins", c[0].Type)
+	assert.Equal(t, "nexus", c[1].Type)
+	assert.Equal(t, "sonar", c[2].Type)
+}
+
+func TestGetEDPComponentsMethod_ShouldOrderByOrdinalFromCustomResources(t *testing.T) {
+	context.Namespace = "stub-namespace"
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/apis/v2.edp.epam.com/v1alpha1/namespaces/stub-namespace/edpcomponents", r.URL.Path)
+		_, _ = w.Write([]byte(`{"items":[
+			{"metadata":{"name":"sonar","annotations":{"edp.epam.com/ordinal":"2"}},"spec":{"type":"sonar"}},
+			{"metadata":{"name":"nexus","annotations":{"edp.epam.com/ordinal":"1"}},"spec":{"type":"nexus"}},
+			{"metadata":{"name":"jenkins"},"spec":{"type":"jenkins"}}]}`))
+	}))
+	defer s.Close()
+	client, err := k8s.CreateEDPRestClient(s.URL)
+	assert.NoError(t, err)
+	mComp := new(mock.MockEDPComponent)
+	cs := EDPComponentService{Clients: k8s.ClientSet{EDPRestClient: client}, IEDPComponent: mComp}
+
+	mComp.On("GetEDPComponents").Return([]*query.EDPComponent{{Type: "jenkins"}, {Type: "nexus"}, {Type: "sonar"}}, nil)
+
+	c, err := cs.GetEDPComponents()
+	assert.NoError(t, err)
+	assert.Equal(t, "jenkins", c[0].Type)
+	assert.Equal(t, "nexus", c[1].Type)
+	assert.Equal(t, 1, c[1].Ordinal)
+	assert.Equal(t, "sonar", c[2].Type)
+}
+
+func TestGetEDPComponentsMethod_ShouldKeepDBOrderOnClusterError(t *testing.T) {
+	context.Namespace = "stub-namespace"
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+	client, err := k8s.CreateEDPRestClient(s.URL)
+	assert.NoError(t, err)
+	mComp := new(mock.MockEDPComponent)
+	cs := EDPComponentService{Clients: k8s.ClientSet{EDPRestClient: client}, IEDPComponent: mComp}
+
+	mComp.On("GetEDPComponents").Return([]*query.EDPComponent{{Type: "jenkins"}, {Type: "nexus"}, {Type: "sonar"}}, nil)
+
+	c, err := cs.GetEDPComponents()
+	assert.NoError(t, err)
+	assert.Len(t, c, 3)
+	assert.Equal(t, "jenkins", c[0].Type)
+	assert.Equal(t, "sonar", c[2].Type)
+}
+
+func TestValidateIcon_ShouldAcceptOnlySvgWithinLimit(t *testing.T) {
+	svg := base64.StdEncoding.EncodeToString([]byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`))
+	assert.NoError(t, validateIcon(svg))
+
+	png := base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n"))
+	assert.IsType(t, &edperror.NonValidEDPComponentError{}, validateIcon(png))
+
+	big := base64.StdEncoding.EncodeToString([]byte("<svg>" + strings.Repeat(" ", maxIconSize) + "</svg>"))
+	assert.IsType(t, &edperror.NonValidEDPComponentError{}, validateIcon(big))
+
+	assert.IsType(t, &edperror.NonValidEDPComponentError{}, validateIcon("not base64"))
+}
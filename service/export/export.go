@@ -0,0 +1,336 @@
+package export
+
+import (
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository"
+	"edp-admin-console/service/logger"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var log = logger.GetLogger()
+
+const (
+	CsvFormat  = "csv"
+	XlsxFormat = "xlsx"
+
+	listSeparator   = "; "
+	formulaPrefixes = "=+-@\t\r"
+)
+
+type Column struct {
+	Id    string
+	Title string
+}
+
+type codebaseColumn struct {
+	Column
+	value func(c *query.Codebase, pipelines []string) string
+}
+
+type cdPipelineColumn struct {
+	Column
+	value func(p *query.CDPipeline, owners map[string]string) string
+}
+
+var codebaseColumns = []codebaseColumn{
+	{Column{"name", "Name"}, func(c *query.Codebase, _ []string) string { return c.Name }},
+	{Column{"type", "Type"}, func(c *query.Codebase, _ []string) string { return string(c.Type) }},
+	{Column{"language", "Language"}, func(c *query.Codebase, _ []string) string { return c.Language }},
+	{Column{"framework", "Framework"}, func(c *query.Codebase, _ []string) string { return c.Framework }},
+	{Column{"build_tool", "Build Tool"}, func(c *query.Codebase, _ []string) string { return c.BuildTool }},
+	{Column{"versioning", "Versioning"}, func(c *query.Codebase, _ []string) string { return c.VersioningType }},
+	{Column{"strategy", "Strategy"}, func(c *query.Codebase, _ []string) string { return c.Strategy }},
+	{Column{"git_server", "Git Server"}, func(c *query.Codebase, _ []string) string { return deref(c.GitServer) }},
+	{Column{"git_project_path", "Git Project Path"}, func(c *query.Codebase, _ []string) string { return deref(c.GitProjectPath) }},
+	{Column{"jira_server", "Jira Server"}, func(c *query.Codebase, _ []string) string { return deref(c.JiraServer) }},
+	{Column{"ci_tool", "CI Tool"}, func(c *query.Codebase, _ []string) string { return c.CiTool }},
+	{Column{"jenkins_slave", "Jenkins Slave"}, func(c *query.Codebase, _ []string) string { return c.JenkinsSlave }},
+	{Column{"job_provisioning", "Job Provisioning"}, func(c *query.Codebase, _ []string) string { return c.JobProvisioning }},
+	{Column{"default_branch", "Default Branch"}, func(c *query.Codebase, _ []string) string { return c.DefaultBranch }},
+	{Column{"branches", "Branches"}, func(c *query.Codebase, _ []string) string {
+		var names []string
+		for _, b := range c.CodebaseBranch {
+			names = append(names, b.Name)
+		}
+		return strings.Join(names, listSeparator)
+	}},
+	{Column{"pipelines", "CD Pipelines"}, func(_ *query.Codebase, pipelines []string) string {
+		return strings.Join(pipelines, listSeparator)
+	}},
+	{Column{"labels", "Labels"}, func(c *query.Codebase, _ []string) string { return joinLabels(c.Labels) }},
+	{Column{"status", "Status"}, func(c *query.Codebase, _ []string) string { return string(c.Status) }},
+	{Column{"description", "Description"}, func(c *query.Codebase, _ []string) string { return c.Description }},
+}
+
+var cdPipelineColumns = []cdPipelineColumn{
+	{Column{"name", "Name"}, func(p *query.CDPipeline, _ map[string]string) string { return p.Name }},
+	{Column{"status", "Status"}, func(p *query.CDPipeline, _ map[string]string) string { return p.Status }},
+	{Column{"applications", "Applications"}, func(p *query.CDPipeline, owners map[string]string) string {
+		var names []string
+		for _, ds := range p.CodebaseDockerStream {
+			if o, ok := owners[ds.OcImageStreamName]; ok {
+				names = append(names, o)
+			}
+		}
+		return strings.Join(names, listSeparator)
+	}},
+	{Column{"docker_streams", "Docker Streams"}, func(p *query.CDPipeline, _ map[string]string) string {
+		var names []string
+		for _, ds := range p.CodebaseDockerStream {
+			names = append(names, ds.OcImageStreamName)
+		}
+		return strings.Join(names, listSeparator)
+	}},
+	{Column{"stages", "Stages"}, func(p *query.CDPipeline, _ map[string]string) string {
+		var names []string
+		for _, s := range p.Stage {
+			names = append(names, s.Name)
+		}
+		return strings.Join(names, listSeparator)
+	}},
+	{Column{"labels", "Labels"}, func(p *query.CDPipeline, _ map[string]string) string { return joinLabels(p.Labels) }},
+}
+
+var (
+	defaultCodebaseColumns = []string{"name", "language", "framework", "build_tool", "versioning",
+		"git_server", "jira_server", "branches", "pipelines"}
+	defaultCDPipelineColumns = []string{"name", "applications", "stages"}
+)
+
+type ExportService struct {
+	ICodebaseRepository   repository.ICodebaseRepository
+	ICDPipelineRepository repository.ICDPipelineRepository
+}
+
+//CodebaseColumns returns all columns which can be selected for codebase export
+func CodebaseColumns() []Column {
+	res := make([]Column, len(codebaseColumns))
+	for i, c := range codebaseColumns {
+		res[i] = c.Column
+	}
+	return res
+}
+
+//CDPipelineColumns returns all columns which can be selected for CD pipeline export
+func CDPipelineColumns() []Column {
+	res := make([]Column, len(cdPipelineColumns))
+	for i, c := range cdPipelineColumns {
+		res[i] = c.Column
+	}
+	return res
+}
+
+//ExportCodebases writes codebases found by the criteria in the format. Default columns are used if none is selected
+func (s ExportService) ExportCodebases(criteria query.CodebaseCriteria, columns []string, format string, w io.Writer) error {
+	log.Debug("start exporting codebases", zap.Any("criteria", criteria), zap.Strings("columns", columns))
+	if err := validateFormat(format); err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		columns = defaultCodebaseColumns
+	}
+	selected := make([]codebaseColumn, 0, len(columns))
+	for _, id := range columns {
+		c, ok := findCodebaseColumn(id)
+		if !ok {
+			return edperror.NewNonValidExportParamsError(fmt.Sprintf("unknown column %v", id))
+		}
+		selected = append(selected, c)
+	}
+
+	codebases, err := s.ICodebaseRepository.GetCodebasesByCriteria(criteria)
+	if err != nil {
+		return errors.Wrap(err, "couldn't get codebases")
+	}
+
+	pipelines, err := s.ICDPipelineRepository.GetCDPipelines(query.CDPipelineCriteria{})
+	if err != nil {
+		return errors.Wrap(err, "couldn't get cd pipelines")
+	}
+	consumers := getConsumers(pipelines)
+
+	rows := [][]string{make([]string, len(selected))}
+	for i, c := range selected {
+		rows[0][i] = c.Title
+	}
+	for _, cb := range codebases {
+		row := make([]string, len(selected))
+		for i, c := range selected {
+			row[i] = c.value(cb, getCodebasePipelines(cb, consumers))
+		}
+		rows = append(rows, row)
+	}
+	return writeTable(format, string(criteria.Type), rows, w)
+}
+
+//ExportCDPipelines writes CD pipelines found by the criteria in the format. Default columns are used if none is selected
+func (s ExportService) ExportCDPipelines(criteria query.CDPipelineCriteria, columns []string, format string, w io.Writer) error {
+	log.Debug("start exporting cd pipelines", zap.Any("criteria", criteria), zap.Strings("columns", columns))
+	if err := validateFormat(format); err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		columns = defaultCDPipelineColumns
+	}
+	selected := make([]cdPipelineColumn, 0, len(columns))
+	for _, id := range columns {
+		c, ok := findCDPipelineColumn(id)
+		if !ok {
+			return edperror.NewNonValidExportParamsError(fmt.Sprintf("unknown column %v", id))
+		}
+		selected = append(selected, c)
+	}
+
+	pipelines, err := s.ICDPipelineRepository.GetCDPipelines(criteria)
+	if err != nil {
+		return errors.Wrap(err, "couldn't get cd pipelines")
+	}
+
+	codebases, err := s.ICodebaseRepository.GetCodebasesByCriteria(query.CodebaseCriteria{})
+	if err != nil {
+		return errors.Wrap(err, "couldn't get codebases")
+	}
+	owners := map[string]string{}
+	for _, c := range codebases {
+		for _, b := range c.CodebaseBranch {
+			for _, ds := range b.CodebaseDockerStream {
+				owners[ds.OcImageStreamName] = c.Name
+			}
+		}
+	}
+
+	rows := [][]string{make([]string, len(selected))}
+	for i, c := range selected {
+		rows[0][i] = c.Title
+	}
+	for _, p := range pipelines {
+		row := make([]string, len(selected))
+		for i, c := range selected {
+			row[i] = c.value(p, owners)
+		}
+		rows = append(rows, row)
+	}
+	return writeTable(format, "cd-pipelines", rows, w)
+}
+
+type pipelineConsumers struct {
+	byStream map[string][]string
+	byBranch map[int][]string
+}
+
+//getConsumers indexes pipelines by docker streams they promote
+//and by branches of autotests and libraries used by their stages
+func getConsumers(pipelines []*query.CDPipeline) pipelineConsumers {
+	res := pipelineConsumers{byStream: map[string][]string{}, byBranch: map[int][]string{}}
+	for _, p := range pipelines {
+		for _, ds := range p.CodebaseDockerStream {
+			res.byStream[ds.OcImageStreamName] = append(res.byStream[ds.OcImageStreamName], p.Name)
+		}
+		for _, st := range p.Stage {
+			if st.SourceCodebaseBranchId != nil {
+				res.byBranch[*st.SourceCodebaseBranchId] = append(res.byBranch[*st.SourceCodebaseBranchId], p.Name)
+			}
+			for _, qg := range st.QualityGates {
+				if qg.CodebaseBranchId != nil {
+					res.byBranch[*qg.CodebaseBranchId] = append(res.byBranch[*qg.CodebaseBranchId], p.Name)
+				}
+			}
+		}
+	}
+	return res
+}
+
+func getCodebasePipelines(c *query.Codebase, consumers pipelineConsumers) []string {
+	set := map[string]bool{}
+	for _, b := range c.CodebaseBranch {
+		for _, p := range consumers.byBranch[b.Id] {
+			set[p] = true
+		}
+		for _, ds := range b.CodebaseDockerStream {
+			for _, p := range consumers.byStream[ds.OcImageStreamName] {
+				set[p] = true
+			}
+		}
+	}
+	res := make([]string, 0, len(set))
+	for p := range set {
+		res = append(res, p)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func writeTable(format, name string, rows [][]string, w io.Writer) error {
+	if format == XlsxFormat {
+		return writeXlsx(w, name, rows)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(escapeFormulas(rows)); err != nil {
+		return errors.Wrap(err, "couldn't write csv")
+	}
+	return nil
+}
+
+// escapeFormulas prefixes cells which spreadsheet applications would evaluate as formulas
+func escapeFormulas(rows [][]string) [][]string {
+	res := make([][]string, len(rows))
+	for i, r := range rows {
+		res[i] = make([]string, len(r))
+		for j, v := range r {
+			if v != "" && strings.ContainsRune(formulaPrefixes, rune(v[0])) {
+				v = "'" + v
+			}
+			res[i][j] = v
+		}
+	}
+	return res
+}
+
+func validateFormat(format string) error {
+	if format != CsvFormat && format != XlsxFormat {
+		return edperror.NewNonValidExportParamsError(fmt.Sprintf("format should be one of %v, %v", CsvFormat, XlsxFormat))
+	}
+	return nil
+}
+
+func findCodebaseColumn(id string) (codebaseColumn, bool) {
+	for _, c := range codebaseColumns {
+		if c.Id == id {
+			return c, true
+		}
+	}
+	return codebaseColumn{}, false
+}
+
+func findCDPipelineColumn(id string) (cdPipelineColumn, bool) {
+	for _, c := range cdPipelineColumns {
+		if c.Id == id {
+			return c, true
+		}
+	}
+	return cdPipelineColumn{}, false
+}
+
+func joinLabels(labels map[string]string) string {
+	res := make([]string, 0, len(labels))
+	for k, v := range labels {
+		res = append(res, fmt.Sprintf("%v=%v", k, v))
+	}
+	sort.Strings(res)
+	return strings.Join(res, listSeparator)
+}
+
+func deref(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
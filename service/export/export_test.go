@@ -0,0 +1,98 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+func createTestData() ([]*query.Codebase, []*query.CDPipeline) {
+	gitServer := "gerrit"
+	libBranch := 2
+	codebases := []*query.Codebase{
+		{Name: "fake-app", Type: query.App, Language: "java", GitServer: &gitServer,
+			Labels: map[string]string{"team": "alpha", "domain": "payments"},
+			CodebaseBranch: []*query.CodebaseBranch{
+				{Id: 1, Name: "master", CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-app-master"}}},
+				{Id: 3, Name: "develop"},
+			}},
+		{Name: "fake-lib", Type: query.Library, Language: "go", CodebaseBranch: []*query.CodebaseBranch{{Id: libBranch, Name: "master"}}},
+	}
+	pipelines := []*query.CDPipeline{
+		{Name: "fake-pipeline", CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-app-master"}},
+			Stage: []*query.Stage{{Name: "sit", SourceCodebaseBranchId: &libBranch}, {Name: "qa"}}},
+	}
+	return codebases, pipelines
+}
+
+func TestExportCodebasesMethod_ShouldWriteCsv(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	mPipeline := new(mock.MockCdPipeline)
+	s := ExportService{ICodebaseRepository: mCodebase, ICDPipelineRepository: mPipeline}
+
+	codebases, pipelines := createTestData()
+	criteria := query.CodebaseCriteria{Type: query.App}
+	mCodebase.On("GetCodebasesByCriteria", criteria).Return(codebases, nil)
+	mPipeline.On("GetCDPipelines", query.CDPipelineCriteria{}).Return(pipelines, nil)
+
+	var buf bytes.Buffer
+	err := s.ExportCodebases(criteria, []string{"name", "git_server", "branches", "pipelines", "labels"}, CsvFormat, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "Name,Git Server,Branches,CD Pipelines,Labels\n"+
+		"fake-app,gerrit,master; develop,fake-pipeline,domain=payments; team=alpha\n"+
+		"fake-lib,,master,fake-pipeline,\n", buf.String())
+}
+
+func TestExportCDPipelinesMethod_ShouldWriteXlsx(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	mPipeline := new(mock.MockCdPipeline)
+	s := ExportService{ICodebaseRepository: mCodebase, ICDPipelineRepository: mPipeline}
+
+	codebases, pipelines := createTestData()
+	mCodebase.On("GetCodebasesByCriteria", query.CodebaseCriteria{}).Return(codebases, nil)
+	mPipeline.On("GetCDPipelines", query.CDPipelineCriteria{}).Return(pipelines, nil)
+
+	var buf bytes.Buffer
+	err := s.ExportCDPipelines(query.CDPipelineCriteria{}, nil, XlsxFormat, &buf)
+	assert.NoError(t, err)
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	var sheet string
+	for _, f := range r.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			b, _ := ioutil.ReadAll(rc)
+			sheet = string(b)
+		}
+	}
+	assert.Contains(t, sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">fake-app</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="C2" t="inlineStr"><is><t xml:space="preserve">sit; qa</t></is></c>`)
+}
+
+func TestExportCodebasesMethod_ShouldRefuseUnknownColumn(t *testing.T) {
+	s := ExportService{}
+
+	err := s.ExportCodebases(query.CodebaseCriteria{}, []string{"fake"}, CsvFormat, &bytes.Buffer{})
+	assert.IsType(t, &edperror.NonValidExportParamsError{}, err)
+
+	err = s.ExportCodebases(query.CodebaseCriteria{}, nil, "pdf", &bytes.Buffer{})
+	assert.IsType(t, &edperror.NonValidExportParamsError{}, err)
+}
+
+func TestColumnNameMethod_ShouldConvertIndexToLetters(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AB", columnName(27))
+}
+
+func TestEscapeFormulasMethod_ShouldPrefixFormulaCells(t *testing.T) {
+	rows := escapeFormulas([][]string{{"name", "=HYPERLINK(\"http://stub\")", "-1+2", "@SUM(A1)", "+1", "stub-description"}})
+	assert.Equal(t, []string{"name", "'=HYPERLINK(\"http://stub\")", "'-1+2", "'@SUM(A1)", "'+1", "stub-description"}, rows[0])
+}
@@ -0,0 +1,212 @@
+package freeze_window
+
+import (
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	fw "edp-admin-console/repository/freeze-window"
+	"edp-admin-console/service/logger"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var log = logger.GetLogger()
+
+var recurrencePeriods = map[string]time.Duration{
+	query.DailyRecurrence:  24 * time.Hour,
+	query.WeeklyRecurrence: 7 * 24 * time.Hour,
+}
+
+type FreezeWindowService struct {
+	IFreezeWindowRepository fw.IFreezeWindowRepository
+}
+
+//GetFreezeWindows returns all freeze windows of the stage with their current state
+func (s FreezeWindowService) GetFreezeWindows(pipelineName, stageName string) ([]*query.FreezeWindow, error) {
+	stageId, err := s.getStageId(pipelineName, stageName)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := s.IFreezeWindowRepository.SelectFreezeWindows(*stageId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get freeze windows of stage %v", stageName)
+	}
+	markActive(windows, time.Now())
+	return windows, nil
+}
+
+//CreateFreezeWindow validates and saves one-off or recurring freeze window of the stage
+func (s FreezeWindowService) CreateFreezeWindow(pipelineName, stageName string, cmd command.FreezeWindowCommand) (*query.FreezeWindow, error) {
+	log.Debug("start creating freeze window",
+		zap.String("pipeline", pipelineName),
+		zap.String("stage", stageName),
+		zap.Any("window", cmd))
+	if cmd.Recurrence == "" {
+		cmd.Recurrence = query.NoRecurrence
+	}
+	if err := validateWindow(cmd); err != nil {
+		return nil, err
+	}
+
+	stageId, err := s.getStageId(pipelineName, stageName)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &query.FreezeWindow{
+		CdStageId:  *stageId,
+		Reason:     cmd.Reason,
+		StartTime:  cmd.StartTime,
+		EndTime:    cmd.EndTime,
+		Recurrence: cmd.Recurrence,
+		CreatedBy:  cmd.Username,
+	}
+	if err := s.IFreezeWindowRepository.CreateFreezeWindow(w); err != nil {
+		return nil, errors.Wrapf(err, "couldn't create freeze window for stage %v", stageName)
+	}
+	log.Info("freeze window has been created",
+		zap.String("pipeline", pipelineName),
+		zap.String("stage", stageName),
+		zap.Int("id", w.Id))
+	return w, nil
+}
+
+//DeleteFreezeWindow removes freeze window from the stage
+func (s FreezeWindowService) DeleteFreezeWindow(pipelineName, stageName string, id int) error {
+	stageId, err := s.getStageId(pipelineName, stageName)
+	if err != nil {
+		return err
+	}
+	n, err := s.IFreezeWindowRepository.DeleteFreezeWindow(*stageId, id)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't delete freeze window %v", id)
+	}
+	if n == 0 {
+		return edperror.NewFreezeWindowDoesNotExistError(id)
+	}
+	log.Info("freeze window has been deleted",
+		zap.String("pipeline", pipelineName),
+		zap.String("stage", stageName),
+		zap.Int("id", id))
+	return nil
+}
+
+//GetFreezeStatus checks whether the stage is frozen at the passed time
+func (s FreezeWindowService) GetFreezeStatus(pipelineName, stageName string, at time.Time) (*query.FreezeStatus, error) {
+	stageId, err := s.getStageId(pipelineName, stageName)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := s.IFreezeWindowRepository.SelectFreezeWindows(*stageId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get freeze windows of stage %v", stageName)
+	}
+	return getFreezeStatus(windows, at), nil
+}
+
+//CheckStageIsNotFrozen returns StageFrozenError if any freeze window of the stage is active now
+func (s FreezeWindowService) CheckStageIsNotFrozen(pipelineName, stageName string) error {
+	stageId, err := s.IFreezeWindowRepository.SelectStageId(pipelineName, stageName)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get stage %v of cd pipeline %v", stageName, pipelineName)
+	}
+	if stageId == nil {
+		return nil
+	}
+	windows, err := s.IFreezeWindowRepository.SelectFreezeWindows(*stageId)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get freeze windows of stage %v", stageName)
+	}
+	status := getFreezeStatus(windows, time.Now())
+	if status.Frozen {
+		return edperror.NewStageFrozenError(stageName, status.Reason, *status.Until)
+	}
+	return nil
+}
+
+//FillFreezeWindows sets freeze windows to the stages
+func (s FreezeWindowService) FillFreezeWindows(stages []*query.Stage) error {
+	now := time.Now()
+	for _, stage := range stages {
+		windows, err := s.IFreezeWindowRepository.SelectFreezeWindows(stage.Id)
+		if err != nil {
+			return errors.Wrapf(err, "couldn't get freeze windows of stage %v", stage.Name)
+		}
+		markActive(windows, now)
+		stage.FreezeWindows = windows
+	}
+	return nil
+}
+
+func (s FreezeWindowService) getStageId(pipelineName, stageName string) (*int, error) {
+	stageId, err := s.IFreezeWindowRepository.SelectStageId(pipelineName, stageName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get stage %v of cd pipeline %v", stageName, pipelineName)
+	}
+	if stageId == nil {
+		return nil, edperror.NewCDStageDoesNotExistError(stageName)
+	}
+	return stageId, nil
+}
+
+func validateWindow(cmd command.FreezeWindowCommand) error {
+	if cmd.StartTime.IsZero() || cmd.EndTime.IsZero() {
+		return edperror.NewNonValidFreezeWindowError("start and end time of freeze window should be specified")
+	}
+	if !cmd.EndTime.After(cmd.StartTime) {
+		return edperror.NewNonValidFreezeWindowError("end time of freeze window should be after start time")
+	}
+	if period, ok := recurrencePeriods[cmd.Recurrence]; ok && cmd.EndTime.Sub(cmd.StartTime) >= period {
+		return edperror.NewNonValidFreezeWindowError(
+			fmt.Sprintf("%v freeze window should be shorter than its recurrence period", cmd.Recurrence))
+	}
+	return nil
+}
+
+func markActive(windows []*query.FreezeWindow, at time.Time) {
+	for _, w := range windows {
+		w.Active = activeUntil(w, at) != nil
+	}
+}
+
+func getFreezeStatus(windows []*query.FreezeWindow, at time.Time) *query.FreezeStatus {
+	status := &query.FreezeStatus{Windows: windows}
+	for _, w := range windows {
+		until := activeUntil(w, at)
+		if until == nil {
+			continue
+		}
+		w.Active = true
+		if !status.Frozen || until.After(*status.Until) {
+			status.Frozen = true
+			status.Reason = w.Reason
+			status.Until = until
+		}
+	}
+	return status
+}
+
+//activeUntil returns the end of the window occurrence which covers the passed time or nil if there's no such one
+func activeUntil(w *query.FreezeWindow, at time.Time) *time.Time {
+	if at.Before(w.StartTime) {
+		return nil
+	}
+	duration := w.EndTime.Sub(w.StartTime)
+	period, ok := recurrencePeriods[w.Recurrence]
+	if !ok {
+		if at.Before(w.EndTime) {
+			return &w.EndTime
+		}
+		return nil
+	}
+
+	elapsed := at.Sub(w.StartTime) % period
+	if elapsed >= duration {
+		return nil
+	}
+	until := at.Add(duration - elapsed)
+	return &until
+}
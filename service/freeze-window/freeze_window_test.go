@@ -0,0 +1,94 @@
+package freeze_window
+
+import (
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFreezeStatusMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	mRepo := new(mock.MockFreezeWindow)
+	s := FreezeWindowService{
+		IFreezeWindowRepository: mRepo,
+	}
+
+	start := time.Date(2020, 3, 2, 18, 0, 0, 0, time.UTC)
+	mRepo.On("SelectStageId", "stub-pipeline", "prod").Return(1, nil)
+	mRepo.On("SelectFreezeWindows", 1).Return([]*query.FreezeWindow{
+		{
+			Reason:     "weekend",
+			StartTime:  start,
+			EndTime:    start.Add(2 * time.Hour),
+			Recurrence: query.WeeklyRecurrence,
+		},
+	}, nil)
+
+	status, err := s.GetFreezeStatus("stub-pipeline", "prod", start.Add(7*24*time.Hour+time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, status.Frozen)
+	assert.Equal(t, "weekend", status.Reason)
+	assert.Equal(t, start.Add(7*24*time.Hour+2*time.Hour), *status.Until)
+
+	status, err = s.GetFreezeStatus("stub-pipeline", "prod", start.Add(24*time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, status.Frozen)
+	assert.Nil(t, status.Until)
+}
+
+func TestActiveUntil_ShouldRespectOneOffWindow(t *testing.T) {
+	start := time.Date(2020, 3, 2, 18, 0, 0, 0, time.UTC)
+	w := &query.FreezeWindow{
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+		Recurrence: query.NoRecurrence,
+	}
+
+	assert.Nil(t, activeUntil(w, start.Add(-time.Minute)))
+	assert.Equal(t, start.Add(time.Hour), *activeUntil(w, start.Add(time.Minute)))
+	assert.Nil(t, activeUntil(w, start.Add(24*time.Hour+time.Minute)))
+}
+
+func TestCreateFreezeWindowMethod_ShouldReturnValidationError(t *testing.T) {
+	mRepo := new(mock.MockFreezeWindow)
+	s := FreezeWindowService{
+		IFreezeWindowRepository: mRepo,
+	}
+
+	start := time.Date(2020, 3, 2, 18, 0, 0, 0, time.UTC)
+	w, err := s.CreateFreezeWindow("stub-pipeline", "prod", command.FreezeWindowCommand{
+		Reason:     "release",
+		StartTime:  start,
+		EndTime:    start.Add(25 * time.Hour),
+		Recurrence: query.DailyRecurrence,
+	})
+	assert.Nil(t, w)
+	assert.IsType(t, &edperror.NonValidFreezeWindowError{}, err)
+	mRepo.AssertNotCalled(t, "CreateFreezeWindow")
+}
+
+func TestCheckStageIsNotFrozenMethod_ShouldReturnFrozenError(t *testing.T) {
+	mRepo := new(mock.MockFreezeWindow)
+	s := FreezeWindowService{
+		IFreezeWindowRepository: mRepo,
+	}
+
+	now := time.Now()
+	mRepo.On("SelectStageId", "stub-pipeline", "prod").Return(1, nil)
+	mRepo.On("SelectFreezeWindows", 1).Return([]*query.FreezeWindow{
+		{
+			Reason:     "release freeze",
+			StartTime:  now.Add(-time.Hour),
+			EndTime:    now.Add(time.Hour),
+			Recurrence: query.NoRecurrence,
+		},
+	}, nil)
+
+	err := s.CheckStageIsNotFrozen("stub-pipeline", "prod")
+	assert.IsType(t, &edperror.StageFrozenError{}, err)
+	assert.Equal(t, "release freeze", err.(*edperror.StageFrozenError).Reason)
+}
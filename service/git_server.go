@@ -1,12 +1,39 @@
 package service
 
 import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository"
+	"edp-admin-console/util"
+	"edp-admin-console/util/consts"
+	"fmt"
+	edpv1alpha1 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	gitServerSshKeyField        = "id_rsa"
+	gitServerUsernameField      = "username"
+	gitServerKnownHostsField    = "known_hosts"
+	gitServerDisabledAnnotation = "edp.epam.com/disabled"
+	gitServerConnectTimeout     = 10 * time.Second
 )
 
 type GitServerService struct {
+	Clients              k8s.ClientSet
 	IGitServerRepository repository.IGitServerRepository
 }
 
@@ -36,3 +63,278 @@ func (s GitServerService) GetGitServer(name string) (*query.GitServer, error) {
 	log.Info("Fetched Git Server", zap.Any("git server", g))
 	return g, nil
 }
+
+func (s GitServerService) GetAllServers() ([]*query.GitServer, error) {
+	gitServers, err := s.IGitServerRepository.GetAllGitServers()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get git servers")
+	}
+	return gitServers, nil
+}
+
+func (s GitServerService) GetGitServerDetails(name string) (*dto.GitServer, error) {
+	cr, err := s.getGitServerCR(name)
+	if err != nil {
+		return nil, err
+	}
+	if cr == nil {
+		return nil, edperror.NewGitServerDoesNotExistError(name)
+	}
+
+	res := &dto.GitServer{
+		Name:         cr.Name,
+		Hostname:     cr.Spec.GitHost,
+		SshPort:      cr.Spec.SshPort,
+		HttpsPort:    cr.Spec.HttpsPort,
+		User:         cr.Spec.GitUser,
+		SshKeySecret: cr.Spec.NameSshKeySecret,
+	}
+	g, err := s.IGitServerRepository.GetGitServerByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get git server %v", name)
+	}
+	if g != nil {
+		res.Available = g.Available
+		res.Disabled = g.Disabled
+	}
+	return res, nil
+}
+
+func (s GitServerService) CreateGitServer(cmd command.GitServerCommand) error {
+	log.Debug("start creating git server", zap.String("name", cmd.Name))
+	cr, err := s.getGitServerCR(cmd.Name)
+	if err != nil {
+		return err
+	}
+	g, err := s.IGitServerRepository.GetGitServerByName(cmd.Name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get git server %v", cmd.Name)
+	}
+	if cr != nil || g != nil {
+		return edperror.NewGitServerExistsError(cmd.Name)
+	}
+	if cmd.SshKey == "" {
+		return edperror.NewNonValidGitServerError("ssh key should be specified")
+	}
+
+	secretName := fmt.Sprintf("%v-sshkey", cmd.Name)
+	if err := s.saveSshKeySecret(secretName, cmd); err != nil {
+		return err
+	}
+
+	gs := &edpv1alpha1.GitServer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v2.edp.epam.com/v1alpha1",
+			Kind:       consts.GitServerKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.Name,
+			Namespace: context.Namespace,
+		},
+		Spec: edpv1alpha1.GitServerSpec{
+			GitHost:          cmd.Hostname,
+			GitUser:          cmd.User,
+			HttpsPort:        cmd.HttpsPort,
+			SshPort:          cmd.SshPort,
+			NameSshKeySecret: secretName,
+		},
+	}
+	err = s.Clients.EDPRestClient.Post().
+		Namespace(context.Namespace).
+		Resource(consts.GitServerPlural).
+		Body(gs).
+		Do().
+		Into(&edpv1alpha1.GitServer{})
+	if err != nil {
+		if derr := s.Clients.CoreClient.Secrets(context.Namespace).Delete(secretName, &metav1.DeleteOptions{}); derr != nil {
+			log.Error("couldn't delete ssh key secret of git server", zap.String("secret", secretName), zap.Error(derr))
+		}
+		return errors.Wrapf(err, "couldn't create git server %v", cmd.Name)
+	}
+	log.Info("git server has been created", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+func (s GitServerService) UpdateGitServer(cmd command.GitServerCommand) error {
+	log.Debug("start updating git server", zap.String("name", cmd.Name))
+	cr, err := s.getGitServerCR(cmd.Name)
+	if err != nil {
+		return err
+	}
+	if cr == nil {
+		return edperror.NewGitServerDoesNotExistError(cmd.Name)
+	}
+
+	if cmd.SshKey != "" || cmd.KnownHosts != "" || cmd.User != cr.Spec.GitUser {
+		if cr.Spec.NameSshKeySecret == "" {
+			cr.Spec.NameSshKeySecret = fmt.Sprintf("%v-sshkey", cmd.Name)
+		}
+		if err := s.saveSshKeySecret(cr.Spec.NameSshKeySecret, cmd); err != nil {
+			return err
+		}
+	}
+
+	cr.Spec.GitHost = cmd.Hostname
+	cr.Spec.GitUser = cmd.User
+	cr.Spec.SshPort = cmd.SshPort
+	cr.Spec.HttpsPort = cmd.HttpsPort
+	err = s.Clients.EDPRestClient.Put().
+		Namespace(context.Namespace).
+		Resource(consts.GitServerPlural).
+		Name(cmd.Name).
+		Body(cr).
+		Do().
+		Into(&edpv1alpha1.GitServer{})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update git server %v", cmd.Name)
+	}
+	log.Info("git server has been updated", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+// SetDisabled marks GitServer CR with annotation and stores the flag in DB, annotation is reverted if DB update fails
+func (s GitServerService) SetDisabled(name string, disabled bool) error {
+	g, err := s.IGitServerRepository.GetGitServerByName(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get git server %v", name)
+	}
+	if g == nil {
+		return edperror.NewGitServerDoesNotExistError(name)
+	}
+
+	if err := s.patchDisabledAnnotation(name, disabled); err != nil {
+		return err
+	}
+	if err := s.IGitServerRepository.UpdateDisabled(name, disabled); err != nil {
+		if rerr := s.patchDisabledAnnotation(name, g.Disabled); rerr != nil {
+			log.Error("couldn't revert git server state in cluster", zap.String("name", name), zap.Error(rerr))
+		}
+		return errors.Wrapf(err, "couldn't update git server %v", name)
+	}
+	log.Info("git server state has been changed", zap.String("name", name), zap.Bool("disabled", disabled))
+	return nil
+}
+
+func (s GitServerService) patchDisabledAnnotation(name string, disabled bool) error {
+	var value interface{}
+	if disabled {
+		value = "true"
+	}
+	bytes, err := util.EncodeStructToBytes(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				gitServerDisabledAnnotation: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.Clients.EDPRestClient.Patch(types.MergePatchType).
+		Namespace(context.Namespace).
+		Resource(consts.GitServerPlural).
+		Name(name).
+		Body(bytes).
+		Do().Error()
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update state of git server %v in cluster", name)
+	}
+	return nil
+}
+
+func (s GitServerService) TestConnection(name string) (*dto.GitServerConnectionTest, error) {
+	gs, err := s.GetGitServerDetails(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var key, knownHosts []byte
+	if gs.SshKeySecret != "" {
+		secret, err := s.Clients.CoreClient.Secrets(context.Namespace).Get(gs.SshKeySecret, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "couldn't get secret %v", gs.SshKeySecret)
+		}
+		if err == nil {
+			key = secret.Data[gitServerSshKeyField]
+			knownHosts = secret.Data[gitServerKnownHostsField]
+		}
+	}
+
+	res := &dto.GitServerConnectionTest{
+		Ssh:   checkSshConnection(gs.Hostname, gs.SshPort, gs.User, key, knownHosts),
+		Https: checkHttpsConnection(gs.Hostname, gs.HttpsPort),
+	}
+	log.Info("git server connection has been tested", zap.String("name", name), zap.Any("result", res))
+	return res, nil
+}
+
+func (s GitServerService) getGitServerCR(name string) (*edpv1alpha1.GitServer, error) {
+	r := &edpv1alpha1.GitServer{}
+	err := s.Clients.EDPRestClient.Get().
+		Namespace(context.Namespace).
+		Resource(consts.GitServerPlural).
+		Name(name).
+		Do().
+		Into(r)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "couldn't get git server %v from cluster", name)
+	}
+	return r, nil
+}
+
+func (s GitServerService) saveSshKeySecret(name string, cmd command.GitServerCommand) error {
+	data := map[string]string{
+		gitServerSshKeyField:     cmd.SshKey,
+		gitServerKnownHostsField: cmd.KnownHosts,
+		gitServerUsernameField:   cmd.User,
+	}
+	return util.SaveSecret(s.Clients.CoreClient, name, data, func() error {
+		if cmd.SshKey == "" {
+			return edperror.NewNonValidGitServerError("ssh key should be specified as secret doesn't exist")
+		}
+		return nil
+	})
+}
+
+func checkSshConnection(host string, port int32, user string, key, knownHosts []byte) dto.ConnectionCheck {
+	if len(key) == 0 {
+		return dto.ConnectionCheck{Message: "ssh key isn't found"}
+	}
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return dto.ConnectionCheck{Message: fmt.Sprintf("couldn't parse ssh key: %v", err)}
+	}
+	hkc, err := util.GetHostKeyCallback(knownHosts)
+	if err != nil {
+		return dto.ConnectionCheck{Message: fmt.Sprintf("couldn't read known hosts: %v", err)}
+	}
+
+	client, err := ssh.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(port))), &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hkc,
+		Timeout:         gitServerConnectTimeout,
+	})
+	if err != nil {
+		return dto.ConnectionCheck{Message: err.Error()}
+	}
+	_ = client.Close()
+	return dto.ConnectionCheck{Success: true, Message: "authenticated"}
+}
+
+func checkHttpsConnection(host string, port int32) dto.ConnectionCheck {
+	c := http.Client{Timeout: gitServerConnectTimeout}
+	resp, err := c.Get(fmt.Sprintf("https://%v/", net.JoinHostPort(host, strconv.Itoa(int(port)))))
+	if err != nil {
+		return dto.ConnectionCheck{Message: err.Error()}
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return dto.ConnectionCheck{Message: resp.Status}
+	}
+	return dto.ConnectionCheck{Success: true, Message: resp.Status}
+}
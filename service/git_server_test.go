@@ -0,0 +1,66 @@
+package service
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetDisabledMethod_ShouldUpdateFlag(t *testing.T) {
+	context.Namespace = "stub-namespace"
+	var patched bool
+	k8sStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		patched = r.Method == http.MethodPatch && r.URL.Path == "/apis/v2.edp.epam.com/v1alpha1/namespaces/stub-namespace/gitservers/stub-gitlab"
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"metadata":{"name":"stub-gitlab"}}`))
+	}))
+	defer k8sStub.Close()
+	client, err := k8s.CreateEDPRestClient(k8sStub.URL)
+	assert.NoError(t, err)
+
+	mGitServer := new(mock.MockGitServer)
+	s := GitServerService{
+		Clients:              k8s.ClientSet{EDPRestClient: client},
+		IGitServerRepository: mGitServer,
+	}
+
+	mGitServer.On("GetGitServerByName", "stub-gitlab").Return(query.GitServer{Name: "stub-gitlab"}, nil)
+	mGitServer.On("UpdateDisabled", "stub-gitlab", true).Return(nil)
+
+	assert.NoError(t, s.SetDisabled("stub-gitlab", true))
+	assert.True(t, patched)
+}
+
+func TestSetDisabledMethod_ShouldReturnNotFoundError(t *testing.T) {
+	mGitServer := new(mock.MockGitServer)
+	s := GitServerService{IGitServerRepository: mGitServer}
+
+	mGitServer.On("GetGitServerByName", "stub-gitlab").Return(nil, nil)
+
+	err := s.SetDisabled("stub-gitlab", true)
+	assert.IsType(t, &edperror.GitServerDoesNotExistError{}, err)
+}
+
+func TestCheckSshConnectionMethod_ShouldRequireKey(t *testing.T) {
+	res := checkSshConnection("localhost", 22, "git", nil, nil)
+	assert.False(t, res.Success)
+	assert.Equal(t, "ssh key isn't found", res.Message)
+}
+
+func TestCheckHttpsConnectionMethod_ShouldReportClosedPort(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	assert.NoError(t, l.Close())
+
+	res := checkHttpsConnection("127.0.0.1", int32(port))
+	assert.False(t, res.Success)
+	assert.NotEmpty(t, res.Message)
+}
@@ -0,0 +1,208 @@
+package gitref
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository"
+	ec "edp-admin-console/service/edp-component"
+	"edp-admin-console/service/logger"
+	"edp-admin-console/util"
+	"edp-admin-console/util/consts"
+	"fmt"
+	edpv1alpha1 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	log = logger.GetLogger()
+	// commitPattern accepts full and abbreviated commit hashes
+	commitPattern = regexp.MustCompile("^[0-9a-fA-F]{4,40}$")
+)
+
+const (
+	commitsLimit     = 20
+	defaultHttpsPort = 443
+)
+
+type GitRefService struct {
+	Clients              k8s.ClientSet
+	ICodebaseRepository  repository.ICodebaseRepository
+	IGitServerRepository repository.IGitServerRepository
+	EDPComponent         ec.EDPComponentService
+	Credentials          util.GitCredentials
+}
+
+func (s GitRefService) GetCodebaseRefs(name string) (*dto.GitRefs, error) {
+	c, err := s.getCodebase(name)
+	if err != nil {
+		return nil, err
+	}
+	url, err := s.GetRepositoryUrl(*c)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := util.ListGitRefs(url, s.Credentials)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't list refs of codebase %v", name)
+	}
+	return refs, nil
+}
+
+func (s GitRefService) GetCodebaseCommits(name, branch string) ([]dto.GitCommit, error) {
+	c, err := s.getCodebase(name)
+	if err != nil {
+		return nil, err
+	}
+	url, err := s.GetRepositoryUrl(*c)
+	if err != nil {
+		return nil, err
+	}
+	if branch == "" {
+		branch = c.DefaultBranch
+	}
+	commits, err := util.GetGitCommits(url, branch, s.Credentials, commitsLimit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get commits of codebase %v branch %v", name, branch)
+	}
+	return commits, nil
+}
+
+func (s GitRefService) GetImportRefs(gitServer, gitUrlPath string) (*dto.GitRefs, error) {
+	url, err := s.getImportUrl(gitServer, gitUrlPath)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := util.ListGitRefs(url, s.Credentials)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't list refs of %v", url)
+	}
+	return refs, nil
+}
+
+// ValidateCommit fails if the commit is absent in the codebase repository or it couldn't be found in time.
+// Repository which can't be read by the console is left to be checked by the operator.
+func (s GitRefService) ValidateCommit(codebase, commit string) error {
+	if !commitPattern.MatchString(commit) {
+		return edperror.NewNonValidCommitError(codebase, commit)
+	}
+	c, err := s.getCodebase(codebase)
+	if err != nil {
+		return err
+	}
+	url, err := s.GetRepositoryUrl(*c)
+	if err != nil {
+		return err
+	}
+
+	refs, err := util.ListGitRefs(url, s.Credentials)
+	if err != nil {
+		return checkCommitError(codebase, commit, err)
+	}
+	if containsHash(refs.Branches, commit) {
+		return nil
+	}
+
+	exists, err := util.HasGitCommit(url, commit, s.Credentials)
+	if err != nil {
+		return checkCommitError(codebase, commit, err)
+	}
+	if !exists {
+		return edperror.NewNonValidCommitError(codebase, commit)
+	}
+	return nil
+}
+
+func checkCommitError(codebase, commit string, err error) error {
+	if util.IsGitTimeout(err) || err == util.ErrGitHistoryTruncated {
+		return edperror.NewUnverifiedCommitError(codebase, commit, err.Error())
+	}
+	log.Warn("couldn't read repository to validate commit", zap.String("codebase", codebase), zap.Error(err))
+	return nil
+}
+
+func (s GitRefService) GetRepositoryUrl(c query.Codebase) (string, error) {
+	if c.Strategy == consts.ImportStrategy {
+		if c.GitServer == nil || c.GitProjectPath == nil {
+			return "", fmt.Errorf("git server or project path of imported codebase %v is not set", c.Name)
+		}
+		return s.getImportUrl(*c.GitServer, *c.GitProjectPath)
+	}
+
+	cg, err := s.EDPComponent.GetEDPComponent(consts.Gerrit)
+	if err != nil {
+		return "", err
+	}
+	if cg == nil {
+		return "", fmt.Errorf("edp-component %v is absent in DB", consts.Gerrit)
+	}
+	return fmt.Sprintf("%v/%v", cg.Url, c.Name), nil
+}
+
+func (s GitRefService) getImportUrl(gitServer, gitUrlPath string) (string, error) {
+	g, err := s.IGitServerRepository.GetGitServerByName(gitServer)
+	if err != nil {
+		return "", err
+	}
+	if g == nil {
+		return "", fmt.Errorf("git server %v doesn't exist", gitServer)
+	}
+
+	port, err := s.getHttpsPort(gitServer)
+	if err != nil {
+		return "", err
+	}
+	if port == defaultHttpsPort {
+		return fmt.Sprintf("https://%v%v", g.Hostname, gitUrlPath), nil
+	}
+	return fmt.Sprintf("https://%v:%v%v", g.Hostname, port, gitUrlPath), nil
+}
+
+// getHttpsPort reads HTTPS port from GitServer CR as it isn't stored in DB
+func (s GitRefService) getHttpsPort(gitServer string) (int32, error) {
+	r := &edpv1alpha1.GitServer{}
+	err := s.Clients.EDPRestClient.Get().
+		Namespace(context.Namespace).
+		Resource(consts.GitServerPlural).
+		Name(gitServer).
+		Do().
+		Into(r)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return defaultHttpsPort, nil
+		}
+		return 0, errors.Wrapf(err, "couldn't get git server %v from cluster", gitServer)
+	}
+	if r.Spec.HttpsPort == 0 {
+		return defaultHttpsPort, nil
+	}
+	return r.Spec.HttpsPort, nil
+}
+
+func (s GitRefService) getCodebase(name string) (*query.Codebase, error) {
+	c, err := s.ICodebaseRepository.GetCodebaseByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get codebase %v", name)
+	}
+	if c == nil {
+		return nil, edperror.NewCodebaseDoesNotExistError(name)
+	}
+	return c, nil
+}
+
+// containsHash matches abbreviated hash too
+func containsHash(refs []dto.GitRef, hash string) bool {
+	prefix := strings.ToLower(hash)
+	for _, r := range refs {
+		if strings.HasPrefix(r.Hash, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package gitref
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateCommitMethod_ShouldFailOnAbsentCodebase(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	s := GitRefService{ICodebaseRepository: mCodebase}
+
+	mCodebase.On("GetCodebaseByName", "stub-name").Return(nil, nil)
+
+	err := s.ValidateCommit("stub-name", "e63ac4de2ef7038ab33788d71c0e271877ce0874")
+	assert.IsType(t, &edperror.CodebaseDoesNotExistError{}, err)
+}
+
+func TestValidateCommitMethod_ShouldSkipUnreadableRepository(t *testing.T) {
+	context.Namespace = "stub-namespace"
+	k8sStub := httptest.NewServer(http.NotFoundHandler())
+	defer k8sStub.Close()
+	client, err := k8s.CreateEDPRestClient(k8sStub.URL)
+	assert.NoError(t, err)
+
+	mCodebase := new(mock.MockCodebase)
+	mGitServer := new(mock.MockGitServer)
+	s := GitRefService{
+		Clients:              k8s.ClientSet{EDPRestClient: client},
+		ICodebaseRepository:  mCodebase,
+		IGitServerRepository: mGitServer,
+	}
+
+	gs, path := "stub-server", "/stub-name"
+	mCodebase.On("GetCodebaseByName", "stub-name").Return(query.Codebase{
+		Name:           "stub-name",
+		Strategy:       "import",
+		GitServer:      &gs,
+		GitProjectPath: &path,
+	}, nil)
+	mGitServer.On("GetGitServerByName", gs).Return(query.GitServer{Name: gs, Hostname: "127.0.0.1:1"}, nil)
+
+	assert.NoError(t, s.ValidateCommit("stub-name", "e63ac4de2ef7038ab33788d71c0e271877ce0874"))
+}
+
+func TestGetRepositoryUrlMethod_ShouldFailOnImportWithoutProjectPath(t *testing.T) {
+	gs := "stub-server"
+	url, err := GitRefService{}.GetRepositoryUrl(query.Codebase{
+		Name:      "stub-name",
+		Strategy:  "import",
+		GitServer: &gs,
+	})
+	assert.Error(t, err)
+	assert.Empty(t, url)
+}
+
+func TestGetImportRefsMethod_ShouldFailOnAbsentGitServer(t *testing.T) {
+	mGitServer := new(mock.MockGitServer)
+	s := GitRefService{IGitServerRepository: mGitServer}
+
+	mGitServer.On("GetGitServerByName", "stub-server").Return(nil, nil)
+
+	refs, err := s.GetImportRefs("stub-server", "/stub-name")
+	assert.Error(t, err)
+	assert.Nil(t, refs)
+}
+
+func TestValidateCommitMethod_ShouldRejectMalformedCommit(t *testing.T) {
+	s := GitRefService{}
+
+	err := s.ValidateCommit("stub-name", "master~1")
+	assert.IsType(t, &edperror.NonValidCommitError{}, err)
+}
+
+func TestContainsHashMethod_ShouldMatchAbbreviatedHash(t *testing.T) {
+	refs := []dto.GitRef{{Name: "master", Hash: "e63ac4de2ef7038ab33788d71c0e271877ce0874"}}
+
+	assert.True(t, containsHash(refs, "E63AC4D"))
+	assert.True(t, containsHash(refs, "e63ac4de2ef7038ab33788d71c0e271877ce0874"))
+	assert.False(t, containsHash(refs, "e63ac4e"))
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"edp-admin-console/models/dto"
+	"fmt"
+	"strings"
+)
+
+const (
+	JsonFormat    = "json"
+	DotFormat     = "dot"
+	MermaidFormat = "mermaid"
+)
+
+var dotShapes = map[string]string{
+	dto.CodebaseNode:      "box",
+	dto.BranchNode:        "ellipse",
+	dto.DockerStreamNode:  "cylinder",
+	dto.PipelineNode:      "box3d",
+	dto.StageNode:         "component",
+	dto.AutotestGateNode:  "diamond",
+	dto.LibrarySourceNode: "note",
+}
+
+var mermaidShapes = map[string]string{
+	dto.CodebaseNode:      "[%v]",
+	dto.BranchNode:        "(%v)",
+	dto.DockerStreamNode:  "[(%v)]",
+	dto.PipelineNode:      "[[%v]]",
+	dto.StageNode:         "[/%v/]",
+	dto.AutotestGateNode:  "{%v}",
+	dto.LibrarySourceNode: ">%v]",
+}
+
+var (
+	dotEscaper     = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	mermaidEscaper = strings.NewReplacer(`"`, "#quot;")
+)
+
+//ToDot renders the graph in Graphviz DOT language
+func ToDot(g *dto.Graph) string {
+	var sb strings.Builder
+	sb.WriteString("digraph edp {\n\trankdir=LR;\n")
+	for _, n := range g.Nodes {
+		sb.WriteString(fmt.Sprintf("\t\"%v\" [label=\"%v\", shape=%v];\n",
+			dotEscaper.Replace(n.Id), dotEscaper.Replace(n.Name), dotShapes[n.Type]))
+	}
+	for _, e := range g.Edges {
+		sb.WriteString(fmt.Sprintf("\t\"%v\" -> \"%v\";\n", dotEscaper.Replace(e.Source), dotEscaper.Replace(e.Target)))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+//ToMermaid renders the graph as Mermaid flowchart. Node ids are replaced with short ones
+//as Mermaid doesn't accept slashes in them
+func ToMermaid(g *dto.Graph) string {
+	ids := map[string]string{}
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	for i, n := range g.Nodes {
+		ids[n.Id] = fmt.Sprintf("n%v", i)
+		label := fmt.Sprintf(`"%v"`, mermaidEscaper.Replace(n.Name))
+		sb.WriteString(fmt.Sprintf("\t%v%v\n", ids[n.Id], fmt.Sprintf(mermaidShapes[n.Type], label)))
+	}
+	for _, e := range g.Edges {
+		sb.WriteString(fmt.Sprintf("\t%v --> %v\n", ids[e.Source], ids[e.Target]))
+	}
+	return sb.String()
+}
@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository"
+	"edp-admin-console/service/logger"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var log = logger.GetLogger()
+
+const autotestGateType = "autotests"
+
+type GraphService struct {
+	ICodebaseRepository   repository.ICodebaseRepository
+	ICDPipelineRepository repository.ICDPipelineRepository
+}
+
+type graphBuilder struct {
+	graph *dto.Graph
+	nodes map[string]bool
+	edges map[dto.GraphEdge]bool
+}
+
+//GetGraph returns typed nodes and edges of codebases and CD pipelines limited by the scope
+func (s GraphService) GetGraph(scope dto.GraphScope) (*dto.Graph, error) {
+	log.Debug("start building graph", zap.Any("scope", scope))
+	codebases, err := s.ICodebaseRepository.GetCodebasesByCriteria(query.CodebaseCriteria{})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get codebases")
+	}
+
+	pipelines, err := s.ICDPipelineRepository.GetCDPipelines(query.CDPipelineCriteria{})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get cd pipelines")
+	}
+
+	g := buildGraph(codebases, pipelines)
+	switch {
+	case scope.Pipeline != "":
+		return scopeGraph(g, pipelineId(scope.Pipeline), false)
+	case scope.Codebase != "":
+		return scopeGraph(g, codebaseId(scope.Codebase), true)
+	}
+	return g, nil
+}
+
+func buildGraph(codebases []*query.Codebase, pipelines []*query.CDPipeline) *dto.Graph {
+	b := graphBuilder{
+		graph: &dto.Graph{Nodes: []dto.GraphNode{}, Edges: []dto.GraphEdge{}},
+		nodes: map[string]bool{},
+		edges: map[dto.GraphEdge]bool{},
+	}
+
+	branches := map[int]*query.CodebaseBranch{}
+	owners := map[int]*query.Codebase{}
+	for _, c := range codebases {
+		cid := b.addNode(codebaseId(c.Name), dto.CodebaseNode, c.Name)
+		for _, br := range c.CodebaseBranch {
+			branches[br.Id] = br
+			owners[br.Id] = c
+			bid := b.addNode(branchId(c.Name, br.Name), dto.BranchNode, br.Name)
+			b.addEdge(cid, bid)
+			for _, ds := range br.CodebaseDockerStream {
+				b.addEdge(bid, b.addNode(streamId(ds.OcImageStreamName), dto.DockerStreamNode, ds.OcImageStreamName))
+			}
+		}
+	}
+
+	for _, p := range pipelines {
+		pid := b.addNode(pipelineId(p.Name), dto.PipelineNode, p.Name)
+		for _, ds := range p.CodebaseDockerStream {
+			b.addEdge(b.addNode(streamId(ds.OcImageStreamName), dto.DockerStreamNode, ds.OcImageStreamName), pid)
+		}
+
+		for _, st := range p.Stage {
+			sid := b.addNode(stageId(p.Name, st.Name), dto.StageNode, st.Name)
+			b.addEdge(pid, sid)
+
+			for _, ds := range st.StageCodebaseDockerStream {
+				if ds.InputCodebaseDockerStreamId != "" {
+					b.addEdge(b.addNode(streamId(ds.InputCodebaseDockerStreamId), dto.DockerStreamNode,
+						ds.InputCodebaseDockerStreamId), sid)
+				}
+				if ds.OutputCodebaseDockerStreamId != "" {
+					b.addEdge(sid, b.addNode(streamId(ds.OutputCodebaseDockerStreamId), dto.DockerStreamNode,
+						ds.OutputCodebaseDockerStreamId))
+				}
+			}
+
+			for _, qg := range st.QualityGates {
+				if qg.QualityGateType != autotestGateType {
+					continue
+				}
+				gid := b.addNode(fmt.Sprintf("%v/%v", dto.AutotestGateNode, qg.Id), dto.AutotestGateNode, qg.StepName)
+				b.addEdge(sid, gid)
+				if qg.CodebaseBranchId != nil && branches[*qg.CodebaseBranchId] != nil {
+					br := branches[*qg.CodebaseBranchId]
+					b.addEdge(branchId(owners[br.Id].Name, br.Name), gid)
+				}
+			}
+
+			if st.SourceCodebaseBranchId != nil && branches[*st.SourceCodebaseBranchId] != nil {
+				br := branches[*st.SourceCodebaseBranchId]
+				lib := owners[br.Id].Name
+				lid := b.addNode(fmt.Sprintf("%v/%v/%v", dto.LibrarySourceNode, lib, br.Name),
+					dto.LibrarySourceNode, fmt.Sprintf("%v/%v", lib, br.Name))
+				b.addEdge(branchId(lib, br.Name), lid)
+				b.addEdge(lid, sid)
+			}
+		}
+	}
+	return b.graph
+}
+
+func (b *graphBuilder) addNode(id, nodeType, name string) string {
+	if !b.nodes[id] {
+		b.nodes[id] = true
+		b.graph.Nodes = append(b.graph.Nodes, dto.GraphNode{Id: id, Type: nodeType, Name: name})
+	}
+	return id
+}
+
+func (b *graphBuilder) addEdge(source, target string) {
+	e := dto.GraphEdge{Source: source, Target: target}
+	if !b.edges[e] {
+		b.edges[e] = true
+		b.graph.Edges = append(b.graph.Edges, e)
+	}
+}
+
+//scopeGraph keeps nodes reachable from the root. Pipeline scope adds everything what feeds reachable nodes,
+//codebase scope adds only stages and pipelines which own reachable nodes
+func scopeGraph(g *dto.Graph, root string, ownersOnly bool) (*dto.Graph, error) {
+	types := map[string]string{}
+	for _, n := range g.Nodes {
+		types[n.Id] = n.Type
+	}
+	if _, ok := types[root]; !ok {
+		return nil, edperror.NewGraphScopeNotFoundError(root)
+	}
+
+	out := map[string][]string{}
+	in := map[string][]string{}
+	for _, e := range g.Edges {
+		out[e.Source] = append(out[e.Source], e.Target)
+		in[e.Target] = append(in[e.Target], e.Source)
+	}
+
+	keep := reach([]string{root}, out, func(string) bool { return true })
+	var seeds []string
+	for id := range keep {
+		seeds = append(seeds, id)
+	}
+	for id := range reach(seeds, in, func(id string) bool {
+		return !ownersOnly || types[id] == dto.StageNode || types[id] == dto.PipelineNode
+	}) {
+		keep[id] = true
+	}
+
+	res := &dto.Graph{Nodes: []dto.GraphNode{}, Edges: []dto.GraphEdge{}}
+	for _, n := range g.Nodes {
+		if keep[n.Id] {
+			res.Nodes = append(res.Nodes, n)
+		}
+	}
+	for _, e := range g.Edges {
+		if keep[e.Source] && keep[e.Target] {
+			res.Edges = append(res.Edges, e)
+		}
+	}
+	return res, nil
+}
+
+func reach(seeds []string, adj map[string][]string, allow func(id string) bool) map[string]bool {
+	visited := map[string]bool{}
+	queue := append([]string{}, seeds...)
+	for _, s := range seeds {
+		visited[s] = true
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, n := range adj[current] {
+			if visited[n] || !allow(n) {
+				continue
+			}
+			visited[n] = true
+			queue = append(queue, n)
+		}
+	}
+	return visited
+}
+
+func codebaseId(name string) string {
+	return fmt.Sprintf("%v/%v", dto.CodebaseNode, name)
+}
+
+func branchId(codebase, branch string) string {
+	return fmt.Sprintf("%v/%v/%v", dto.BranchNode, codebase, branch)
+}
+
+func streamId(name string) string {
+	return fmt.Sprintf("%v/%v", dto.DockerStreamNode, name)
+}
+
+func pipelineId(name string) string {
+	return fmt.Sprintf("%v/%v", dto.PipelineNode, name)
+}
+
+func stageId(pipeline, stage string) string {
+	return fmt.Sprintf("%v/%v/%v", dto.StageNode, pipeline, stage)
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func createTestData() ([]*query.Codebase, []*query.CDPipeline) {
+	gateBranch := 3
+	sourceBranch := 4
+	codebases := []*query.Codebase{
+		{Name: "fake-app", Type: query.App, CodebaseBranch: []*query.CodebaseBranch{
+			{Id: 1, Name: "master", CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-app-master"}}},
+		}},
+		{Name: "fake-other-app", Type: query.App, CodebaseBranch: []*query.CodebaseBranch{
+			{Id: 2, Name: "master", CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-other-app-master"}}},
+		}},
+		{Name: "fake-autotest", Type: query.Autotests, CodebaseBranch: []*query.CodebaseBranch{{Id: gateBranch, Name: "master"}}},
+		{Name: "fake-lib", Type: query.Library, CodebaseBranch: []*query.CodebaseBranch{{Id: sourceBranch, Name: "master"}}},
+	}
+	pipelines := []*query.CDPipeline{
+		{
+			Name:                 "fake-pipeline",
+			CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-app-master"}},
+			Stage: []*query.Stage{{
+				Name:                   "sit",
+				SourceCodebaseBranchId: &sourceBranch,
+				StageCodebaseDockerStream: []query.StageCodebaseDockerStream{{
+					InputCodebaseDockerStreamId:  "fake-app-master",
+					OutputCodebaseDockerStreamId: "fake-pipeline-sit-fake-app-verified",
+				}},
+				QualityGates: []query.QualityGate{
+					{Id: 1, QualityGateType: "manual", StepName: "approve"},
+					{Id: 2, QualityGateType: "autotests", StepName: "smoke", CodebaseBranchId: &gateBranch},
+				},
+			}},
+		},
+		{
+			Name:                 "fake-other-pipeline",
+			CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-other-app-master"}},
+		},
+	}
+	return codebases, pipelines
+}
+
+func TestBuildGraphMethod_ShouldCreateTypedNodes(t *testing.T) {
+	g := buildGraph(createTestData())
+
+	types := map[string]string{}
+	for _, n := range g.Nodes {
+		types[n.Id] = n.Type
+	}
+	assert.Equal(t, dto.CodebaseNode, types["codebase/fake-app"])
+	assert.Equal(t, dto.BranchNode, types["branch/fake-app/master"])
+	assert.Equal(t, dto.DockerStreamNode, types["docker_stream/fake-pipeline-sit-fake-app-verified"])
+	assert.Equal(t, dto.StageNode, types["stage/fake-pipeline/sit"])
+	assert.Equal(t, dto.AutotestGateNode, types["autotest_gate/2"])
+	assert.Equal(t, dto.LibrarySourceNode, types["library_source/fake-lib/master"])
+	assert.NotContains(t, types, "autotest_gate/1")
+	assert.Contains(t, g.Edges, dto.GraphEdge{Source: "branch/fake-autotest/master", Target: "autotest_gate/2"})
+}
+
+func TestScopeGraphMethod_ShouldKeepOnlyPipelineRelatedNodes(t *testing.T) {
+	g, err := scopeGraph(buildGraph(createTestData()), pipelineId("fake-pipeline"), false)
+	assert.NoError(t, err)
+
+	ids := map[string]bool{}
+	for _, n := range g.Nodes {
+		ids[n.Id] = true
+	}
+	assert.True(t, ids["codebase/fake-app"])
+	assert.True(t, ids["codebase/fake-autotest"])
+	assert.True(t, ids["codebase/fake-lib"])
+	assert.False(t, ids["codebase/fake-other-app"])
+	assert.False(t, ids["pipeline/fake-other-pipeline"])
+}
+
+func TestScopeGraphMethod_ShouldReturnNotFoundError(t *testing.T) {
+	_, err := scopeGraph(buildGraph(createTestData()), codebaseId("fake-absent"), true)
+	assert.IsType(t, &edperror.GraphScopeNotFoundError{}, err)
+}
+
+func TestExportMethods_ShouldRenderAllNodesAndEdges(t *testing.T) {
+	g, err := scopeGraph(buildGraph(createTestData()), codebaseId("fake-other-app"), true)
+	assert.NoError(t, err)
+
+	dot := ToDot(g)
+	assert.True(t, strings.HasPrefix(dot, "digraph edp {"))
+	assert.Contains(t, dot, `"codebase/fake-other-app" [label="fake-other-app", shape=box];`)
+	assert.Contains(t, dot, `"docker_stream/fake-other-app-master" -> "pipeline/fake-other-pipeline";`)
+
+	mermaid := ToMermaid(g)
+	assert.True(t, strings.HasPrefix(mermaid, "graph LR\n"))
+	assert.Contains(t, mermaid, `n0["fake-other-app"]`)
+	assert.Equal(t, len(g.Nodes)+len(g.Edges)+1, strings.Count(mermaid, "\n"))
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/util/consts"
+	"encoding/json"
+	"github.com/astaxie/beego"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+type jenkinsSpec struct {
+	Slaves        []jenkinsSlave `json:"slaves"`
+	JobProvisions []jobProvision `json:"jobProvisions"`
+}
+
+type jenkinsSlave struct {
+	Name string `json:"name"`
+}
+
+type jobProvision struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope"`
+}
+
+//updateJenkinsSpec applies change to the lists of slaves and job provisioners declared in spec of Jenkins custom resource,
+//Jenkins operator reflects them in status and synchronizes to DB. The patch carries resourceVersion of the read object,
+//so a concurrent change of the resource makes it fail with conflict and the change is applied again
+func updateJenkinsSpec(clients k8s.ClientSet, change func(spec *jenkinsSpec)) error {
+	name := beego.AppConfig.String("jenkinsName")
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		raw, err := clients.EDPRestClient.Get().
+			Namespace(context.Namespace).
+			Resource(consts.JenkinsPlural).
+			Name(name).
+			Do().
+			Raw()
+		if err != nil {
+			return errors.Wrapf(err, "couldn't get jenkins %v", name)
+		}
+
+		var jenkins struct {
+			Metadata struct {
+				ResourceVersion string `json:"resourceVersion"`
+			} `json:"metadata"`
+			Spec jenkinsSpec `json:"spec"`
+		}
+		if err := json.Unmarshal(raw, &jenkins); err != nil {
+			return errors.Wrapf(err, "couldn't parse jenkins %v", name)
+		}
+		change(&jenkins.Spec)
+
+		body, err := json.Marshal(jenkins)
+		if err != nil {
+			return err
+		}
+		return clients.EDPRestClient.Patch(types.MergePatchType).
+			Namespace(context.Namespace).
+			Resource(consts.JenkinsPlural).
+			Name(name).
+			Body(body).
+			Do().
+			Error()
+	})
+	return errors.Wrapf(err, "couldn't update spec of jenkins %v", name)
+}
+
+func (s *jenkinsSpec) addSlave(name string) {
+	for _, sl := range s.Slaves {
+		if sl.Name == name {
+			return
+		}
+	}
+	s.Slaves = append(s.Slaves, jenkinsSlave{Name: name})
+}
+
+func (s *jenkinsSpec) removeSlave(name string) {
+	res := make([]jenkinsSlave, 0, len(s.Slaves))
+	for _, sl := range s.Slaves {
+		if sl.Name != name {
+			res = append(res, sl)
+		}
+	}
+	s.Slaves = res
+}
+
+func (s *jenkinsSpec) addJobProvision(name, scope string) {
+	for _, p := range s.JobProvisions {
+		if p.Name == name && p.Scope == scope {
+			return
+		}
+	}
+	s.JobProvisions = append(s.JobProvisions, jobProvision{Name: name, Scope: scope})
+}
+
+func (s *jenkinsSpec) removeJobProvision(name, scope string) {
+	res := make([]jobProvision, 0, len(s.JobProvisions))
+	for _, p := range s.JobProvisions {
+		if p.Name != name || p.Scope != scope {
+			res = append(res, p)
+		}
+	}
+	s.JobProvisions = res
+}
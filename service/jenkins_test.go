@@ -0,0 +1,85 @@
+package service
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/repository/mock"
+	dberror "edp-admin-console/util/error/db-errors"
+	"fmt"
+	"github.com/astaxie/beego"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJenkinsSpecMethods_ShouldKeepListsUnique(t *testing.T) {
+	sp := jenkinsSpec{
+		Slaves:        []jenkinsSlave{{Name: "maven"}},
+		JobProvisions: []jobProvision{{Name: "default", Scope: "ci"}},
+	}
+
+	sp.addSlave("maven")
+	sp.addSlave("gradle")
+	sp.removeSlave("maven")
+	sp.addJobProvision("default", "ci")
+	sp.addJobProvision("default", "cd")
+	sp.removeJobProvision("default", "ci")
+
+	assert.Equal(t, []jenkinsSlave{{Name: "gradle"}}, sp.Slaves)
+	assert.Equal(t, []jobProvision{{Name: "default", Scope: "cd"}}, sp.JobProvisions)
+}
+
+func TestUpdateJenkinsSpecMethod_ShouldRetryOnConflict(t *testing.T) {
+	context.Namespace = "stub-namespace"
+	beego.AppConfig.Set("jenkinsName", "jenkins")
+	version, patches := 1, []string{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/apis/v2.edp.epam.com/v1alpha1/namespaces/stub-namespace/jenkins/jenkins", r.URL.Path)
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprintf(w, `{"metadata":{"resourceVersion":"%v"},"spec":{"slaves":[{"name":"maven"}]}}`, version)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		patches = append(patches, string(body))
+		if len(patches) == 1 {
+			version++
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Conflict","code":409}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+	client, err := k8s.CreateEDPRestClient(s.URL)
+	assert.NoError(t, err)
+
+	err = updateJenkinsSpec(k8s.ClientSet{EDPRestClient: client}, func(sp *jenkinsSpec) { sp.addSlave("gradle") })
+	assert.NoError(t, err)
+	assert.Len(t, patches, 2)
+	assert.JSONEq(t, `{"metadata":{"resourceVersion":"2"},"spec":{"slaves":[{"name":"maven"},{"name":"gradle"}],"jobProvisions":null}}`, patches[1])
+}
+
+func TestDeleteSlaveMethod_ShouldRefuseUsedSlave(t *testing.T) {
+	mSlave := new(mock.MockSlave)
+	s := SlaveService{ISlaveRepository: mSlave}
+
+	mSlave.On("SelectCodebasesUsingSlave", "maven").Return([]string{"stub-service"}, nil)
+
+	err := s.DeleteSlave("maven")
+	assert.True(t, dberror.JenkinsResourceIsUsed(err))
+	assert.Equal(t, []string{"stub-service"}, err.(dberror.JenkinsResourceIsUsedByCodebase).Codebases)
+}
+
+func TestDeleteJobProvisionerMethod_ShouldRefuseProvisionerUsedByStage(t *testing.T) {
+	mProvision := new(mock.MockJobProvision)
+	s := JobProvisioning{IJobProvisioningRepository: mProvision}
+
+	mProvision.On("SelectCodebasesUsingJobProvisioner", "default", "cd").Return([]string{}, nil)
+	mProvision.On("SelectStagesUsingJobProvisioner", "default", "cd").Return([]string{"stub-pipeline/sit"}, nil)
+
+	err := s.DeleteJobProvisioner("default", "cd")
+	assert.True(t, dberror.JenkinsResourceIsUsed(err))
+	assert.Equal(t, []string{"stub-pipeline/sit"}, err.(dberror.JenkinsResourceIsUsedByCodebase).Stages)
+}
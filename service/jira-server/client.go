@@ -0,0 +1,141 @@
+package jira_server
+
+import (
+	"edp-admin-console/models/dto"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	jiraRequestTimeout = 15 * time.Second
+	issueKeysLimit     = 50
+)
+
+//jqlEscaper escapes a value to be placed inside a double-quoted JQL string
+var jqlEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+var requiredPermissions = []string{"BROWSE_PROJECTS", "EDIT_ISSUES"}
+
+type jiraClient struct {
+	url      string
+	user     string
+	password string
+	client   http.Client
+}
+
+func newJiraClient(apiUrl, user, password string) *jiraClient {
+	return &jiraClient{
+		url:      strings.TrimSuffix(apiUrl, "/"),
+		user:     user,
+		password: password,
+		client:   http.Client{Timeout: jiraRequestTimeout},
+	}
+}
+
+func (c *jiraClient) get(path string, res interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.user, c.password)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("jira responded with %v", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
+
+func (c *jiraClient) checkConnection() dto.JiraConnectionTest {
+	var user struct {
+		DisplayName string `json:"displayName"`
+	}
+	if err := c.get("/rest/api/2/myself", &user); err != nil {
+		return dto.JiraConnectionTest{
+			Connection:  dto.ConnectionCheck{Message: err.Error()},
+			Permissions: dto.ConnectionCheck{Message: "skipped as connection is failed"},
+		}
+	}
+	res := dto.JiraConnectionTest{
+		Connection: dto.ConnectionCheck{Success: true, Message: fmt.Sprintf("authenticated as %v", user.DisplayName)},
+	}
+
+	var p struct {
+		Permissions map[string]struct {
+			HavePermission bool `json:"havePermission"`
+		} `json:"permissions"`
+	}
+	path := "/rest/api/2/mypermissions?permissions=" + strings.Join(requiredPermissions, ",")
+	if err := c.get(path, &p); err != nil {
+		res.Permissions = dto.ConnectionCheck{Message: err.Error()}
+		return res
+	}
+
+	var missed []string
+	for _, n := range requiredPermissions {
+		if !p.Permissions[n].HavePermission {
+			missed = append(missed, n)
+		}
+	}
+	if len(missed) > 0 {
+		res.Permissions = dto.ConnectionCheck{Message: fmt.Sprintf("missed permissions: %v", strings.Join(missed, ", "))}
+		return res
+	}
+	res.Permissions = dto.ConnectionCheck{Success: true, Message: "all required permissions are granted"}
+	return res
+}
+
+func (c *jiraClient) getIssueKeys(project string) ([]string, error) {
+	var r struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	q := url.Values{}
+	q.Set("jql", fmt.Sprintf("project = \"%v\" order by created desc", jqlEscaper.Replace(project)))
+	q.Set("fields", "key")
+	q.Set("maxResults", fmt.Sprint(issueKeysLimit))
+	if err := c.get("/rest/api/2/search?"+q.Encode(), &r); err != nil {
+		return nil, errors.Wrapf(err, "couldn't get issues of %v project", project)
+	}
+
+	keys := make([]string, 0, len(r.Issues))
+	for _, i := range r.Issues {
+		keys = append(keys, i.Key)
+	}
+	return keys, nil
+}
+
+func matchTicketPattern(project, pattern string, keys []string) (*dto.TicketPatternCheck, error) {
+	re, err := regexp.Compile(fmt.Sprintf("^(?:%v)$", pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.TicketPatternCheck{
+		Project:    project,
+		Pattern:    pattern,
+		Matched:    []string{},
+		NotMatched: []string{},
+	}
+	for _, k := range keys {
+		if re.MatchString(k) {
+			res.Matched = append(res.Matched, k)
+		} else {
+			res.NotMatched = append(res.NotMatched, k)
+		}
+	}
+	return res, nil
+}
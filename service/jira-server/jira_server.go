@@ -1,16 +1,36 @@
 package jira_server
 
 import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
+	"edp-admin-console/repository"
 	jiraserver "edp-admin-console/repository/jira-server"
 	"edp-admin-console/service/logger"
+	"edp-admin-console/util"
+	"edp-admin-console/util/consts"
+	"fmt"
+	edpv1alpha1 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var log = logger.GetLogger()
 
+const (
+	usernameField = "username"
+	passwordField = "password"
+)
+
 type JiraServer struct {
-	IJiraServer jiraserver.IJiraServer
+	Clients             k8s.ClientSet
+	IJiraServer         jiraserver.IJiraServer
+	ICodebaseRepository repository.ICodebaseRepository
 }
 
 //GetJiraServers gets all Jira Servers from DB
@@ -23,3 +43,251 @@ func (s JiraServer) GetJiraServers() ([]*query.JiraServer, error) {
 	log.Info("Jira servers have been retrieved", zap.Any("servers", servers))
 	return servers, nil
 }
+
+//GetJiraServer gets Jira Server settings from the custom resource and its availability from DB
+func (s JiraServer) GetJiraServer(name string) (*dto.JiraServer, error) {
+	cr, err := s.getJiraServerCR(name)
+	if err != nil {
+		return nil, err
+	}
+	if cr == nil {
+		return nil, edperror.NewJiraServerDoesNotExistError(name)
+	}
+
+	res := &dto.JiraServer{
+		Name:           cr.Name,
+		ApiUrl:         cr.Spec.ApiUrl,
+		RootUrl:        cr.Spec.RootUrl,
+		CredentialName: cr.Spec.CredentialName,
+	}
+	js, err := s.IJiraServer.GetJiraServer(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get jira server %v", name)
+	}
+	if js != nil {
+		res.Available = js.Available
+	}
+	return res, nil
+}
+
+//CreateJiraServer creates secret with credentials and Jira Server custom resource which is handled by operator
+func (s JiraServer) CreateJiraServer(cmd command.JiraServerCommand) error {
+	log.Debug("start creating jira server", zap.String("name", cmd.Name))
+	cr, err := s.getJiraServerCR(cmd.Name)
+	if err != nil {
+		return err
+	}
+	js, err := s.IJiraServer.GetJiraServer(cmd.Name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get jira server %v", cmd.Name)
+	}
+	if cr != nil || js != nil {
+		return edperror.NewJiraServerExistsError(cmd.Name)
+	}
+	if cmd.Password == "" {
+		return edperror.NewNonValidJiraServerError("password should be specified")
+	}
+
+	secretName := fmt.Sprintf("%v-jira-credentials", cmd.Name)
+	if err := s.saveCredentials(secretName, cmd.User, cmd.Password); err != nil {
+		return err
+	}
+
+	jira := &edpv1alpha1.JiraServer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v2.edp.epam.com/v1alpha1",
+			Kind:       consts.JiraServerKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.Name,
+			Namespace: context.Namespace,
+		},
+		Spec: edpv1alpha1.JiraServerSpec{
+			ApiUrl:         cmd.ApiUrl,
+			RootUrl:        cmd.RootUrl,
+			CredentialName: secretName,
+		},
+	}
+	err = s.Clients.EDPRestClient.Post().
+		Namespace(context.Namespace).
+		Resource(consts.JiraServerPlural).
+		Body(jira).
+		Do().
+		Into(&edpv1alpha1.JiraServer{})
+	if err != nil {
+		if derr := s.Clients.CoreClient.Secrets(context.Namespace).Delete(secretName, &metav1.DeleteOptions{}); derr != nil {
+			log.Error("couldn't delete credentials secret of jira server", zap.String("secret", secretName), zap.Error(derr))
+		}
+		return errors.Wrapf(err, "couldn't create jira server %v", cmd.Name)
+	}
+	log.Info("jira server has been created", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+//UpdateJiraServer changes urls and credentials of Jira Server. Password is kept if the new one isn't passed
+func (s JiraServer) UpdateJiraServer(cmd command.JiraServerCommand) error {
+	log.Debug("start updating jira server", zap.String("name", cmd.Name))
+	cr, err := s.getJiraServerCR(cmd.Name)
+	if err != nil {
+		return err
+	}
+	if cr == nil {
+		return edperror.NewJiraServerDoesNotExistError(cmd.Name)
+	}
+
+	if cr.Spec.CredentialName == "" {
+		cr.Spec.CredentialName = fmt.Sprintf("%v-jira-credentials", cmd.Name)
+	}
+	if err := s.saveCredentials(cr.Spec.CredentialName, cmd.User, cmd.Password); err != nil {
+		return err
+	}
+
+	cr.Spec.ApiUrl = cmd.ApiUrl
+	cr.Spec.RootUrl = cmd.RootUrl
+	err = s.Clients.EDPRestClient.Put().
+		Namespace(context.Namespace).
+		Resource(consts.JiraServerPlural).
+		Name(cmd.Name).
+		Body(cr).
+		Do().
+		Into(&edpv1alpha1.JiraServer{})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update jira server %v", cmd.Name)
+	}
+	log.Info("jira server has been updated", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+//DeleteJiraServer removes Jira Server which isn't used by any codebase
+func (s JiraServer) DeleteJiraServer(name string) error {
+	log.Debug("start deleting jira server", zap.String("name", name))
+	count, err := s.IJiraServer.SelectCountCodebases(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't count codebases of jira server %v", name)
+	}
+	if count > 0 {
+		return edperror.NewNonValidJiraServerError(
+			fmt.Sprintf("jira server %v is used by %v codebase(s)", name, count))
+	}
+
+	cr, err := s.getJiraServerCR(name)
+	if err != nil {
+		return err
+	}
+	if cr == nil {
+		return edperror.NewJiraServerDoesNotExistError(name)
+	}
+
+	err = s.Clients.EDPRestClient.Delete().
+		Namespace(context.Namespace).
+		Resource(consts.JiraServerPlural).
+		Name(name).
+		Do().
+		Error()
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrapf(err, "couldn't delete jira server %v", name)
+	}
+	if cr.Spec.CredentialName != "" {
+		err := s.Clients.CoreClient.Secrets(context.Namespace).Delete(cr.Spec.CredentialName, &metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "couldn't delete secret %v", cr.Spec.CredentialName)
+		}
+	}
+
+	log.Info("jira server has been deleted", zap.String("name", name))
+	return nil
+}
+
+//TestConnection checks that Jira REST API accepts the credentials and the user has permissions required by EDP
+func (s JiraServer) TestConnection(name string) (*dto.JiraConnectionTest, error) {
+	c, err := s.createJiraClient(name)
+	if err != nil {
+		return nil, err
+	}
+	res := c.checkConnection()
+	log.Info("jira server connection has been tested", zap.String("name", name), zap.Any("result", res))
+	return &res, nil
+}
+
+//CheckTicketPattern matches issue keys of Jira project against ticket name pattern of codebase.
+//The pattern of codebase is used if the custom one isn't passed
+func (s JiraServer) CheckTicketPattern(codebaseName, project, pattern string) (*dto.TicketPatternCheck, error) {
+	if project == "" {
+		return nil, edperror.NewNonValidJiraServerError("project should be specified")
+	}
+	cb, err := s.ICodebaseRepository.GetCodebaseByName(codebaseName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get codebase %v", codebaseName)
+	}
+	if cb == nil {
+		return nil, edperror.NewCodebaseDoesNotExistError(codebaseName)
+	}
+	if cb.JiraServer == nil {
+		return nil, edperror.NewNonValidJiraServerError(
+			fmt.Sprintf("codebase %v isn't integrated with jira", codebaseName))
+	}
+	if pattern == "" {
+		pattern = cb.TicketNamePattern
+	}
+
+	c, err := s.createJiraClient(*cb.JiraServer)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := c.getIssueKeys(project)
+	if err != nil {
+		return nil, edperror.NewNonValidJiraServerError(err.Error())
+	}
+
+	res, err := matchTicketPattern(project, pattern, keys)
+	if err != nil {
+		return nil, edperror.NewNonValidJiraServerError(fmt.Sprintf("ticket name pattern is invalid: %v", err))
+	}
+	return res, nil
+}
+
+func (s JiraServer) createJiraClient(name string) (*jiraClient, error) {
+	js, err := s.GetJiraServer(name)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.Clients.CoreClient.Secrets(context.Namespace).Get(js.CredentialName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, edperror.NewNonValidJiraServerError(
+				fmt.Sprintf("credentials secret %v doesn't exist", js.CredentialName))
+		}
+		return nil, errors.Wrapf(err, "couldn't get secret %v", js.CredentialName)
+	}
+	return newJiraClient(js.ApiUrl, string(secret.Data[usernameField]), string(secret.Data[passwordField])), nil
+}
+
+func (s JiraServer) getJiraServerCR(name string) (*edpv1alpha1.JiraServer, error) {
+	r := &edpv1alpha1.JiraServer{}
+	err := s.Clients.EDPRestClient.Get().
+		Namespace(context.Namespace).
+		Resource(consts.JiraServerPlural).
+		Name(name).
+		Do().
+		Into(r)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "couldn't get jira server %v from cluster", name)
+	}
+	return r, nil
+}
+
+func (s JiraServer) saveCredentials(name, user, password string) error {
+	data := map[string]string{
+		usernameField: user,
+		passwordField: password,
+	}
+	return util.SaveSecret(s.Clients.CoreClient, name, data, func() error {
+		if password == "" {
+			return edperror.NewNonValidJiraServerError("password should be specified as credentials secret doesn't exist")
+		}
+		return nil
+	})
+}
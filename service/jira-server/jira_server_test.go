@@ -0,0 +1,142 @@
+package jira_server
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/repository/mock"
+	"github.com/stretchr/testify/assert"
+	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func createJiraStub(permissions string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/myself", func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "stub-user" || p != "stub-password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"displayName":"Stub User"}`))
+	})
+	mux.HandleFunc("/rest/api/2/mypermissions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(permissions))
+	})
+	mux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issues":[{"key":"EDP-12"},{"key":"EDP-7"},{"key":"OLD_3"}]}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestCheckConnectionMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	s := createJiraStub(`{"permissions":{"BROWSE_PROJECTS":{"havePermission":true},"EDIT_ISSUES":{"havePermission":true}}}`)
+	defer s.Close()
+
+	res := newJiraClient(s.URL, "stub-user", "stub-password").checkConnection()
+	assert.True(t, res.Connection.Success)
+	assert.Equal(t, "authenticated as Stub User", res.Connection.Message)
+	assert.True(t, res.Permissions.Success)
+}
+
+func TestCheckConnectionMethod_ShouldReportMissedPermissions(t *testing.T) {
+	s := createJiraStub(`{"permissions":{"BROWSE_PROJECTS":{"havePermission":true},"EDIT_ISSUES":{"havePermission":false}}}`)
+	defer s.Close()
+
+	res := newJiraClient(s.URL, "stub-user", "stub-password").checkConnection()
+	assert.True(t, res.Connection.Success)
+	assert.False(t, res.Permissions.Success)
+	assert.Equal(t, "missed permissions: EDIT_ISSUES", res.Permissions.Message)
+}
+
+func TestCheckConnectionMethod_ShouldReportWrongCredentials(t *testing.T) {
+	s := createJiraStub(`{}`)
+	defer s.Close()
+
+	res := newJiraClient(s.URL, "stub-user", "wrong-password").checkConnection()
+	assert.False(t, res.Connection.Success)
+	assert.Contains(t, res.Connection.Message, "401")
+	assert.False(t, res.Permissions.Success)
+}
+
+func TestMatchTicketPatternMethod_ShouldSplitIssueKeys(t *testing.T) {
+	s := createJiraStub(`{}`)
+	defer s.Close()
+
+	keys, err := newJiraClient(s.URL, "stub-user", "stub-password").getIssueKeys("EDP")
+	assert.NoError(t, err)
+
+	res, err := matchTicketPattern("EDP", "[A-Z]+-\\d+", keys)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"EDP-12", "EDP-7"}, res.Matched)
+	assert.Equal(t, []string{"OLD_3"}, res.NotMatched)
+}
+
+func TestDeleteJiraServerMethod_ShouldRefuseUsedServer(t *testing.T) {
+	mJira := new(mock.MockJiraServer)
+	s := JiraServer{IJiraServer: mJira}
+
+	mJira.On("SelectCountCodebases", "stub-jira").Return(2, nil)
+
+	err := s.DeleteJiraServer("stub-jira")
+	assert.IsType(t, &edperror.NonValidJiraServerError{}, err)
+}
+
+func TestGetIssueKeysMethod_ShouldEscapeProjectKey(t *testing.T) {
+	var jql string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql = r.URL.Query().Get("jql")
+		_, _ = w.Write([]byte(`{"issues":[]}`))
+	}))
+	defer s.Close()
+
+	_, err := newJiraClient(s.URL, "stub-user", "stub-password").getIssueKeys(`EDP" or project = "OTHER\`)
+	assert.NoError(t, err)
+	assert.Equal(t, `project = "EDP\" or project = \"OTHER\\" order by created desc`, jql)
+}
+
+func TestCreateJiraServerMethod_ShouldDeleteCredentialsOnFailedCreation(t *testing.T) {
+	context.Namespace = "stub-namespace"
+	var deleted bool
+	k8sStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/namespaces/stub-namespace/secrets":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"metadata":{"name":"stub-jira-jira-credentials"}}`))
+		case r.Method == http.MethodDelete:
+			deleted = r.URL.Path == "/api/v1/namespaces/stub-namespace/secrets/stub-jira-jira-credentials"
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer k8sStub.Close()
+	client, err := k8s.CreateEDPRestClient(k8sStub.URL)
+	assert.NoError(t, err)
+	core, err := coreV1Client.NewForConfig(&rest.Config{Host: k8sStub.URL})
+	assert.NoError(t, err)
+
+	mJira := new(mock.MockJiraServer)
+	s := JiraServer{
+		Clients:     k8s.ClientSet{CoreClient: core, EDPRestClient: client},
+		IJiraServer: mJira,
+	}
+	mJira.On("GetJiraServer", "stub-jira").Return(nil, nil)
+
+	err = s.CreateJiraServer(command.JiraServerCommand{
+		Name:     "stub-jira",
+		ApiUrl:   "https://stub-jira",
+		RootUrl:  "https://stub-jira",
+		User:     "stub-user",
+		Password: "stub-password",
+	})
+	assert.Error(t, err)
+	assert.True(t, deleted)
+}
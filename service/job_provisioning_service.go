@@ -1,14 +1,35 @@
 package service
 
 import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository"
+	"edp-admin-console/util/consts"
+	dberror "edp-admin-console/util/error/db-errors"
+	"encoding/json"
+	"fmt"
+	"strings"
 
+	"github.com/astaxie/beego"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	jobProvisionerKind = "job provisioner"
+	jenkinsScriptKey   = "context"
 )
 
 type JobProvisioning struct {
+	Clients                    k8s.ClientSet
 	IJobProvisioningRepository repository.IJobProvisioningRepository
 }
 
@@ -22,3 +43,221 @@ func (s JobProvisioning) GetAllJobProvisioners(criteria query.JobProvisioningCri
 	log.Info("Fetched Job Provisioning", zap.Any("job provision", p))
 	return p, nil
 }
+
+//GetJobProvisionersUsage gets all job provisioners with codebases and stages which use them
+func (s JobProvisioning) GetJobProvisionersUsage() ([]dto.JobProvisioner, error) {
+	provisioners, err := s.GetAllJobProvisioners(query.JobProvisioningCriteria{})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]dto.JobProvisioner, 0, len(provisioners))
+	for _, p := range provisioners {
+		codebases, stages, err := s.getUsage(p.Name, p.Scope)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, dto.JobProvisioner{Name: p.Name, Scope: p.Scope, Codebases: codebases, Stages: stages})
+	}
+	return res, nil
+}
+
+//GetJobProvisioner gets script of job provisioner and codebases and stages which use it
+func (s JobProvisioning) GetJobProvisioner(name, scope string) (*dto.JobProvisioner, error) {
+	cm, err := s.getScriptConfigMap(name, scope)
+	if err != nil {
+		return nil, err
+	}
+	if cm == nil {
+		p, err := s.IJobProvisioningRepository.GetJobProvisioner(name, scope)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't get job provisioner %v", name)
+		}
+		if p == nil {
+			return nil, edperror.NewJenkinsResourceDoesNotExistError(jobProvisionerKind, name)
+		}
+	}
+
+	codebases, stages, err := s.getUsage(name, scope)
+	if err != nil {
+		return nil, err
+	}
+	res := &dto.JobProvisioner{
+		Name:      name,
+		Scope:     scope,
+		Codebases: codebases,
+		Stages:    stages,
+	}
+	if cm != nil {
+		res.Script = cm.Data[jenkinsScriptKey]
+	}
+	return res, nil
+}
+
+//CreateJobProvisioner creates Jenkins script which provisions the job and registers it in Jenkins custom resource
+func (s JobProvisioning) CreateJobProvisioner(cmd command.JobProvisionerCommand) error {
+	log.Debug("start creating job provisioner", zap.String("name", cmd.Name), zap.String("scope", cmd.Scope))
+	cm, err := s.getScriptConfigMap(cmd.Name, cmd.Scope)
+	if err != nil {
+		return err
+	}
+	p, err := s.IJobProvisioningRepository.GetJobProvisioner(cmd.Name, cmd.Scope)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get job provisioner %v", cmd.Name)
+	}
+	if cm != nil || p != nil {
+		return edperror.NewJenkinsResourceExistsError(jobProvisionerKind, cmd.Name)
+	}
+
+	name := jenkinsScriptName(cmd.Name, cmd.Scope)
+	_, err = s.Clients.CoreClient.ConfigMaps(context.Namespace).Create(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Data:       map[string]string{jenkinsScriptKey: cmd.Script},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "couldn't create config map %v", name)
+	}
+
+	script, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "v2.edp.epam.com/v1alpha1",
+		"kind":       consts.JenkinsScriptKind,
+		"metadata":   map[string]string{"name": name},
+		"spec": map[string]string{
+			"sourceConfigMapName": name,
+			"ownerName":           beego.AppConfig.String("jenkinsName"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = s.Clients.EDPRestClient.Post().
+		Namespace(context.Namespace).
+		Resource(consts.JenkinsScriptPlural).
+		Body(script).
+		Do().
+		Error()
+	if err != nil {
+		return errors.Wrapf(err, "couldn't create jenkins script %v", name)
+	}
+
+	if err := updateJenkinsSpec(s.Clients, func(sp *jenkinsSpec) { sp.addJobProvision(cmd.Name, cmd.Scope) }); err != nil {
+		return err
+	}
+	log.Info("job provisioner has been created", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+//UpdateJobProvisioner changes script of job provisioner and makes Jenkins operator execute it again
+func (s JobProvisioning) UpdateJobProvisioner(cmd command.JobProvisionerCommand) error {
+	log.Debug("start updating job provisioner", zap.String("name", cmd.Name), zap.String("scope", cmd.Scope))
+	cm, err := s.getScriptConfigMap(cmd.Name, cmd.Scope)
+	if err != nil {
+		return err
+	}
+	if cm == nil {
+		return edperror.NewJenkinsResourceDoesNotExistError(jobProvisionerKind, cmd.Name)
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[jenkinsScriptKey] = cmd.Script
+	if _, err := s.Clients.CoreClient.ConfigMaps(context.Namespace).Update(cm); err != nil {
+		return errors.Wrapf(err, "couldn't update config map %v", cm.Name)
+	}
+
+	err = s.Clients.EDPRestClient.Patch(types.MergePatchType).
+		Namespace(context.Namespace).
+		Resource(consts.JenkinsScriptPlural).
+		Name(cm.Name).
+		Body([]byte(`{"status":{"executed":false}}`)).
+		Do().
+		Error()
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update jenkins script %v", cm.Name)
+	}
+	log.Info("job provisioner has been updated", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+//DeleteJobProvisioner removes job provisioner which isn't used by any codebase or CD stage
+func (s JobProvisioning) DeleteJobProvisioner(name, scope string) error {
+	log.Debug("start deleting job provisioner", zap.String("name", name), zap.String("scope", scope))
+	codebases, stages, err := s.getUsage(name, scope)
+	if err != nil {
+		return err
+	}
+	if len(codebases) > 0 || len(stages) > 0 {
+		return dberror.JenkinsResourceIsUsedByCodebase{
+			Status: dberror.StatusReasonJenkinsResourceIsUsed,
+			Message: fmt.Sprintf("job provisioner %v is used by %v codebase(s) and %v stage(s). couldn't delete.",
+				name, strings.Join(codebases, ","), strings.Join(stages, ",")),
+			Name:      name,
+			Codebases: codebases,
+			Stages:    stages,
+		}
+	}
+
+	cm, err := s.getScriptConfigMap(name, scope)
+	if err != nil {
+		return err
+	}
+	p, err := s.IJobProvisioningRepository.GetJobProvisioner(name, scope)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get job provisioner %v", name)
+	}
+	if cm == nil && p == nil {
+		return edperror.NewJenkinsResourceDoesNotExistError(jobProvisionerKind, name)
+	}
+
+	sn := jenkinsScriptName(name, scope)
+	err = s.Clients.EDPRestClient.Delete().
+		Namespace(context.Namespace).
+		Resource(consts.JenkinsScriptPlural).
+		Name(sn).
+		Do().
+		Error()
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrapf(err, "couldn't delete jenkins script %v", sn)
+	}
+	if cm != nil {
+		if err := s.Clients.CoreClient.ConfigMaps(context.Namespace).Delete(sn, &metav1.DeleteOptions{}); err != nil {
+			return errors.Wrapf(err, "couldn't delete config map %v", sn)
+		}
+	}
+	if err := updateJenkinsSpec(s.Clients, func(sp *jenkinsSpec) { sp.removeJobProvision(name, scope) }); err != nil {
+		return err
+	}
+	if err := s.IJobProvisioningRepository.DeleteJobProvisioner(name, scope); err != nil {
+		return errors.Wrapf(err, "couldn't delete job provisioner %v from DB", name)
+	}
+	log.Info("job provisioner has been deleted", zap.String("name", name), zap.String("scope", scope))
+	return nil
+}
+
+func (s JobProvisioning) getUsage(name, scope string) ([]string, []string, error) {
+	codebases, err := s.IJobProvisioningRepository.SelectCodebasesUsingJobProvisioner(name, scope)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "couldn't get codebases using job provisioner %v", name)
+	}
+	stages, err := s.IJobProvisioningRepository.SelectStagesUsingJobProvisioner(name, scope)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "couldn't get stages using job provisioner %v", name)
+	}
+	return codebases, stages, nil
+}
+
+func (s JobProvisioning) getScriptConfigMap(name, scope string) (*v1.ConfigMap, error) {
+	n := jenkinsScriptName(name, scope)
+	cm, err := s.Clients.CoreClient.ConfigMaps(context.Namespace).Get(n, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "couldn't get config map %v", n)
+	}
+	return cm, nil
+}
+
+func jenkinsScriptName(name, scope string) string {
+	return fmt.Sprintf("%v-%v-job-provisioner", name, scope)
+}
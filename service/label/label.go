@@ -0,0 +1,165 @@
+package label
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository"
+	"edp-admin-console/service/logger"
+	"edp-admin-console/util"
+	"edp-admin-console/util/consts"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var log = logger.GetLogger()
+
+type LabelService struct {
+	Clients               k8s.ClientSet
+	ILabelRepository      repository.ILabelRepository
+	ICodebaseRepository   repository.ICodebaseRepository
+	ICDPipelineRepository repository.ICDPipelineRepository
+}
+
+//UpdateCodebaseLabels replaces labels of Codebase CR and stores them in DB, CR labels are reverted if DB update fails
+func (s LabelService) UpdateCodebaseLabels(name string, labels map[string]string) error {
+	log.Debug("start updating codebase labels", zap.String("name", name), zap.Any("labels", labels))
+	if err := ValidateLabels(labels); err != nil {
+		return err
+	}
+
+	cb, err := s.ICodebaseRepository.GetCodebaseByName(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get codebase %v", name)
+	}
+	if cb == nil {
+		return edperror.NewCodebaseDoesNotExistError(name)
+	}
+
+	if err := s.patchLabels(consts.CodebasePlural, name, cb.Labels, labels); err != nil {
+		return err
+	}
+
+	if err := s.ILabelRepository.ReplaceLabels(query.CodebaseLabels, cb.Id, labels); err != nil {
+		s.revertLabels(consts.CodebasePlural, name, labels, cb.Labels)
+		return errors.Wrapf(err, "couldn't save labels of codebase %v", name)
+	}
+	log.Info("codebase labels have been updated", zap.String("name", name))
+	return nil
+}
+
+//UpdateCDPipelineLabels replaces labels of CDPipeline CR and stores them in DB, CR labels are reverted if DB update fails
+func (s LabelService) UpdateCDPipelineLabels(name string, labels map[string]string) error {
+	log.Debug("start updating cd pipeline labels", zap.String("name", name), zap.Any("labels", labels))
+	if err := ValidateLabels(labels); err != nil {
+		return err
+	}
+
+	p, err := s.ICDPipelineRepository.GetCDPipelineByName(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get cd pipeline %v", name)
+	}
+	if p == nil {
+		return edperror.NewCDPipelineDoesNotExistError()
+	}
+
+	if err := s.patchLabels(consts.CDPipelinePlural, name, p.Labels, labels); err != nil {
+		return err
+	}
+
+	if err := s.ILabelRepository.ReplaceLabels(query.CDPipelineLabels, p.Id, labels); err != nil {
+		s.revertLabels(consts.CDPipelinePlural, name, labels, p.Labels)
+		return errors.Wrapf(err, "couldn't save labels of cd pipeline %v", name)
+	}
+	log.Info("cd pipeline labels have been updated", zap.String("name", name))
+	return nil
+}
+
+func (s LabelService) revertLabels(resource, name string, current, old map[string]string) {
+	if err := s.patchLabels(resource, name, current, old); err != nil {
+		log.Error("couldn't revert labels in cluster", zap.String("resource", resource),
+			zap.String("name", name), zap.Error(err))
+	}
+}
+
+func (s LabelService) patchLabels(resource, name string, old, current map[string]string) error {
+	labels := map[string]interface{}{}
+	for k := range old {
+		labels[k] = nil
+	}
+	for k, v := range current {
+		labels[k] = v
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+
+	bytes, err := util.EncodeStructToBytes(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": labels,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.Clients.EDPRestClient.Patch(types.MergePatchType).
+		Namespace(context.Namespace).
+		Resource(resource).
+		Name(name).
+		Body(bytes).
+		Do().Error()
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Info("custom resource doesn't exist in cluster. labels are saved only in DB",
+				zap.String("resource", resource), zap.String("name", name))
+			return nil
+		}
+		return errors.Wrapf(err, "couldn't update labels of %v %v in cluster", resource, name)
+	}
+	return nil
+}
+
+//ParseLabels converts key=value pairs separated by commas or new lines into labels
+func ParseLabels(values ...string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, v := range values {
+		for _, pair := range strings.FieldsFunc(v, func(r rune) bool {
+			return r == ',' || r == '\n' || r == '\r'
+		}) {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 {
+				return nil, edperror.NewNonValidLabelError(fmt.Sprintf("label %v should be in key=value format", pair))
+			}
+			labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+	if err := ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+//ValidateLabels checks that labels can be used as Kubernetes labels
+func ValidateLabels(labels map[string]string) error {
+	for k, v := range labels {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return edperror.NewNonValidLabelError(fmt.Sprintf("label key %v is invalid: %v", k, strings.Join(errs, "; ")))
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return edperror.NewNonValidLabelError(fmt.Sprintf("label value %v is invalid: %v", v, strings.Join(errs, "; ")))
+		}
+	}
+	return nil
+}
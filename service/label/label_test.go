@@ -0,0 +1,41 @@
+package label
+
+import (
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/repository/mock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseLabelsMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	labels, err := ParseLabels("team=alpha, domain = payments", "edp.epam.com/tier=backend\n")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"team":              "alpha",
+		"domain":            "payments",
+		"edp.epam.com/tier": "backend",
+	}, labels)
+}
+
+func TestParseLabelsMethod_ShouldRefuseInvalidLabels(t *testing.T) {
+	_, err := ParseLabels("team")
+	assert.IsType(t, &edperror.NonValidLabelError{}, err)
+
+	_, err = ParseLabels("team=alpha beta")
+	assert.IsType(t, &edperror.NonValidLabelError{}, err)
+
+	_, err = ParseLabels("-team=alpha")
+	assert.IsType(t, &edperror.NonValidLabelError{}, err)
+}
+
+func TestUpdateCodebaseLabelsMethod_ShouldReturnNotFoundError(t *testing.T) {
+	mCodebase := new(mock.MockCodebase)
+	s := LabelService{
+		ICodebaseRepository: mCodebase,
+	}
+
+	mCodebase.On("GetCodebaseByName", "stub-name").Return(nil, nil)
+
+	err := s.UpdateCodebaseLabels("stub-name", map[string]string{"team": "alpha"})
+	assert.IsType(t, &edperror.CodebaseDoesNotExistError{}, err)
+}
@@ -0,0 +1,232 @@
+package metrics
+
+import (
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository"
+	"edp-admin-console/service/logger"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var log = logger.GetLogger()
+
+const (
+	failedResult = "error"
+	deployAction = "deploy"
+	buildAction  = "build"
+)
+
+type MetricsService struct {
+	IMetricsRepository    repository.IMetricsRepository
+	ICodebaseRepository   repository.ICodebaseRepository
+	ICDPipelineRepository repository.ICDPipelineRepository
+	TeamLabel             string
+}
+
+//GetDoraReport calculates DORA metrics for the [from, to) period in total and per codebase, team and CD pipeline.
+//Deploy actions of CD stages are deployment attempts which are failed if the action result is error.
+//Successful build actions of codebases are changes which are delivered by the next successful
+//deployment of each stage of the pipelines which use docker streams of the codebase.
+//Both actions are reported by CI/CD jobs via CreateDeploymentEvent and CreateBuildEvent
+func (s MetricsService) GetDoraReport(from, to time.Time) (*dto.DoraReport, error) {
+	log.Debug("start calculating DORA metrics", zap.Time("from", from), zap.Time("to", to))
+	deployments, err := s.IMetricsRepository.SelectDeploymentEvents(from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get deployment events")
+	}
+
+	buildEvents, err := s.IMetricsRepository.SelectBuildEvents(from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get build events")
+	}
+	builds := map[string][]time.Time{}
+	for _, b := range buildEvents {
+		builds[b.Codebase] = append(builds[b.Codebase], b.Time)
+	}
+
+	codebases, err := s.ICodebaseRepository.GetCodebasesByCriteria(query.CodebaseCriteria{})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get codebases")
+	}
+
+	pipelines, err := s.ICDPipelineRepository.GetCDPipelines(query.CDPipelineCriteria{})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get cd pipelines")
+	}
+
+	days := to.Sub(from).Hours() / 24
+	pipelineCodebases := getPipelineCodebases(codebases, pipelines)
+	report := &dto.DoraReport{
+		From:      from,
+		To:        to,
+		Total:     calculate(deployments, builds, pipelineCodebases, days),
+		Codebases: map[string]dto.DoraMetrics{},
+		Teams:     map[string]dto.DoraMetrics{},
+		Pipelines: map[string]dto.DoraMetrics{},
+	}
+
+	for p, cbs := range pipelineCodebases {
+		report.Pipelines[p] = calculate(deployments, builds, map[string][]string{p: cbs}, days)
+	}
+
+	teams := map[string]map[string]bool{}
+	for _, c := range codebases {
+		report.Codebases[c.Name] = calculate(deployments, builds,
+			restrictCodebases(pipelineCodebases, map[string]bool{c.Name: true}), days)
+		if team, ok := c.Labels[s.TeamLabel]; ok {
+			if teams[team] == nil {
+				teams[team] = map[string]bool{}
+			}
+			teams[team][c.Name] = true
+		}
+	}
+
+	for team, members := range teams {
+		report.Teams[team] = calculate(deployments, builds, restrictCodebases(pipelineCodebases, members), days)
+	}
+
+	log.Debug("DORA metrics have been calculated", zap.Int("deployments", report.Total.Deployments))
+	return report, nil
+}
+
+//CreateDeploymentEvent adds deploy action to log of the stage
+func (s MetricsService) CreateDeploymentEvent(event command.DeploymentEventCommand) error {
+	id, err := s.IMetricsRepository.CreateDeploymentEvent(event.Pipeline, event.Stage,
+		newActionLog(deployAction, event.Result, event.Username, event.Time))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't create deployment event of stage %v/%v", event.Pipeline, event.Stage)
+	}
+	if id == nil {
+		return edperror.NewCDStageDoesNotExistError(fmt.Sprintf("%v/%v", event.Pipeline, event.Stage))
+	}
+	log.Info("deployment event has been created",
+		zap.String("pipeline", event.Pipeline), zap.String("stage", event.Stage), zap.String("result", event.Result))
+	return nil
+}
+
+//CreateBuildEvent adds build action to log of the codebase
+func (s MetricsService) CreateBuildEvent(event command.BuildEventCommand) error {
+	id, err := s.IMetricsRepository.CreateBuildEvent(event.Codebase,
+		newActionLog(buildAction, event.Result, event.Username, event.Time))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't create build event of codebase %v", event.Codebase)
+	}
+	if id == nil {
+		return edperror.NewCodebaseDoesNotExistError(event.Codebase)
+	}
+	log.Info("build event has been created",
+		zap.String("codebase", event.Codebase), zap.String("result", event.Result))
+	return nil
+}
+
+func newActionLog(action, result, username string, t *time.Time) query.ActionLog {
+	al := query.ActionLog{
+		LastTimeUpdate: time.Now().UTC(),
+		UserName:       username,
+		Message:        fmt.Sprintf("%v is reported by %v", action, username),
+		Action:         action,
+		Result:         result,
+	}
+	if t != nil {
+		al.LastTimeUpdate = t.UTC()
+	}
+	return al
+}
+
+func getPipelineCodebases(codebases []*query.Codebase, pipelines []*query.CDPipeline) map[string][]string {
+	owners := map[string]string{}
+	for _, c := range codebases {
+		for _, br := range c.CodebaseBranch {
+			for _, ds := range br.CodebaseDockerStream {
+				owners[ds.OcImageStreamName] = c.Name
+			}
+		}
+	}
+
+	res := map[string][]string{}
+	for _, p := range pipelines {
+		res[p.Name] = []string{}
+		for _, ds := range p.CodebaseDockerStream {
+			if c, ok := owners[ds.OcImageStreamName]; ok {
+				res[p.Name] = append(res[p.Name], c)
+			}
+		}
+	}
+	return res
+}
+
+//restrictCodebases keeps only pipelines which use at least one of the codebases
+//and only those codebases in each pipeline
+func restrictCodebases(pipelineCodebases map[string][]string, codebases map[string]bool) map[string][]string {
+	res := map[string][]string{}
+	for p, cbs := range pipelineCodebases {
+		for _, c := range cbs {
+			if codebases[c] {
+				res[p] = append(res[p], c)
+			}
+		}
+	}
+	return res
+}
+
+//calculate expects deployments to be ordered by time and takes into account only deployments of the pipelines,
+//failures and deliveries are tracked for each stage
+func calculate(deployments []query.DeploymentEvent, builds map[string][]time.Time,
+	pipelines map[string][]string, days float64) dto.DoraMetrics {
+	var m dto.DoraMetrics
+	var leadTime, restoreTime time.Duration
+	var changes, restores int
+	lastSuccess := map[string]time.Time{}
+	failedSince := map[string]time.Time{}
+
+	for _, d := range deployments {
+		cbs, ok := pipelines[d.Pipeline]
+		if !ok {
+			continue
+		}
+		m.Deployments++
+		stage := fmt.Sprintf("%v/%v", d.Pipeline, d.Stage)
+		if d.Result == failedResult {
+			m.FailedDeployments++
+			if _, ok := failedSince[stage]; !ok {
+				failedSince[stage] = d.Time
+			}
+			continue
+		}
+
+		if t, ok := failedSince[stage]; ok {
+			restoreTime += d.Time.Sub(t)
+			restores++
+			delete(failedSince, stage)
+		}
+		for _, c := range cbs {
+			for _, b := range builds[c] {
+				if b.After(lastSuccess[stage]) && !b.After(d.Time) {
+					leadTime += d.Time.Sub(b)
+					changes++
+				}
+			}
+		}
+		lastSuccess[stage] = d.Time
+	}
+
+	if days > 0 {
+		m.DeploymentFrequency = float64(m.Deployments-m.FailedDeployments) / days
+	}
+	if m.Deployments > 0 {
+		m.ChangeFailureRate = float64(m.FailedDeployments) / float64(m.Deployments)
+	}
+	if changes > 0 {
+		m.LeadTimeHours = leadTime.Hours() / float64(changes)
+	}
+	if restores > 0 {
+		m.TimeToRestoreHours = restoreTime.Hours() / float64(restores)
+	}
+	return m
+}
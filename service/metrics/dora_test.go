@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func at(hours int) time.Time {
+	return start.Add(time.Duration(hours) * time.Hour)
+}
+
+func TestCalculateMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	deployments := []query.DeploymentEvent{
+		{Pipeline: "fake-pipeline", Time: at(2), Result: "success"},
+		{Pipeline: "fake-other-pipeline", Time: at(3), Result: "success"},
+		{Pipeline: "fake-pipeline", Time: at(10), Result: "error"},
+		{Pipeline: "fake-pipeline", Time: at(12), Result: "error"},
+		{Pipeline: "fake-pipeline", Time: at(14), Result: "success"},
+	}
+	builds := map[string][]time.Time{
+		"fake-app": {at(0), at(1), at(8)},
+	}
+
+	m := calculate(deployments, builds, map[string][]string{"fake-pipeline": {"fake-app"}}, 2)
+	assert.Equal(t, 4, m.Deployments)
+	assert.Equal(t, 2, m.FailedDeployments)
+	assert.Equal(t, float64(1), m.DeploymentFrequency)
+	assert.Equal(t, 0.5, m.ChangeFailureRate)
+	assert.Equal(t, float64(3), m.LeadTimeHours)
+	assert.Equal(t, float64(4), m.TimeToRestoreHours)
+}
+
+func TestGetDoraReportMethod_ShouldGroupMetrics(t *testing.T) {
+	mMetrics := new(mock.MockMetrics)
+	mCodebase := new(mock.MockCodebase)
+	mPipeline := new(mock.MockCdPipeline)
+	s := MetricsService{
+		IMetricsRepository:    mMetrics,
+		ICodebaseRepository:   mCodebase,
+		ICDPipelineRepository: mPipeline,
+		TeamLabel:             "team",
+	}
+
+	from, to := at(0), at(48)
+	mMetrics.On("SelectDeploymentEvents", from, to).Return([]query.DeploymentEvent{
+		{Pipeline: "fake-pipeline", Time: at(2), Result: "success"},
+		{Pipeline: "fake-other-pipeline", Time: at(3), Result: "error"},
+	}, nil)
+	mMetrics.On("SelectBuildEvents", from, to).Return([]query.BuildEvent{
+		{Codebase: "fake-app", Time: at(1)},
+	}, nil)
+	mCodebase.On("GetCodebasesByCriteria", query.CodebaseCriteria{}).Return([]*query.Codebase{
+		{Name: "fake-app", Labels: map[string]string{"team": "fake-team"}, CodebaseBranch: []*query.CodebaseBranch{
+			{Name: "master", CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-app-master"}}},
+		}},
+		{Name: "fake-other-app", CodebaseBranch: []*query.CodebaseBranch{
+			{Name: "master", CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-other-app-master"}}},
+		}},
+	}, nil)
+	mPipeline.On("GetCDPipelines", query.CDPipelineCriteria{}).Return([]*query.CDPipeline{
+		{Name: "fake-pipeline", CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-app-master"}}},
+		{Name: "fake-other-pipeline", CodebaseDockerStream: []*query.CodebaseDockerStream{{OcImageStreamName: "fake-other-app-master"}}},
+	}, nil)
+
+	r, err := s.GetDoraReport(from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, r.Total.Deployments)
+	assert.Equal(t, 0.5, r.Total.ChangeFailureRate)
+	assert.Equal(t, 1, r.Codebases["fake-app"].Deployments)
+	assert.Equal(t, float64(1), r.Codebases["fake-app"].LeadTimeHours)
+	assert.Equal(t, float64(1), r.Codebases["fake-other-app"].ChangeFailureRate)
+	assert.Equal(t, r.Codebases["fake-app"], r.Teams["fake-team"])
+	assert.Len(t, r.Teams, 1)
+	assert.Equal(t, 0.5, r.Pipelines["fake-pipeline"].DeploymentFrequency)
+}
+
+func TestGetDoraReportMethod_ShouldReturnRepositoryError(t *testing.T) {
+	mMetrics := new(mock.MockMetrics)
+	s := MetricsService{IMetricsRepository: mMetrics}
+
+	mMetrics.On("SelectDeploymentEvents", at(0), at(1)).Return([]query.DeploymentEvent{}, errors.New("fake"))
+
+	_, err := s.GetDoraReport(at(0), at(1))
+	assert.Error(t, err)
+}
+
+func TestCalculateMethod_ShouldTrackFailuresOfEachStage(t *testing.T) {
+	deployments := []query.DeploymentEvent{
+		{Pipeline: "fake-pipeline", Stage: "qa", Time: at(2), Result: "error"},
+		{Pipeline: "fake-pipeline", Stage: "dev", Time: at(3), Result: "success"},
+		{Pipeline: "fake-pipeline", Stage: "qa", Time: at(6), Result: "success"},
+	}
+
+	m := calculate(deployments, map[string][]time.Time{}, map[string][]string{"fake-pipeline": {}}, 1)
+	assert.Equal(t, 3, m.Deployments)
+	assert.Equal(t, float64(4), m.TimeToRestoreHours)
+}
+
+func TestCreateDeploymentEventMethod_ShouldFailOnAbsentStage(t *testing.T) {
+	mMetrics := new(mock.MockMetrics)
+	s := MetricsService{IMetricsRepository: mMetrics}
+
+	reported := at(5)
+	mMetrics.On("CreateDeploymentEvent", "fake-pipeline", "fake-stage", query.ActionLog{
+		LastTimeUpdate: reported,
+		UserName:       "fake-user",
+		Message:        "deploy is reported by fake-user",
+		Action:         "deploy",
+		Result:         "success",
+	}).Return(nil, nil)
+
+	err := s.CreateDeploymentEvent(command.DeploymentEventCommand{
+		Pipeline: "fake-pipeline",
+		Stage:    "fake-stage",
+		Result:   "success",
+		Time:     &reported,
+		Username: "fake-user",
+	})
+	assert.IsType(t, &edperror.CDStageDoesNotExistError{}, err)
+}
@@ -0,0 +1,104 @@
+package perfboard
+
+import (
+	"bytes"
+	"edp-admin-console/models/dto"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const perfRequestTimeout = 15 * time.Second
+
+type perfClient struct {
+	url      string
+	user     string
+	password string
+	client   http.Client
+}
+
+func newPerfClient(apiUrl, user, password string) *perfClient {
+	return &perfClient{
+		url:      strings.TrimSuffix(apiUrl, "/"),
+		user:     user,
+		password: password,
+		client:   http.Client{Timeout: perfRequestTimeout},
+	}
+}
+
+func (c *perfClient) getToken() (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"username": c.user,
+		"password": c.password,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.client.Post(c.url+"/api/v2/sso/token", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("perf responded with %v", resp.Status)
+	}
+	var t struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return "", err
+	}
+	if t.AccessToken == "" {
+		return "", errors.New("perf responded with empty token")
+	}
+	return t.AccessToken, nil
+}
+
+func (c *perfClient) get(token, path string, res interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("perf responded with %v", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
+
+func (c *perfClient) checkConnection(project string) dto.PerfConnectionTest {
+	token, err := c.getToken()
+	if err != nil {
+		return dto.PerfConnectionTest{
+			Connection: dto.ConnectionCheck{Message: err.Error()},
+			Project:    dto.ConnectionCheck{Message: "skipped as connection is failed"},
+		}
+	}
+	res := dto.PerfConnectionTest{
+		Connection: dto.ConnectionCheck{Success: true, Message: fmt.Sprintf("authenticated as %v", c.user)},
+	}
+
+	var p struct {
+		Name string `json:"name"`
+	}
+	if err := c.get(token, "/api/v2/projects/"+url.PathEscape(project), &p); err != nil {
+		res.Project = dto.ConnectionCheck{Message: fmt.Sprintf("couldn't get project %v: %v", project, err)}
+		return res
+	}
+	res.Project = dto.ConnectionCheck{Success: true, Message: fmt.Sprintf("project %v is accessible", p.Name)}
+	return res
+}
@@ -1,17 +1,50 @@
 package perfboard
 
 import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository/perfboard"
 	"edp-admin-console/service/logger"
+	"edp-admin-console/util"
+	"edp-admin-console/util/consts"
+	"encoding/json"
+	"fmt"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
 )
 
 var log = logger.GetLogger()
 
+const (
+	dataSourcesAnnotation = "perf.edp.epam.com/data-sources"
+	usernameField         = "username"
+	passwordField         = "password"
+)
+
 type PerfBoard struct {
-	PerfRepo perfboard.IPerfServer
+	Clients     k8s.ClientSet
+	PerfRepo    perfboard.IPerfServer
+	DataSources []string
+}
+
+type perfServerCR struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              perfServerSpec `json:"spec"`
+}
+
+type perfServerSpec struct {
+	ApiUrl         string `json:"apiUrl"`
+	RootUrl        string `json:"rootUrl"`
+	CredentialName string `json:"credentialName"`
+	ProjectName    string `json:"projectName"`
 }
 
 func (s PerfBoard) GetPerfServers() ([]*query.PerfServer, error) {
@@ -40,3 +73,283 @@ func (s PerfBoard) GetCodebaseDataSources(codebaseId int) ([]string, error) {
 	}
 	return ds, nil
 }
+
+func (s PerfBoard) GetPerfServer(name string) (*dto.PerfServer, error) {
+	cr, err := s.getPerfServerCR(name)
+	if err != nil {
+		return nil, err
+	}
+	if cr == nil {
+		return nil, edperror.NewPerfServerDoesNotExistError(name)
+	}
+
+	res := &dto.PerfServer{
+		Name:           cr.Name,
+		ApiUrl:         cr.Spec.ApiUrl,
+		RootUrl:        cr.Spec.RootUrl,
+		ProjectName:    cr.Spec.ProjectName,
+		CredentialName: cr.Spec.CredentialName,
+		DataSources:    s.getDataSources(cr),
+	}
+	ps, err := s.PerfRepo.GetPerfServer(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get PERF server %v from DB", name)
+	}
+	if ps != nil {
+		res.Available = ps.Available
+	}
+	return res, nil
+}
+
+func (s PerfBoard) CreatePerfServer(cmd command.PerfServerCommand) error {
+	log.Debug("start creating PERF server", zap.String("name", cmd.Name))
+	if err := s.validateDataSources(cmd.DataSources); err != nil {
+		return err
+	}
+	cr, err := s.getPerfServerCR(cmd.Name)
+	if err != nil {
+		return err
+	}
+	ps, err := s.PerfRepo.GetPerfServer(cmd.Name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get PERF server %v from DB", cmd.Name)
+	}
+	if cr != nil || ps != nil {
+		return edperror.NewPerfServerExistsError(cmd.Name)
+	}
+	if cmd.Password == "" {
+		return edperror.NewNonValidPerfServerError("password should be specified")
+	}
+
+	secretName := fmt.Sprintf("%v-perf-credentials", cmd.Name)
+	if err := s.saveCredentials(secretName, cmd.User, cmd.Password); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(perfServerCR{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v2.edp.epam.com/v1alpha1",
+			Kind:       consts.PerfServerKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cmd.Name,
+			Namespace:   context.Namespace,
+			Annotations: map[string]string{dataSourcesAnnotation: strings.Join(cmd.DataSources, ",")},
+		},
+		Spec: perfServerSpec{
+			ApiUrl:         cmd.ApiUrl,
+			RootUrl:        cmd.RootUrl,
+			CredentialName: secretName,
+			ProjectName:    cmd.ProjectName,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = s.Clients.EDPRestClient.Post().
+		Namespace(context.Namespace).
+		Resource(consts.PerfServerPlural).
+		Body(body).
+		Do().
+		Error()
+	if err != nil {
+		if derr := s.Clients.CoreClient.Secrets(context.Namespace).Delete(secretName, &metav1.DeleteOptions{}); derr != nil {
+			log.Error("couldn't delete credentials secret of PERF server", zap.String("secret", secretName), zap.Error(derr))
+		}
+		return errors.Wrapf(err, "couldn't create PERF server %v", cmd.Name)
+	}
+	log.Info("PERF server has been created", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+func (s PerfBoard) UpdatePerfServer(cmd command.PerfServerCommand) error {
+	log.Debug("start updating PERF server", zap.String("name", cmd.Name))
+	if err := s.validateDataSources(cmd.DataSources); err != nil {
+		return err
+	}
+	cr, err := s.getPerfServerCR(cmd.Name)
+	if err != nil {
+		return err
+	}
+	if cr == nil {
+		return edperror.NewPerfServerDoesNotExistError(cmd.Name)
+	}
+
+	if cr.Spec.CredentialName == "" {
+		cr.Spec.CredentialName = fmt.Sprintf("%v-perf-credentials", cmd.Name)
+	}
+	if err := s.saveCredentials(cr.Spec.CredentialName, cmd.User, cmd.Password); err != nil {
+		return err
+	}
+
+	cr.Spec.ApiUrl = cmd.ApiUrl
+	cr.Spec.RootUrl = cmd.RootUrl
+	cr.Spec.ProjectName = cmd.ProjectName
+	if cr.Annotations == nil {
+		cr.Annotations = map[string]string{}
+	}
+	cr.Annotations[dataSourcesAnnotation] = strings.Join(cmd.DataSources, ",")
+	body, err := json.Marshal(cr)
+	if err != nil {
+		return err
+	}
+	err = s.Clients.EDPRestClient.Put().
+		Namespace(context.Namespace).
+		Resource(consts.PerfServerPlural).
+		Name(cmd.Name).
+		Body(body).
+		Do().
+		Error()
+	if err != nil {
+		return errors.Wrapf(err, "couldn't update PERF server %v", cmd.Name)
+	}
+	log.Info("PERF server has been updated", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+func (s PerfBoard) DeletePerfServer(name string) error {
+	log.Debug("start deleting PERF server", zap.String("name", name))
+	count, err := s.PerfRepo.SelectCountCodebases(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't count codebases of PERF server %v", name)
+	}
+	if count > 0 {
+		return edperror.NewNonValidPerfServerError(
+			fmt.Sprintf("perf server %v is used by %v codebase(s)", name, count))
+	}
+
+	cr, err := s.getPerfServerCR(name)
+	if err != nil {
+		return err
+	}
+	if cr == nil {
+		return edperror.NewPerfServerDoesNotExistError(name)
+	}
+
+	err = s.Clients.EDPRestClient.Delete().
+		Namespace(context.Namespace).
+		Resource(consts.PerfServerPlural).
+		Name(name).
+		Do().
+		Error()
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrapf(err, "couldn't delete PERF server %v", name)
+	}
+	if cr.Spec.CredentialName != "" {
+		err := s.Clients.CoreClient.Secrets(context.Namespace).Delete(cr.Spec.CredentialName, &metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "couldn't delete secret %v", cr.Spec.CredentialName)
+		}
+	}
+	log.Info("PERF server has been deleted", zap.String("name", name))
+	return nil
+}
+
+func (s PerfBoard) TestConnection(name string) (*dto.PerfConnectionTest, error) {
+	ps, err := s.GetPerfServer(name)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.Clients.CoreClient.Secrets(context.Namespace).Get(ps.CredentialName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, edperror.NewNonValidPerfServerError(
+				fmt.Sprintf("credentials secret %v doesn't exist", ps.CredentialName))
+		}
+		return nil, errors.Wrapf(err, "couldn't get secret %v", ps.CredentialName)
+	}
+
+	c := newPerfClient(ps.ApiUrl, string(secret.Data[usernameField]), string(secret.Data[passwordField]))
+	res := c.checkConnection(ps.ProjectName)
+	log.Info("PERF server connection has been tested", zap.String("name", name), zap.Any("result", res))
+	return &res, nil
+}
+
+func (s PerfBoard) ValidateDataSources(name string, dataSources []string) error {
+	cr, err := s.getPerfServerCR(name)
+	if err != nil {
+		return err
+	}
+
+	var configured []string
+	if cr != nil {
+		configured = s.getDataSources(cr)
+	} else {
+		ps, err := s.PerfRepo.GetPerfServer(name)
+		if err != nil {
+			return errors.Wrapf(err, "couldn't get PERF server %v from DB", name)
+		}
+		if ps == nil {
+			return edperror.NewNonValidPerfServerError(fmt.Sprintf("perf server %v doesn't exist", name))
+		}
+		configured = s.DataSources
+	}
+
+	for _, ds := range dataSources {
+		if !util.Contains(configured, ds) {
+			return edperror.NewNonValidPerfServerError(
+				fmt.Sprintf("data source %v isn't configured on perf server %v", ds, name))
+		}
+	}
+	return nil
+}
+
+func (s PerfBoard) validateDataSources(dataSources []string) error {
+	if len(dataSources) == 0 {
+		return edperror.NewNonValidPerfServerError("at least one data source should be specified")
+	}
+	for _, ds := range dataSources {
+		if !util.Contains(s.DataSources, ds) {
+			return edperror.NewNonValidPerfServerError(fmt.Sprintf("data source %v isn't supported", ds))
+		}
+	}
+	return nil
+}
+
+func (s PerfBoard) getDataSources(cr *perfServerCR) []string {
+	v, ok := cr.Annotations[dataSourcesAnnotation]
+	if !ok {
+		return s.DataSources
+	}
+	var res []string
+	for _, ds := range strings.Split(v, ",") {
+		if ds = strings.TrimSpace(ds); ds != "" {
+			res = append(res, ds)
+		}
+	}
+	return res
+}
+
+func (s PerfBoard) getPerfServerCR(name string) (*perfServerCR, error) {
+	raw, err := s.Clients.EDPRestClient.Get().
+		Namespace(context.Namespace).
+		Resource(consts.PerfServerPlural).
+		Name(name).
+		Do().
+		Raw()
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "couldn't get PERF server %v from cluster", name)
+	}
+
+	r := &perfServerCR{}
+	if err := json.Unmarshal(raw, r); err != nil {
+		return nil, errors.Wrapf(err, "couldn't parse PERF server %v", name)
+	}
+	return r, nil
+}
+
+func (s PerfBoard) saveCredentials(name, user, password string) error {
+	data := map[string]string{
+		usernameField: user,
+		passwordField: password,
+	}
+	return util.SaveSecret(s.Clients.CoreClient, name, data, func() error {
+		if password == "" {
+			return edperror.NewNonValidPerfServerError("password should be specified as credentials secret doesn't exist")
+		}
+		return nil
+	})
+}
@@ -1,13 +1,44 @@
 package perfboard
 
 import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository/mock"
+	"encoding/json"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
+func createPerfStub() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/sso/token", func(w http.ResponseWriter, r *http.Request) {
+		var c map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil || c["username"] != "stub-user" || c["password"] != "stub-password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"stub-token"}`))
+	})
+	mux.HandleFunc("/api/v2/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer stub-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/api/v2/projects/EDP" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"EDP"}`))
+	})
+	return httptest.NewServer(mux)
+}
+
 func TestGetPerfServersMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
 	pbrm := new(mock.MockPerfBoard)
 	pb := PerfBoard{
@@ -95,3 +126,70 @@ func TestGetCodebaseDataSourcesMethod_ShouldReturnError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, ds)
 }
+
+func TestCheckConnectionMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	s := createPerfStub()
+	defer s.Close()
+
+	res := newPerfClient(s.URL, "stub-user", "stub-password").checkConnection("EDP")
+	assert.True(t, res.Connection.Success)
+	assert.Equal(t, "authenticated as stub-user", res.Connection.Message)
+	assert.True(t, res.Project.Success)
+}
+
+func TestCheckConnectionMethod_ShouldReportWrongCredentials(t *testing.T) {
+	s := createPerfStub()
+	defer s.Close()
+
+	res := newPerfClient(s.URL, "stub-user", "wrong-password").checkConnection("EDP")
+	assert.False(t, res.Connection.Success)
+	assert.Contains(t, res.Connection.Message, "401")
+	assert.False(t, res.Project.Success)
+}
+
+func TestCheckConnectionMethod_ShouldReportMissedProject(t *testing.T) {
+	s := createPerfStub()
+	defer s.Close()
+
+	res := newPerfClient(s.URL, "stub-user", "stub-password").checkConnection("stub-project")
+	assert.True(t, res.Connection.Success)
+	assert.False(t, res.Project.Success)
+	assert.Contains(t, res.Project.Message, "404")
+}
+
+func TestValidateDataSourcesMethod_ShouldRefuseNotConfiguredDataSource(t *testing.T) {
+	context.Namespace = "stub-namespace"
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/perfservers/stub-perf") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"metadata":{"name":"stub-perf","annotations":{"perf.edp.epam.com/data-sources":"Sonar"}}}`))
+	}))
+	defer s.Close()
+	client, err := k8s.CreateEDPRestClient(s.URL)
+	assert.NoError(t, err)
+	pb := PerfBoard{
+		Clients:     k8s.ClientSet{EDPRestClient: client},
+		DataSources: []string{"Sonar", "Jenkins", "GitLab"},
+	}
+
+	assert.NoError(t, pb.ValidateDataSources("stub-perf", []string{"Sonar"}))
+
+	err = pb.ValidateDataSources("stub-perf", []string{"Sonar", "Jenkins"})
+	assert.IsType(t, &edperror.NonValidPerfServerError{}, err)
+	assert.Equal(t, "data source Jenkins isn't configured on perf server stub-perf", err.Error())
+}
+
+func TestDeletePerfServerMethod_ShouldRefuseUsedServer(t *testing.T) {
+	pbrm := new(mock.MockPerfBoard)
+	pb := PerfBoard{
+		PerfRepo: pbrm,
+	}
+
+	pbrm.On("SelectCountCodebases", "stub-perf").Return(2, nil)
+
+	err := pb.DeletePerfServer("stub-perf")
+	assert.IsType(t, &edperror.NonValidPerfServerError{}, err)
+}
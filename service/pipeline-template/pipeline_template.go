@@ -0,0 +1,258 @@
+package pipeline_template
+
+import (
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	pt "edp-admin-console/repository/pipeline-template"
+	"edp-admin-console/service/cd_pipeline"
+	"edp-admin-console/service/logger"
+	"encoding/json"
+	"fmt"
+
+	edppipelinesv1alpha1 "github.com/epmd-edp/cd-pipeline-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var log = logger.GetLogger()
+
+type PipelineTemplateService struct {
+	ITemplateRepository pt.IPipelineTemplateRepository
+}
+
+//GetTemplates gets all CD Pipeline templates from DB
+func (s PipelineTemplateService) GetTemplates() ([]*query.CDPipelineTemplate, error) {
+	log.Debug("start fetching CD Pipeline templates from DB")
+	templates, err := s.ITemplateRepository.GetTemplates()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get CD Pipeline templates from DB")
+	}
+	for _, t := range templates {
+		if err := unmarshalStages(t); err != nil {
+			return nil, err
+		}
+	}
+	log.Info("CD Pipeline templates have been fetched", zap.Int("count", len(templates)))
+	return templates, nil
+}
+
+//GetTemplate gets CD Pipeline template by name from DB, returns nil if template doesn't exist
+func (s PipelineTemplateService) GetTemplate(name string) (*query.CDPipelineTemplate, error) {
+	log.Debug("start fetching CD Pipeline template", zap.String("name", name))
+	t, err := s.ITemplateRepository.GetTemplate(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get CD Pipeline template %v from DB", name)
+	}
+	if t == nil {
+		log.Debug("CD Pipeline template doesn't exist in DB", zap.String("name", name))
+		return nil, nil
+	}
+	if err := unmarshalStages(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+//CreateTemplate saves new CD Pipeline template into DB
+func (s PipelineTemplateService) CreateTemplate(template command.CDPipelineTemplateCommand) error {
+	return s.saveTemplate(template.Name, template.Description, convertStageCommands(template.Stages))
+}
+
+//CreateTemplateFromStages saves stages of the existing CD Pipeline into DB as a new template
+func (s PipelineTemplateService) CreateTemplateFromStages(name, description string, stages []*query.Stage) error {
+	return s.saveTemplate(name, description, convertStages(stages))
+}
+
+func (s PipelineTemplateService) saveTemplate(name, description string, stages []query.TemplateStage) error {
+	log.Debug("start creating CD Pipeline template", zap.String("name", name))
+	t, err := s.ITemplateRepository.GetTemplate(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get CD Pipeline template %v from DB", name)
+	}
+	if t != nil {
+		log.Debug("CD Pipeline template already exists in DB", zap.String("name", name))
+		return edperror.NewCDPipelineTemplateExistsError()
+	}
+
+	rs, err := json.Marshal(stages)
+	if err != nil {
+		return errors.Wrap(err, "couldn't marshal template stages")
+	}
+	if err := s.ITemplateRepository.CreateTemplate(&query.CDPipelineTemplate{
+		Name:        name,
+		Description: description,
+		RawStages:   string(rs),
+	}); err != nil {
+		return errors.Wrapf(err, "couldn't save CD Pipeline template %v into DB", name)
+	}
+	log.Info("CD Pipeline template has been created", zap.String("name", name))
+	return nil
+}
+
+//UpdateTemplate replaces description and stages of the existing CD Pipeline template
+func (s PipelineTemplateService) UpdateTemplate(template command.CDPipelineTemplateCommand) error {
+	log.Debug("start updating CD Pipeline template", zap.String("name", template.Name))
+	t, err := s.ITemplateRepository.GetTemplate(template.Name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get CD Pipeline template %v from DB", template.Name)
+	}
+	if t == nil {
+		return edperror.NewCDPipelineTemplateDoesNotExistError()
+	}
+
+	rs, err := json.Marshal(convertStageCommands(template.Stages))
+	if err != nil {
+		return errors.Wrap(err, "couldn't marshal template stages")
+	}
+	t.Description = template.Description
+	t.RawStages = string(rs)
+	if err := s.ITemplateRepository.UpdateTemplate(t); err != nil {
+		return errors.Wrapf(err, "couldn't update CD Pipeline template %v", template.Name)
+	}
+	log.Info("CD Pipeline template has been updated", zap.String("name", template.Name))
+	return nil
+}
+
+//DeleteTemplate removes CD Pipeline template from DB
+func (s PipelineTemplateService) DeleteTemplate(name string) error {
+	log.Debug("start deleting CD Pipeline template", zap.String("name", name))
+	t, err := s.ITemplateRepository.GetTemplate(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get CD Pipeline template %v from DB", name)
+	}
+	if t == nil {
+		return edperror.NewCDPipelineTemplateDoesNotExistError()
+	}
+	if err := s.ITemplateRepository.DeleteTemplate(name); err != nil {
+		return errors.Wrapf(err, "couldn't delete CD Pipeline template %v", name)
+	}
+	log.Info("CD Pipeline template has been deleted", zap.String("name", name))
+	return nil
+}
+
+//ExpandTemplate builds stages of CD Pipeline using template and params passed in request
+func (s PipelineTemplateService) ExpandTemplate(name string, params command.CDPipelineTemplateParams) ([]command.CDStageCommand, error) {
+	log.Debug("start expanding CD Pipeline template",
+		zap.String("name", name), zap.Any("params", params))
+	t, err := s.GetTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, edperror.NewCDPipelineTemplateDoesNotExistError()
+	}
+	return expandStages(t.Stages, params)
+}
+
+func unmarshalStages(template *query.CDPipelineTemplate) error {
+	if err := json.Unmarshal([]byte(template.RawStages), &template.Stages); err != nil {
+		return errors.Wrapf(err, "couldn't unmarshal stages of %v CD Pipeline template", template.Name)
+	}
+	return nil
+}
+
+func expandStages(stages []query.TemplateStage, params command.CDPipelineTemplateParams) ([]command.CDStageCommand, error) {
+	var result []command.CDStageCommand
+	for i, s := range stages {
+		sc := command.CDStageCommand{
+			Name:            s.Name,
+			Description:     s.Description,
+			TriggerType:     s.TriggerType,
+			Order:           i,
+			Source:          cd_pipeline.ConvertStageSource(s.Source),
+			JobProvisioning: s.JobProvisioning,
+		}
+		for _, g := range s.QualityGates {
+			qg := edppipelinesv1alpha1.QualityGate{
+				QualityGateType: g.QualityGateType,
+				StepName:        g.StepName,
+			}
+			if g.QualityGateType == "autotests" {
+				if g.AutotestName == nil {
+					return nil, edperror.NewNonValidTemplateParamsError(
+						fmt.Sprintf("autotest isn't specified for %v step of %v stage", g.StepName, s.Name))
+				}
+				branch := g.BranchName
+				if b, ok := params.AutotestBranches[*g.AutotestName]; ok {
+					branch = &b
+				}
+				if branch == nil || *branch == "" {
+					return nil, edperror.NewNonValidTemplateParamsError(
+						fmt.Sprintf("branch of %v autotest isn't specified", *g.AutotestName))
+				}
+				autotest := *g.AutotestName
+				qg.AutotestName = &autotest
+				qg.BranchName = branch
+			}
+			sc.QualityGates = append(sc.QualityGates, qg)
+		}
+		result = append(result, sc)
+	}
+	return result, nil
+}
+
+func convertStageCommands(stages []command.CDStageCommand) []query.TemplateStage {
+	var result []query.TemplateStage
+	for _, s := range stages {
+		ts := query.TemplateStage{
+			Name:            s.Name,
+			Description:     s.Description,
+			TriggerType:     s.TriggerType,
+			JobProvisioning: s.JobProvisioning,
+			Source:          convertFromSourceSpec(s.Source),
+		}
+		for _, g := range s.QualityGates {
+			ts.QualityGates = append(ts.QualityGates, query.TemplateQualityGate{
+				QualityGateType: g.QualityGateType,
+				StepName:        g.StepName,
+				AutotestName:    g.AutotestName,
+				BranchName:      g.BranchName,
+			})
+		}
+		result = append(result, ts)
+	}
+	return result
+}
+
+func convertStages(stages []*query.Stage) []query.TemplateStage {
+	var result []query.TemplateStage
+	for _, s := range stages {
+		ts := query.TemplateStage{
+			Name:        s.Name,
+			Description: s.Description,
+			TriggerType: s.TriggerType,
+			Source:      s.Source,
+		}
+		if s.JobProvisioning != nil {
+			ts.JobProvisioning = s.JobProvisioning.Name
+		}
+		for _, g := range s.QualityGates {
+			tg := query.TemplateQualityGate{
+				QualityGateType: g.QualityGateType,
+				StepName:        g.StepName,
+			}
+			if g.Autotest != nil && g.Branch != nil {
+				autotest, branch := g.Autotest.Name, g.Branch.Name
+				tg.AutotestName = &autotest
+				tg.BranchName = &branch
+			}
+			ts.QualityGates = append(ts.QualityGates, tg)
+		}
+		result = append(result, ts)
+	}
+	return result
+}
+
+func convertFromSourceSpec(source edppipelinesv1alpha1.Source) query.Source {
+	if source.Type != "library" {
+		return query.Source{Type: "default"}
+	}
+	return query.Source{
+		Type: "library",
+		Library: &query.SourceLibrary{
+			Name:   source.Library.Name,
+			Branch: source.Library.Branch,
+		},
+	}
+}
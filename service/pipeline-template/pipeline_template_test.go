@@ -0,0 +1,102 @@
+package pipeline_template
+
+import (
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+)
+
+const rawStages = `[{"name":"sit","description":"sit stage","triggerType":"manual","jobProvisioning":"default",
+"source":{"type":"default","library":null},
+"qualityGates":[{"qualityGateType":"manual","stepName":"approve"},
+{"qualityGateType":"autotests","stepName":"tests","autotestName":"autotest","branchName":"master"}]}]`
+
+func TestExpandTemplateMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	mRepo := new(mock.MockPipelineTemplate)
+	s := PipelineTemplateService{
+		ITemplateRepository: mRepo,
+	}
+
+	mRepo.On("GetTemplate", "stub-template").Return(query.CDPipelineTemplate{
+		Name:      "stub-template",
+		RawStages: rawStages,
+	}, nil)
+
+	stages, err := s.ExpandTemplate("stub-template", command.CDPipelineTemplateParams{
+		AutotestBranches: map[string]string{"autotest": "release-1.0"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, stages, 1)
+	assert.Equal(t, "sit", stages[0].Name)
+	assert.Equal(t, "default", stages[0].Source.Type)
+	assert.Equal(t, "default", stages[0].JobProvisioning)
+	assert.Len(t, stages[0].QualityGates, 2)
+	assert.Nil(t, stages[0].QualityGates[0].AutotestName)
+	assert.Equal(t, "autotest", *stages[0].QualityGates[1].AutotestName)
+	assert.Equal(t, "release-1.0", *stages[0].QualityGates[1].BranchName)
+}
+
+func TestExpandTemplateMethod_ShouldReturnNotFoundError(t *testing.T) {
+	mRepo := new(mock.MockPipelineTemplate)
+	s := PipelineTemplateService{
+		ITemplateRepository: mRepo,
+	}
+
+	mRepo.On("GetTemplate", "stub-template").Return(nil, nil)
+
+	stages, err := s.ExpandTemplate("stub-template", command.CDPipelineTemplateParams{})
+	assert.Error(t, err)
+	assert.IsType(t, &edperror.CDPipelineTemplateDoesNotExistError{}, err)
+	assert.Nil(t, stages)
+}
+
+func TestExpandStages_ShouldReturnErrorIfAutotestBranchIsMissing(t *testing.T) {
+	autotest := "autotest"
+	stages, err := expandStages([]query.TemplateStage{
+		{
+			Name: "sit",
+			QualityGates: []query.TemplateQualityGate{
+				{QualityGateType: "autotests", StepName: "tests", AutotestName: &autotest},
+			},
+		},
+	}, command.CDPipelineTemplateParams{})
+	assert.Error(t, err)
+	assert.IsType(t, &edperror.NonValidTemplateParamsError{}, err)
+	assert.Nil(t, stages)
+}
+
+func TestCreateTemplateMethod_ShouldReturnExistsError(t *testing.T) {
+	mRepo := new(mock.MockPipelineTemplate)
+	s := PipelineTemplateService{
+		ITemplateRepository: mRepo,
+	}
+
+	mRepo.On("GetTemplate", "stub-template").Return(query.CDPipelineTemplate{Name: "stub-template"}, nil)
+
+	err := s.CreateTemplate(command.CDPipelineTemplateCommand{Name: "stub-template"})
+	assert.Error(t, err)
+	assert.IsType(t, &edperror.CDPipelineTemplateExistsError{}, err)
+}
+
+func TestCreateTemplateMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	mRepo := new(mock.MockPipelineTemplate)
+	s := PipelineTemplateService{
+		ITemplateRepository: mRepo,
+	}
+
+	mRepo.On("GetTemplate", "stub-template").Return(nil, nil)
+	mRepo.On("CreateTemplate", tmock.Anything).Return(nil)
+
+	err := s.CreateTemplate(command.CDPipelineTemplateCommand{
+		Name: "stub-template",
+		Stages: []command.CDStageCommand{
+			{Name: "sit", Description: "sit stage", TriggerType: "manual"},
+		},
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,239 @@
+package quality_gate
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	qg "edp-admin-console/repository/quality-gate"
+	fws "edp-admin-console/service/freeze-window"
+	"edp-admin-console/service/logger"
+	"edp-admin-console/util/consts"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var log = logger.GetLogger()
+
+const (
+	manualGateType       = "manual"
+	gateAnnotationPrefix = "gate.edp.epam.com"
+)
+
+type QualityGateService struct {
+	Clients                k8s.ClientSet
+	IQualityGateRepository qg.IQualityGateRepository
+	FreezeWindowService    fws.FreezeWindowService
+}
+
+// MakeDecision surfaces approve/reject decision of manual quality gate to Stage CR as annotation and stores it,
+// annotation is reverted if decision couldn't be stored
+func (s QualityGateService) MakeDecision(pipelineName, stageName, stepName string, cmd command.QualityGateDecisionCommand) (*query.QualityGateDecision, error) {
+	log.Debug("start making decision on manual quality gate",
+		zap.String("pipeline", pipelineName),
+		zap.String("stage", stageName),
+		zap.String("step", stepName),
+		zap.String("decision", cmd.Decision))
+	stageId, err := s.getManualGateStageId(pipelineName, stageName, stepName)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Decision == "approve" {
+		if err := s.FreezeWindowService.CheckStageIsNotFrozen(pipelineName, stageName); err != nil {
+			return nil, err
+		}
+	}
+
+	d := &query.QualityGateDecision{
+		CdStageId: *stageId,
+		StepName:  stepName,
+		Decision:  convertDecision(cmd.Decision),
+		Comment:   cmd.Comment,
+		Username:  cmd.Username,
+		Date:      time.Now(),
+	}
+	value, err := marshalDecision(d)
+	if err != nil {
+		return nil, err
+	}
+
+	crName := fmt.Sprintf("%v-%v", pipelineName, stageName)
+	prev, err := s.getStageDecision(crName, stepName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.annotateStage(crName, stepName, &value); err != nil {
+		return nil, err
+	}
+
+	if err := s.IQualityGateRepository.CreateDecision(d); err != nil {
+		if rerr := s.annotateStage(crName, stepName, prev); rerr != nil {
+			log.Error("couldn't revert quality gate decision on stage",
+				zap.String("stage", crName), zap.String("step", stepName), zap.Error(rerr))
+		}
+		return nil, errors.Wrapf(err, "couldn't save decision on quality gate %v", stepName)
+	}
+	log.Info("decision on manual quality gate has been made",
+		zap.String("pipeline", pipelineName),
+		zap.String("stage", stageName),
+		zap.String("step", stepName),
+		zap.String("decision", d.Decision),
+		zap.String("user", d.Username))
+	return d, nil
+}
+
+// GetDecision returns last decision on manual quality gate which was made after since time if it's passed
+func (s QualityGateService) GetDecision(pipelineName, stageName, stepName string, since *time.Time) (*query.QualityGateDecision, error) {
+	stageId, err := s.getManualGateStageId(pipelineName, stageName, stepName)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.IQualityGateRepository.SelectLastDecision(*stageId, stepName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get decision on quality gate %v", stepName)
+	}
+	if d == nil || (since != nil && d.Date.Before(*since)) {
+		return nil, nil
+	}
+	return d, nil
+}
+
+// FillDecisions sets last decisions to manual quality gates of stages
+func (s QualityGateService) FillDecisions(stages []*query.Stage) error {
+	for _, stage := range stages {
+		if !hasManualGates(stage.QualityGates) {
+			continue
+		}
+		decisions, err := s.IQualityGateRepository.SelectLastDecisions(stage.Id)
+		if err != nil {
+			return errors.Wrapf(err, "couldn't get decisions on quality gates of stage %v", stage.Name)
+		}
+		for i, g := range stage.QualityGates {
+			if g.QualityGateType != manualGateType {
+				continue
+			}
+			stage.QualityGates[i].Decision = findDecision(decisions, g.StepName)
+		}
+	}
+	return nil
+}
+
+func (s QualityGateService) getManualGateStageId(pipelineName, stageName, stepName string) (*int, error) {
+	stageId, err := s.IQualityGateRepository.SelectStageId(pipelineName, stageName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get stage %v of cd pipeline %v", stageName, pipelineName)
+	}
+	if stageId == nil {
+		return nil, edperror.NewQualityGateDoesNotExistError(stageName, stepName)
+	}
+
+	gate, err := s.IQualityGateRepository.SelectManualGate(*stageId, stepName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get quality gate %v of stage %v", stepName, stageName)
+	}
+	if gate == nil {
+		return nil, edperror.NewQualityGateDoesNotExistError(stageName, stepName)
+	}
+	return stageId, nil
+}
+
+func (s QualityGateService) getStageDecision(crName, stepName string) (*string, error) {
+	raw, err := s.Clients.EDPRestClient.Get().
+		Namespace(context.Namespace).
+		Resource(consts.StagePlural).
+		Name(crName).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get stage %v", crName)
+	}
+
+	var stage struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(raw, &stage); err != nil {
+		return nil, errors.Wrapf(err, "couldn't parse stage %v", crName)
+	}
+	value, ok := stage.Metadata.Annotations[decisionAnnotation(stepName)]
+	if !ok {
+		return nil, nil
+	}
+	return &value, nil
+}
+
+// annotateStage sets decision annotation of quality gate, nil value removes annotation
+func (s QualityGateService) annotateStage(crName, stepName string, value *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				decisionAnnotation(stepName): value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.Clients.EDPRestClient.Patch(types.MergePatchType).
+		Namespace(context.Namespace).
+		Resource(consts.StagePlural).
+		Name(crName).
+		Body(patch).
+		Do().Error()
+	if err != nil {
+		return errors.Wrapf(err, "couldn't annotate stage %v with quality gate decision", crName)
+	}
+	return nil
+}
+
+func marshalDecision(d *query.QualityGateDecision) (string, error) {
+	value, err := json.Marshal(map[string]string{
+		"decision": d.Decision,
+		"user":     d.Username,
+		"date":     d.Date.Format(time.RFC3339),
+		"comment":  d.Comment,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+func decisionAnnotation(stepName string) string {
+	return fmt.Sprintf("%v/%v", gateAnnotationPrefix, stepName)
+}
+
+func convertDecision(decision string) string {
+	if decision == "approve" {
+		return query.Approved
+	}
+	return query.Rejected
+}
+
+func hasManualGates(gates []query.QualityGate) bool {
+	for _, g := range gates {
+		if g.QualityGateType == manualGateType {
+			return true
+		}
+	}
+	return false
+}
+
+func findDecision(decisions []*query.QualityGateDecision, stepName string) *query.QualityGateDecision {
+	for _, d := range decisions {
+		if d.StepName == stepName {
+			return d
+		}
+	}
+	return nil
+}
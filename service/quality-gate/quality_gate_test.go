@@ -0,0 +1,89 @@
+package quality_gate
+
+import (
+	"edp-admin-console/models/command"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDecisionMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	mRepo := new(mock.MockQualityGate)
+	s := QualityGateService{
+		IQualityGateRepository: mRepo,
+	}
+
+	date := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	mRepo.On("SelectStageId", "stub-pipeline", "sit").Return(1, nil)
+	mRepo.On("SelectManualGate", 1, "approve").Return(query.QualityGate{StepName: "approve"}, nil)
+	mRepo.On("SelectLastDecision", 1, "approve").Return(query.QualityGateDecision{
+		StepName: "approve",
+		Decision: query.Approved,
+		Username: "stub-user",
+		Date:     date,
+	}, nil)
+
+	d, err := s.GetDecision("stub-pipeline", "sit", "approve", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, query.Approved, d.Decision)
+	assert.Equal(t, "stub-user", d.Username)
+
+	since := date.Add(time.Hour)
+	d, err = s.GetDecision("stub-pipeline", "sit", "approve", &since)
+	assert.NoError(t, err)
+	assert.Nil(t, d)
+}
+
+func TestMakeDecisionMethod_ShouldReturnNotFoundError(t *testing.T) {
+	mRepo := new(mock.MockQualityGate)
+	s := QualityGateService{
+		IQualityGateRepository: mRepo,
+	}
+
+	mRepo.On("SelectStageId", "stub-pipeline", "sit").Return(1, nil)
+	mRepo.On("SelectManualGate", 1, "tests").Return(nil, nil)
+
+	d, err := s.MakeDecision("stub-pipeline", "sit", "tests", command.QualityGateDecisionCommand{
+		Decision: "approve",
+		Username: "stub-user",
+	})
+	assert.Nil(t, d)
+	assert.IsType(t, &edperror.QualityGateDoesNotExistError{}, err)
+	mRepo.AssertNotCalled(t, "CreateDecision")
+}
+
+func TestFillDecisionsMethod_ShouldBeExecutedSuccessfully(t *testing.T) {
+	mRepo := new(mock.MockQualityGate)
+	s := QualityGateService{
+		IQualityGateRepository: mRepo,
+	}
+
+	stages := []*query.Stage{
+		{
+			Id: 1,
+			QualityGates: []query.QualityGate{
+				{QualityGateType: "autotests", StepName: "tests"},
+				{QualityGateType: "manual", StepName: "approve"},
+			},
+		},
+		{
+			Id: 2,
+			QualityGates: []query.QualityGate{
+				{QualityGateType: "autotests", StepName: "tests"},
+			},
+		},
+	}
+	mRepo.On("SelectLastDecisions", 1).Return([]*query.QualityGateDecision{
+		{StepName: "approve", Decision: query.Rejected},
+	}, nil)
+
+	err := s.FillDecisions(stages)
+	assert.NoError(t, err)
+	assert.Nil(t, stages[0].QualityGates[0].Decision)
+	assert.Equal(t, query.Rejected, stages[0].QualityGates[1].Decision.Decision)
+	mRepo.AssertNotCalled(t, "SelectLastDecisions", 2)
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"edp-admin-console/context"
+	"edp-admin-console/models/dto"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository"
+	"edp-admin-console/service/logger"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var log = logger.GetLogger()
+
+const (
+	minTermLength = 2
+	DefaultLimit  = 50
+	maxLimit      = 200
+)
+
+var patternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type SearchService struct {
+	ISearchRepository     repository.ISearchRepository
+	ICDPipelineRepository repository.ICDPipelineRepository
+}
+
+//Search looks for codebases, branches, docker streams, CD pipelines and stages which names
+//are similar to the term. Results are grouped by kind, groups are ordered by their best rank
+func (s SearchService) Search(term string, limit int) (*dto.SearchResult, error) {
+	term = strings.TrimSpace(term)
+	res := &dto.SearchResult{Query: term, Groups: []dto.SearchGroup{}}
+	if len([]rune(term)) < minTermLength {
+		return res, nil
+	}
+	if limit <= 0 || limit > maxLimit {
+		limit = DefaultLimit
+	}
+
+	log.Debug("start searching", zap.String("term", term), zap.Int("limit", limit))
+	hits, err := s.ISearchRepository.SelectSearchHits(term, "%"+patternEscaper.Replace(term)+"%", limit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't search by %v term", term)
+	}
+
+	res.Groups = groupHits(hits)
+	for i, g := range res.Groups {
+		if g.Kind != query.BranchSearchKind {
+			continue
+		}
+		for j, item := range g.Items {
+			pipelines, err := s.ICDPipelineRepository.GetCDPipelinesUsingApplicationAndBranch(item.Parent, item.Name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "couldn't get pipelines using %v branch of %v codebase", item.Name, item.Parent)
+			}
+			res.Groups[i].Items[j].Pipelines = pipelines
+		}
+	}
+	log.Debug("search has been finished", zap.String("term", term), zap.Int("hits", len(hits)))
+	return res, nil
+}
+
+//groupHits expects hits to be ordered by rank, so the first hit of each kind is the best one
+func groupHits(hits []query.SearchHit) []dto.SearchGroup {
+	groups := []dto.SearchGroup{}
+	index := map[string]int{}
+	for _, h := range hits {
+		i, ok := index[h.Kind]
+		if !ok {
+			i = len(groups)
+			index[h.Kind] = i
+			groups = append(groups, dto.SearchGroup{Kind: h.Kind, Items: []dto.SearchItem{}})
+		}
+		groups[i].Items = append(groups[i].Items, dto.SearchItem{
+			Name:   h.Name,
+			Parent: h.Parent,
+			Link:   createLink(h),
+			Rank:   h.Rank,
+		})
+	}
+	return groups
+}
+
+func createLink(h query.SearchHit) string {
+	switch h.Kind {
+	case query.CodebaseSearchKind:
+		return fmt.Sprintf("%v/admin/edp/codebase/%v/overview", context.BasePath, h.Name)
+	case query.BranchSearchKind, query.DockerStreamSearchKind:
+		return fmt.Sprintf("%v/admin/edp/codebase/%v/overview", context.BasePath, h.Parent)
+	case query.CDPipelineSearchKind:
+		return fmt.Sprintf("%v/admin/edp/cd-pipeline/%v/overview", context.BasePath, h.Name)
+	case query.StageSearchKind:
+		return fmt.Sprintf("%v/admin/edp/cd-pipeline/%v/overview", context.BasePath, h.Parent)
+	}
+	return ""
+}
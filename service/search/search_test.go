@@ -0,0 +1,71 @@
+package search
+
+import (
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSearchMethod_ShouldGroupHitsByKind(t *testing.T) {
+	mSearch := new(mock.MockSearch)
+	mPipeline := new(mock.MockCdPipeline)
+	s := SearchService{ISearchRepository: mSearch, ICDPipelineRepository: mPipeline}
+
+	mSearch.On("SelectSearchHits", "fake", "%fake%", DefaultLimit).Return([]query.SearchHit{
+		{Kind: query.CDPipelineSearchKind, Name: "fake-pipeline", Rank: 0.9},
+		{Kind: query.BranchSearchKind, Name: "fake-branch", Parent: "fake-app", Rank: 0.8},
+		{Kind: query.CDPipelineSearchKind, Name: "other-fake-pipeline", Rank: 0.5},
+		{Kind: query.StageSearchKind, Name: "fake-sit", Parent: "fake-pipeline", Rank: 0.4},
+	}, nil)
+	mPipeline.On("GetCDPipelinesUsingApplicationAndBranch", "fake-app", "fake-branch").
+		Return([]string{"fake-pipeline"}, nil)
+
+	res, err := s.Search(" fake ", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "fake", res.Query)
+	assert.Len(t, res.Groups, 3)
+
+	assert.Equal(t, query.CDPipelineSearchKind, res.Groups[0].Kind)
+	assert.Len(t, res.Groups[0].Items, 2)
+	assert.Equal(t, "/admin/edp/cd-pipeline/fake-pipeline/overview", res.Groups[0].Items[0].Link)
+
+	assert.Equal(t, query.BranchSearchKind, res.Groups[1].Kind)
+	assert.Equal(t, "/admin/edp/codebase/fake-app/overview", res.Groups[1].Items[0].Link)
+	assert.Equal(t, []string{"fake-pipeline"}, res.Groups[1].Items[0].Pipelines)
+
+	assert.Equal(t, "/admin/edp/cd-pipeline/fake-pipeline/overview", res.Groups[2].Items[0].Link)
+}
+
+func TestSearchMethod_ShouldEscapePatternWildcards(t *testing.T) {
+	mSearch := new(mock.MockSearch)
+	s := SearchService{ISearchRepository: mSearch}
+
+	mSearch.On("SelectSearchHits", "50%_off", `%50\%\_off%`, 10).Return([]query.SearchHit{}, nil)
+
+	res, err := s.Search("50%_off", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Groups)
+	mSearch.AssertExpectations(t)
+}
+
+func TestSearchMethod_ShouldSkipTooShortTerm(t *testing.T) {
+	mSearch := new(mock.MockSearch)
+	s := SearchService{ISearchRepository: mSearch}
+
+	res, err := s.Search("a", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Groups)
+	mSearch.AssertNotCalled(t, "SelectSearchHits")
+}
+
+func TestSearchMethod_ShouldReturnRepositoryError(t *testing.T) {
+	mSearch := new(mock.MockSearch)
+	s := SearchService{ISearchRepository: mSearch}
+
+	mSearch.On("SelectSearchHits", "fake", "%fake%", DefaultLimit).Return([]query.SearchHit{}, errors.New("fake"))
+
+	_, err := s.Search("fake", DefaultLimit)
+	assert.Error(t, err)
+}
@@ -1,13 +1,28 @@
 package service
 
 import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository"
+	dberror "edp-admin-console/util/error/db-errors"
+	"fmt"
+	"github.com/astaxie/beego"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
 )
 
+const jenkinsSlaveKind = "jenkins slave"
+
 type SlaveService struct {
+	Clients          k8s.ClientSet
 	ISlaveRepository repository.ISlaveRepository
 }
 
@@ -21,3 +36,165 @@ func (s SlaveService) GetAllSlaves() ([]*query.JenkinsSlave, error) {
 	log.Info("Fetched Slaves", zap.Any("slaves", jenkinsSlaves))
 	return jenkinsSlaves, nil
 }
+
+//GetSlavesUsage gets all slaves with codebases which are built on them
+func (s SlaveService) GetSlavesUsage() ([]dto.JenkinsSlave, error) {
+	slaves, err := s.GetAllSlaves()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]dto.JenkinsSlave, 0, len(slaves))
+	for _, sl := range slaves {
+		codebases, err := s.ISlaveRepository.SelectCodebasesUsingSlave(sl.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't get codebases using slave %v", sl.Name)
+		}
+		res = append(res, dto.JenkinsSlave{Name: sl.Name, Codebases: codebases})
+	}
+	return res, nil
+}
+
+//GetSlave gets pod template of slave and codebases which are built on it
+func (s SlaveService) GetSlave(name string) (*dto.JenkinsSlave, error) {
+	cm, err := s.getSlavesConfigMap()
+	if err != nil {
+		return nil, err
+	}
+	t, ok := cm.Data[slaveTemplateKey(name)]
+	if !ok {
+		sl, err := s.ISlaveRepository.GetSlave(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't get slave %v", name)
+		}
+		if sl == nil {
+			return nil, edperror.NewJenkinsResourceDoesNotExistError(jenkinsSlaveKind, name)
+		}
+	}
+
+	codebases, err := s.ISlaveRepository.SelectCodebasesUsingSlave(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get codebases using slave %v", name)
+	}
+	return &dto.JenkinsSlave{
+		Name:      name,
+		Template:  t,
+		Codebases: codebases,
+	}, nil
+}
+
+//CreateSlave adds pod template of slave to Jenkins configuration and registers slave in Jenkins custom resource
+func (s SlaveService) CreateSlave(cmd command.JenkinsSlaveCommand) error {
+	log.Debug("start creating jenkins slave", zap.String("name", cmd.Name))
+	cm, err := s.getSlavesConfigMap()
+	if err != nil {
+		return err
+	}
+	sl, err := s.ISlaveRepository.GetSlave(cmd.Name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get slave %v", cmd.Name)
+	}
+	if _, ok := cm.Data[slaveTemplateKey(cmd.Name)]; ok || sl != nil {
+		return edperror.NewJenkinsResourceExistsError(jenkinsSlaveKind, cmd.Name)
+	}
+
+	if err := s.saveSlaveTemplate(cm, cmd.Name, cmd.Template); err != nil {
+		return err
+	}
+	if err := updateJenkinsSpec(s.Clients, func(sp *jenkinsSpec) { sp.addSlave(cmd.Name) }); err != nil {
+		return err
+	}
+	log.Info("jenkins slave has been created", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+func (s SlaveService) UpdateSlave(cmd command.JenkinsSlaveCommand) error {
+	log.Debug("start updating jenkins slave", zap.String("name", cmd.Name))
+	cm, err := s.getSlavesConfigMap()
+	if err != nil {
+		return err
+	}
+	if _, ok := cm.Data[slaveTemplateKey(cmd.Name)]; !ok {
+		return edperror.NewJenkinsResourceDoesNotExistError(jenkinsSlaveKind, cmd.Name)
+	}
+
+	if err := s.saveSlaveTemplate(cm, cmd.Name, cmd.Template); err != nil {
+		return err
+	}
+	log.Info("jenkins slave has been updated", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+//DeleteSlave removes slave which isn't used by any codebase
+func (s SlaveService) DeleteSlave(name string) error {
+	log.Debug("start deleting jenkins slave", zap.String("name", name))
+	codebases, err := s.ISlaveRepository.SelectCodebasesUsingSlave(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get codebases using slave %v", name)
+	}
+	if len(codebases) > 0 {
+		return dberror.JenkinsResourceIsUsedByCodebase{
+			Status:    dberror.StatusReasonJenkinsResourceIsUsed,
+			Message:   fmt.Sprintf("slave %v is used by %v codebase(s). couldn't delete.", name, strings.Join(codebases, ",")),
+			Name:      name,
+			Codebases: codebases,
+		}
+	}
+
+	cm, err := s.getSlavesConfigMap()
+	if err != nil {
+		return err
+	}
+	sl, err := s.ISlaveRepository.GetSlave(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get slave %v", name)
+	}
+	if _, ok := cm.Data[slaveTemplateKey(name)]; !ok && sl == nil {
+		return edperror.NewJenkinsResourceDoesNotExistError(jenkinsSlaveKind, name)
+	}
+
+	if _, ok := cm.Data[slaveTemplateKey(name)]; ok {
+		delete(cm.Data, slaveTemplateKey(name))
+		if _, err := s.Clients.CoreClient.ConfigMaps(context.Namespace).Update(cm); err != nil {
+			return errors.Wrapf(err, "couldn't update config map %v", cm.Name)
+		}
+	}
+	if err := updateJenkinsSpec(s.Clients, func(sp *jenkinsSpec) { sp.removeSlave(name) }); err != nil {
+		return err
+	}
+	if err := s.ISlaveRepository.DeleteSlave(name); err != nil {
+		return errors.Wrapf(err, "couldn't delete slave %v from DB", name)
+	}
+	log.Info("jenkins slave has been deleted", zap.String("name", name))
+	return nil
+}
+
+func (s SlaveService) getSlavesConfigMap() (*v1.ConfigMap, error) {
+	name := beego.AppConfig.String("jenkinsSlavesConfigMap")
+	cm, err := s.Clients.CoreClient.ConfigMaps(context.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+		}
+		return nil, errors.Wrapf(err, "couldn't get config map %v", name)
+	}
+	return cm, nil
+}
+
+func (s SlaveService) saveSlaveTemplate(cm *v1.ConfigMap, name, template string) error {
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[slaveTemplateKey(name)] = template
+
+	var err error
+	if cm.ResourceVersion == "" {
+		_, err = s.Clients.CoreClient.ConfigMaps(context.Namespace).Create(cm)
+	} else {
+		_, err = s.Clients.CoreClient.ConfigMaps(context.Namespace).Update(cm)
+	}
+	return errors.Wrapf(err, "couldn't save template of slave %v", name)
+}
+
+func slaveTemplateKey(name string) string {
+	return fmt.Sprintf("%v-template", name)
+}
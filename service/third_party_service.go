@@ -1,12 +1,31 @@
 package service
 
 import (
+	"edp-admin-console/context"
+	"edp-admin-console/k8s"
+	"edp-admin-console/models/command"
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
 	"edp-admin-console/models/query"
 	"edp-admin-console/repository"
+	"edp-admin-console/util/consts"
+	"encoding/json"
+	"fmt"
+	edppipelinesv1alpha1 "github.com/epmd-edp/cd-pipeline-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sort"
+	"strings"
 )
 
+//StageServiceAnnotationPrefix prefixes annotations of stage custom resource which keep catalog versions
+//of the services selected for the stage
+const StageServiceAnnotationPrefix = "service.edp.epam.com"
+
 type ThirdPartyService struct {
+	Clients                   k8s.ClientSet
 	IServiceCatalogRepository repository.IServiceCatalogRepository
 }
 
@@ -21,3 +40,338 @@ func (s ThirdPartyService) GetAllServices() ([]query.ThirdPartyService, error) {
 		zap.Int("count", len(services)), zap.Any("services", services))
 	return services, nil
 }
+
+func (s ThirdPartyService) GetServicesDetails() ([]dto.ThirdPartyService, error) {
+	services, err := s.IServiceCatalogRepository.GetAllServices()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get services")
+	}
+	usages, err := s.getStageUsages()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.ThirdPartyService, 0, len(services))
+	for i := range services {
+		res = append(res, toThirdPartyServiceDto(&services[i], usages[services[i].Name]))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+func (s ThirdPartyService) GetServiceDetails(name string) (*dto.ThirdPartyService, error) {
+	service, err := s.IServiceCatalogRepository.GetService(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't get service %v", name)
+	}
+	if service == nil {
+		return nil, edperror.NewThirdPartyServiceDoesNotExistError(name)
+	}
+	usages, err := s.getStageUsages()
+	if err != nil {
+		return nil, err
+	}
+	res := toThirdPartyServiceDto(service, usages[name])
+	return &res, nil
+}
+
+func (s ThirdPartyService) CreateService(cmd command.ThirdPartyServiceCommand) error {
+	log.Debug("start creating service", zap.String("name", cmd.Name))
+	service, err := s.IServiceCatalogRepository.GetService(cmd.Name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get service %v", cmd.Name)
+	}
+	if service != nil {
+		return edperror.NewThirdPartyServiceExistsError(cmd.Name)
+	}
+
+	service = &query.ThirdPartyService{Name: cmd.Name}
+	if err := fillThirdPartyService(service, cmd); err != nil {
+		return err
+	}
+	if err := s.IServiceCatalogRepository.CreateService(service); err != nil {
+		return errors.Wrapf(err, "couldn't create service %v", cmd.Name)
+	}
+	log.Info("service has been created", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+func (s ThirdPartyService) UpdateService(cmd command.ThirdPartyServiceCommand) error {
+	log.Debug("start updating service", zap.String("name", cmd.Name))
+	service, err := s.IServiceCatalogRepository.GetService(cmd.Name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get service %v", cmd.Name)
+	}
+	if service == nil {
+		return edperror.NewThirdPartyServiceDoesNotExistError(cmd.Name)
+	}
+
+	if err := fillThirdPartyService(service, cmd); err != nil {
+		return err
+	}
+	if err := s.IServiceCatalogRepository.UpdateService(service); err != nil {
+		return errors.Wrapf(err, "couldn't update service %v", cmd.Name)
+	}
+	log.Info("service has been updated", zap.String("name", cmd.Name), zap.String("user", cmd.Username))
+	return nil
+}
+
+func (s ThirdPartyService) DeleteService(name string) error {
+	log.Debug("start deleting service", zap.String("name", name))
+	service, err := s.IServiceCatalogRepository.GetService(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get service %v", name)
+	}
+	if service == nil {
+		return edperror.NewThirdPartyServiceDoesNotExistError(name)
+	}
+
+	pipelines, err := s.IServiceCatalogRepository.SelectPipelinesUsingService(name)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get pipelines using service %v", name)
+	}
+	if len(pipelines) > 0 {
+		return edperror.NewNonValidThirdPartyServiceError(
+			fmt.Sprintf("service %v is used by pipelines: %v", name, strings.Join(pipelines, ", ")))
+	}
+	usages, err := s.getStageUsages()
+	if err != nil {
+		return err
+	}
+	if u := usages[name]; len(u) > 0 {
+		var stages []string
+		for _, v := range u {
+			stages = append(stages, fmt.Sprintf("%v/%v", v.Pipeline, v.Stage))
+		}
+		return edperror.NewNonValidThirdPartyServiceError(
+			fmt.Sprintf("service %v is used by stages: %v", name, strings.Join(stages, ", ")))
+	}
+
+	if err := s.IServiceCatalogRepository.DeleteService(name); err != nil {
+		return errors.Wrapf(err, "couldn't delete service %v", name)
+	}
+	log.Info("service has been deleted", zap.String("name", name))
+	return nil
+}
+
+func (s ThirdPartyService) GetServiceVersions(names []string) (map[string]string, error) {
+	versions := map[string]string{}
+	for _, n := range names {
+		service, err := s.IServiceCatalogRepository.GetService(n)
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't get service %v", n)
+		}
+		if service == nil {
+			return nil, edperror.NewNonValidThirdPartyServiceError(fmt.Sprintf("service %v doesn't exist", n))
+		}
+		versions[n] = service.Version
+	}
+	return versions, nil
+}
+
+func StageServiceAnnotations(versions map[string]string) map[string]string {
+	if len(versions) == 0 {
+		return nil
+	}
+	annotations := map[string]string{}
+	for n, v := range versions {
+		annotations[stageServiceAnnotation(n)] = v
+	}
+	return annotations
+}
+
+//GetStageServices returns names of the services selected for each stage of the pipeline
+func (s ThirdPartyService) GetStageServices(pipeline string) (map[string][]string, error) {
+	stages, err := s.getStages()
+	if err != nil {
+		return nil, err
+	}
+	res := map[string][]string{}
+	for _, st := range stages {
+		if st.Spec.CdPipeline == pipeline {
+			res[st.Spec.Name] = getStageServiceNames(st.Annotations)
+		}
+	}
+	return res, nil
+}
+
+//SetStageServices replaces services of the stage, services which are already selected keep their versions
+func (s ThirdPartyService) SetStageServices(pipeline, stage string, names []string) error {
+	crName := fmt.Sprintf("%v-%v", pipeline, stage)
+	cr := &edppipelinesv1alpha1.Stage{}
+	err := s.Clients.EDPRestClient.Get().
+		Namespace(context.Namespace).
+		Resource(consts.StagePlural).
+		Name(crName).
+		Do().
+		Into(cr)
+	if k8serrors.IsNotFound(err) {
+		return edperror.NewNonValidThirdPartyServiceError(fmt.Sprintf("stage %v doesn't exist in pipeline %v", stage, pipeline))
+	}
+	if err != nil {
+		return errors.Wrapf(err, "couldn't get stage %v from cluster", crName)
+	}
+
+	versions, err := s.GetServiceVersions(names)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": getStageServicesPatch(cr.Annotations, versions),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.Clients.EDPRestClient.Patch(types.MergePatchType).
+		Namespace(context.Namespace).
+		Resource(consts.StagePlural).
+		Name(crName).
+		Body(patch).
+		Do().Error()
+	if err != nil {
+		return errors.Wrapf(err, "couldn't set services of stage %v", crName)
+	}
+	log.Info("services of stage have been set", zap.String("stage", crName), zap.Strings("services", names))
+	return nil
+}
+
+//getStageServicesPatch removes annotations of unselected services and adds the new ones,
+//nil value removes annotation
+func getStageServicesPatch(annotations map[string]string, versions map[string]string) map[string]*string {
+	patch := map[string]*string{}
+	for _, n := range getStageServiceNames(annotations) {
+		if _, ok := versions[n]; !ok {
+			patch[stageServiceAnnotation(n)] = nil
+		}
+	}
+	for n, v := range versions {
+		if _, ok := annotations[stageServiceAnnotation(n)]; !ok {
+			v := v
+			patch[stageServiceAnnotation(n)] = &v
+		}
+	}
+	return patch
+}
+
+func getStageServiceNames(annotations map[string]string) []string {
+	var names []string
+	for k := range annotations {
+		if strings.HasPrefix(k, StageServiceAnnotationPrefix+"/") {
+			names = append(names, strings.TrimPrefix(k, StageServiceAnnotationPrefix+"/"))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func stageServiceAnnotation(name string) string {
+	return fmt.Sprintf("%v/%v", StageServiceAnnotationPrefix, name)
+}
+
+func (s ThirdPartyService) getStages() ([]edppipelinesv1alpha1.Stage, error) {
+	stages := &edppipelinesv1alpha1.StageList{}
+	err := s.Clients.EDPRestClient.Get().
+		Namespace(context.Namespace).
+		Resource(consts.StagePlural).
+		Do().
+		Into(stages)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get stages from cluster")
+	}
+	return stages.Items, nil
+}
+
+func (s ThirdPartyService) getStageUsages() (map[string][]dto.ThirdPartyServiceUsage, error) {
+	stages, err := s.getStages()
+	if err != nil {
+		return nil, err
+	}
+	return getStageUsages(stages), nil
+}
+
+func getStageUsages(stages []edppipelinesv1alpha1.Stage) map[string][]dto.ThirdPartyServiceUsage {
+	usages := map[string][]dto.ThirdPartyServiceUsage{}
+	for _, st := range stages {
+		for _, name := range getStageServiceNames(st.Annotations) {
+			usages[name] = append(usages[name], dto.ThirdPartyServiceUsage{
+				Pipeline: st.Spec.CdPipeline,
+				Stage:    st.Spec.Name,
+				Version:  st.Annotations[stageServiceAnnotation(name)],
+			})
+		}
+	}
+	for _, u := range usages {
+		sort.Slice(u, func(i, j int) bool {
+			if u[i].Pipeline != u[j].Pipeline {
+				return u[i].Pipeline < u[j].Pipeline
+			}
+			return u[i].Stage < u[j].Stage
+		})
+	}
+	return usages
+}
+
+func fillThirdPartyService(service *query.ThirdPartyService, cmd command.ThirdPartyServiceCommand) error {
+	params, err := json.Marshal(cmd.Parameters)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't marshal parameters of service %v", cmd.Name)
+	}
+	service.Version = cmd.Version
+	service.Description = cmd.Description
+	service.Url = cmd.Url
+	service.SourceType = cmd.SourceType
+	service.SourceReference = cmd.SourceReference
+	service.Parameters = string(params)
+	return nil
+}
+
+func toThirdPartyServiceDto(service *query.ThirdPartyService, usages []dto.ThirdPartyServiceUsage) dto.ThirdPartyService {
+	params := map[string]string{}
+	if service.Parameters != "" {
+		if err := json.Unmarshal([]byte(service.Parameters), &params); err != nil {
+			log.Error("couldn't unmarshal service parameters", zap.String("name", service.Name), zap.Error(err))
+		}
+	}
+	return dto.ThirdPartyService{
+		Name:            service.Name,
+		Version:         service.Version,
+		Description:     service.Description,
+		Url:             service.Url,
+		Icon:            service.Icon,
+		SourceType:      service.SourceType,
+		SourceReference: service.SourceReference,
+		Parameters:      params,
+		Usages:          usages,
+	}
+}
+
+func ParseServiceParameters(value string) (map[string]string, error) {
+	params := map[string]string{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, edperror.NewNonValidThirdPartyServiceError(
+				fmt.Sprintf("parameter %v should be in key=value format", line))
+		}
+		params[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return params, nil
+}
+
+func FormatServiceParameters(params map[string]string) string {
+	var lines []string
+	for k, v := range params {
+		lines = append(lines, fmt.Sprintf("%v=%v", k, v))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
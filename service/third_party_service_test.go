@@ -0,0 +1,87 @@
+package service
+
+import (
+	"edp-admin-console/models/dto"
+	edperror "edp-admin-console/models/error"
+	"edp-admin-console/models/query"
+	"edp-admin-console/repository/mock"
+	edppipelinesv1alpha1 "github.com/epmd-edp/cd-pipeline-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestDeleteServiceMethod_ShouldRefuseServiceUsedByPipeline(t *testing.T) {
+	mCatalog := new(mock.MockServiceCatalog)
+	s := ThirdPartyService{IServiceCatalogRepository: mCatalog}
+
+	mCatalog.On("GetService", "postgres").Return(query.ThirdPartyService{Name: "postgres"}, nil)
+	mCatalog.On("SelectPipelinesUsingService", "postgres").Return([]string{"stub-pipeline"}, nil)
+
+	err := s.DeleteService("postgres")
+	assert.IsType(t, &edperror.NonValidThirdPartyServiceError{}, err)
+	assert.Contains(t, err.Error(), "stub-pipeline")
+}
+
+func TestGetServiceVersionsMethod_ShouldReturnErrorForUnknownService(t *testing.T) {
+	mCatalog := new(mock.MockServiceCatalog)
+	s := ThirdPartyService{IServiceCatalogRepository: mCatalog}
+
+	mCatalog.On("GetService", "postgres").Return(query.ThirdPartyService{Name: "postgres", Version: "9.6"}, nil)
+	mCatalog.On("GetService", "redis").Return(nil, nil)
+
+	versions, err := s.GetServiceVersions([]string{"postgres"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"postgres": "9.6"}, versions)
+
+	_, err = s.GetServiceVersions([]string{"postgres", "redis"})
+	assert.IsType(t, &edperror.NonValidThirdPartyServiceError{}, err)
+}
+
+func TestParseServiceParameters_ShouldParseKeyValueLines(t *testing.T) {
+	params, err := ParseServiceParameters("storage=10Gi\n\n replicas = 2 \nargs=--a=b,--c")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"storage": "10Gi", "replicas": "2", "args": "--a=b,--c"}, params)
+	assert.Equal(t, "args=--a=b,--c\nreplicas=2\nstorage=10Gi", FormatServiceParameters(params))
+
+	_, err = ParseServiceParameters("storage")
+	assert.IsType(t, &edperror.NonValidThirdPartyServiceError{}, err)
+}
+
+func TestGetStageServicesPatch_ShouldKeepVersionsOfSelectedServices(t *testing.T) {
+	annotations := map[string]string{
+		"service.edp.epam.com/postgres": "9.6",
+		"service.edp.epam.com/redis":    "5.0",
+		"team":                          "payments",
+	}
+
+	kafka := "2.4"
+	patch := getStageServicesPatch(annotations, map[string]string{"postgres": "10.1", "kafka": "2.4"})
+	assert.Equal(t, map[string]*string{
+		"service.edp.epam.com/redis": nil,
+		"service.edp.epam.com/kafka": &kafka,
+	}, patch)
+}
+
+func TestGetStageUsages_ShouldListStagesByAnnotations(t *testing.T) {
+	stages := []edppipelinesv1alpha1.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"service.edp.epam.com/postgres": "9.6"}},
+			Spec:       edppipelinesv1alpha1.StageSpec{Name: "sit", CdPipeline: "stub-pipeline"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"service.edp.epam.com/postgres": "10.1"}},
+			Spec:       edppipelinesv1alpha1.StageSpec{Name: "qa", CdPipeline: "stub-pipeline"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"team": "payments"}},
+			Spec:       edppipelinesv1alpha1.StageSpec{Name: "dev", CdPipeline: "stub-pipeline"},
+		},
+	}
+
+	usages := getStageUsages(stages)
+	assert.Equal(t, map[string][]dto.ThirdPartyServiceUsage{"postgres": {
+		{Pipeline: "stub-pipeline", Stage: "qa", Version: "10.1"},
+		{Pipeline: "stub-pipeline", Stage: "sit", Version: "9.6"},
+	}}, usages)
+}
@@ -0,0 +1,25 @@
+$(function () {
+
+    let REGEX = {
+        PIPELINE_NAME: /^[a-z0-9]([-a-z0-9]*[a-z0-9])$/
+    };
+
+    $('#cloneCDCR').submit(function (e) {
+        let $pipelineNameInputEl = $('#pipelineName');
+
+        if (!isFieldValid($pipelineNameInputEl, REGEX.PIPELINE_NAME)) {
+            e.preventDefault();
+            $('.invalid-feedback.pipeline-name-validation').show();
+            $pipelineNameInputEl.addClass('is-invalid');
+            $('#collapseOne').collapse('show');
+        }
+    });
+
+    $('#pipelineName').focusout(function () {
+        if (isFieldValid($(this), REGEX.PIPELINE_NAME)) {
+            $(this).removeClass('is-invalid');
+            $('.invalid-feedback.pipeline-name-validation').hide();
+        }
+    });
+
+});
@@ -0,0 +1,51 @@
+$(function () {
+
+    let REGEX = {
+        TEMPLATE_NAME: /^[a-z0-9]([-a-z0-9]*[a-z0-9])$/
+    };
+
+    !function () {
+        let successMsg = $('#successMsg').val();
+        if (successMsg) {
+            $.notify({
+                    icon: 'glyphicon glyphicon-ok-circle alert-icon',
+                    message: successMsg
+                },
+                {
+                    type: 'success',
+                    delay: 5000,
+                    animate: {
+                        enter: 'animated fadeInRight',
+                        exit: 'animated fadeOutRight'
+                    },
+                    onShow: function () {
+                        this.css({'width': 'auto', 'display': 'flex'});
+                    },
+                });
+        }
+    }();
+
+    $('#createCDPipelineTemplate').submit(function (e) {
+        let $templateNameEl = $('#templateName');
+        if (!isFieldValid($templateNameEl, REGEX.TEMPLATE_NAME)) {
+            e.preventDefault();
+            $templateNameEl.addClass('is-invalid');
+            $('.invalid-feedback.template-name-validation').show();
+        }
+    });
+
+    $('.delete-cd-pipeline-template').click(function () {
+        let name = $(this).data('name'),
+            $modal = $("#delete-confirmation");
+        $('.confirmation-msg').text(`Confirm Deletion of '${name}'`);
+        $modal.data('name', name).modal('show');
+    });
+
+    $('.delete-confirmation').click(function () {
+        deleteConfirmation();
+    });
+
+    $('.close,.cancel-delete').click(function () {
+        closeConfirmation();
+    });
+});
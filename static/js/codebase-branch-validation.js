@@ -14,6 +14,16 @@ $(function () {
                 let errorMessage = 'The release branch with the ' + getUrlParameter('errorExistingBranch') + ' name already exists. To proceed, use another branch name.';
                 $('.branch-exists-modal').text(errorMessage).show();
                 $('#releaseBranchModal').modal('show');
+            } else if (anchor === '#commitNotFoundModal') {
+                let errorMessage = 'The commit ' + getUrlParameter('errorCommit') + ' doesn\'t exist in the codebase repository. To proceed, use another commit hash.';
+                $('.commit-not-found-modal').text(errorMessage).show();
+                loadCommitList();
+                $('#releaseBranchModal').modal('show');
+            } else if (anchor === '#commitNotVerifiedModal') {
+                let errorMessage = 'The commit ' + getUrlParameter('errorCommit') + ' couldn\'t be found in the recent history of the codebase repository in time. To proceed, try again or use one of the recent commits.';
+                $('.commit-not-found-modal').text(errorMessage).show();
+                loadCommitList();
+                $('#releaseBranchModal').modal('show');
             } else if (anchor === '#branchSuccessModal') {
                 showNotification(true);
             } else if (anchor === "#branchDeletedSuccessModal") {
@@ -48,6 +58,7 @@ $(function () {
         $('#commitNumber').val("");
         showBranchModalControls();
         $('.branch-exists-modal').hide();
+        $('.commit-not-found-modal').hide();
         if ($('#versioningPostfix').length) {
             $('#branchName,#commitNumber,#branch-version,#master-branch-version').removeClass('non-valid-input');
             $('.invalid-feedback.master-branch-version').hide();
@@ -61,6 +72,7 @@ $(function () {
     });
 
     $('.modal-release-branch').click(function () {
+        loadCommitList();
         $('#releaseBranchModal').modal('show');
         if ($('#versioningPostfix').length) {
             let branchName = $('#branchName').val(),
@@ -72,6 +84,7 @@ $(function () {
 
     $('#create-release-branch').click(function () {
         $('.branch-exists-modal').hide();
+        $('.commit-not-found-modal').hide();
         let isBranchValid = true;
         if (!$('#releaseBranch').length || $('#releaseBranch').is(':not(:checked)')) {
             isBranchValid = handleBranchNameValidation();
@@ -102,6 +115,33 @@ $(function () {
         }
     });
 
+    function loadCommitList() {
+        let $commitList = $('#commitList');
+        if ($commitList.data('loaded')) {
+            return;
+        }
+        $commitList.data('loaded', true);
+
+        let url = $('#create-branch-action').data('git-ref-url');
+        $.get(url, function (refs) {
+            $.each(refs.branches || [], function (i, ref) {
+                addCommitOption($commitList, ref.hash, 'branch ' + ref.name);
+            });
+        });
+        $.get(url + '/commits', function (commits) {
+            $.each(commits || [], function (i, commit) {
+                addCommitOption($commitList, commit.hash, commit.message + ' (' + commit.author + ')');
+            });
+        });
+    }
+
+    function addCommitOption($commitList, hash, label) {
+        if ($commitList.find('option[value="' + hash + '"]').length) {
+            return;
+        }
+        $('<option>').val(hash).text(label).appendTo($commitList);
+    }
+
     function showBranchModalControls() {
         let $createNewBranchModalEl = $('.create-new-branch-modal'),
             $versioningPostfixEl = $createNewBranchModalEl.find('.versioning-postfix'),
@@ -19,8 +19,26 @@ $(function () {
             } else if (anchor === '#codebaseIsDeleted') {
                 let codebase = getUrlParameter('codebase');
                 showNotification(true, `Codebase ${codebase} was marked for deletion.`);
+            } else if (anchor === '#codebaseIsArchived') {
+                let codebase = getUrlParameter('codebase');
+                showNotification(true, `Codebase ${codebase} has been archived.`);
+            } else if (anchor === '#codebaseIsRestored') {
+                let codebase = getUrlParameter('codebase');
+                showNotification(true, `Codebase ${codebase} has been restored.`);
+            } else if (anchor === '#codebaseIsNotArchived') {
+                let codebase = getUrlParameter('codebase');
+                showNotification(false, `Codebase ${codebase} is used by CD pipelines and couldn't be archived.`);
             } else if (anchor === '#codebaseUpdateSuccessModal') {
                 showNotification(true, 'The codebase has been updated successfully.');
+            } else if (anchor === '#codebasesReleased') {
+                let released = getUrlParameter('released'),
+                    failed = getUrlParameter('failed');
+                if (released) {
+                    showNotification(true, `Release branches have been created for ${released}.`);
+                }
+                if (failed) {
+                    showNotification(false, `Couldn't cut release for ${failed}.`);
+                }
             }
             location.hash = '';
         }
@@ -39,6 +57,10 @@ $(function () {
         window.history.replaceState({}, document.title, uri);
     });
 
+    $('.release-codebase').change(function () {
+        $('.cut-release').prop('disabled', !$('.release-codebase:checked').length);
+    });
+
     $('.delete-codebase').click(function () {
         let codebase = $(this).data('codebase'),
             $modal = $("#delete-confirmation");
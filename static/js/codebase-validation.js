@@ -32,6 +32,18 @@ $(function () {
         IMPORT: 'import'
     };
 
+    let REPO_UNAVAILABILITY_REASONS = {
+        AUTH_FAILED: 'auth_failed'
+    };
+
+    let REPO_UNAVAILABILITY_MESSAGES = {
+        not_found: "Current repository doesn't exist.",
+        timeout: "Repository didn't respond in time.",
+        empty: "Current repository is empty.",
+        host_key_rejected: "Host key of Git server isn't trusted.",
+        unreachable: "Repository is unreachable."
+    };
+
     $('.tooltip-icon').add('[data-toggle="tooltip"]').tooltip();
 
     !function () {
@@ -435,6 +447,66 @@ $(function () {
         $('#appName').val($(this).val().match(/([^\/]*)\/*$/)[1]);
     });
 
+    $('#defaultBranchName').focus(function () {
+        let data = getRepositoryRefsRequest();
+        if (!data) {
+            return;
+        }
+        let $branchList = $('#defaultBranchList'),
+            key = JSON.stringify(data);
+        if ($branchList.data('request') === key) {
+            return;
+        }
+        $branchList.data('request', key);
+
+        _sendPostRequest.bind(this)(true, `${$('input[id="basepath"]').val()}/api/v1/edp/repository/refs`, data, $('input[name="_xsrf"]').val(),
+            function (refs) {
+                $branchList.empty();
+                $.each(refs.branches || [], function (i, ref) {
+                    $('<option>').val(ref.name).appendTo($branchList);
+                });
+            }, function () {
+                $branchList.empty().removeData('request');
+                console.log('an error has occurred while reading repository branches')
+            });
+    });
+
+    function getRepositoryRefsRequest() {
+        let $strategyEl = $('.codebase-block').find('#strategy'),
+            strategy = $strategyEl.length ? $strategyEl.val().toLowerCase() : 'clone';
+
+        if (strategy === 'import') {
+            let $gitRelativePath = $('#gitRelativePath');
+            if (!isFieldValid($gitRelativePath, REGEX.RELATIVE_PATH)) {
+                return null;
+            }
+            return {
+                gitServer: $('#gitServer').val(),
+                gitUrlPath: $gitRelativePath.val()
+            };
+        }
+
+        if (strategy !== 'clone') {
+            return null;
+        }
+        let $repoUrl = $('#gitRepoUrl');
+        if (!isFieldValid($repoUrl, REGEX.REPO_URL)) {
+            return null;
+        }
+        let data = {
+            url: $repoUrl.val()
+        };
+        if ($('#isRepoPrivate').is(':checked')) {
+            if ($('#repoSecret').val()) {
+                data.secretName = $('#repoSecret').val();
+            } else {
+                data.login = $('#repoLogin').val();
+                data.password = $('#repoPassword').val();
+            }
+        }
+        return data;
+    }
+
     function setJenkinsSlave(el) {
         let $slave = getSlaveElement(el);
         if ($slave.length) {
@@ -578,8 +650,12 @@ $(function () {
 
                 let isRepoPrivate = $('#isRepoPrivate').is(':checked'),
                     $repoLogin = $('#repoLogin'),
-                    $repoPassword = $('#repoPassword');
-                if (isRepoPrivate) {
+                    $repoPassword = $('#repoPassword'),
+                    $repoSecret = $('#repoSecret'),
+                    $gitRepoReasonMsg = $('.git-repo-reason');
+                if (isRepoPrivate && $repoSecret.val()) {
+                    creds.secretName = $repoSecret.val();
+                } else if (isRepoPrivate) {
                     let isLoginValid = isFieldValid($repoLogin, REGEX.REPO_LOGIN);
                     if (!isLoginValid) {
                         $repoLogin.next('.invalid-feedback').show();
@@ -599,28 +675,26 @@ $(function () {
                     }
                 }
 
-                _sendPostRequest.bind(this)(false, `${$('input[id="basepath"]').val()}/api/v1/repository/available`, creds, $('input[name="_xsrf"]').val(),
-                    function (isAvailable) {
-                        if (isRepoPrivate) {
-                            if (isAvailable) {
-                                isValid = true;
-                            } else {
-                                $('.git-creds').show();
-                                $repoUrl.addClass('is-invalid');
-                                $repoLogin.addClass('is-invalid');
-                                $repoPassword.addClass('is-invalid');
-                                isValid = false;
-                            }
+                _sendPostRequest.bind(this)(false, `${$('input[id="basepath"]').val()}/api/v1/edp/repository/available`, creds, $('input[name="_xsrf"]').val(),
+                    function (res) {
+                        if (res.available) {
+                            isValid = true;
+                            return;
+                        }
+                        isValid = false;
+                        $repoUrl.addClass('is-invalid');
+                        if (res.reason !== REPO_UNAVAILABILITY_REASONS.AUTH_FAILED) {
+                            $gitRepoReasonMsg.text(REPO_UNAVAILABILITY_MESSAGES[res.reason] || res.message).show();
+                        } else if (isRepoPrivate) {
+                            $('.git-creds').show();
+                            $repoLogin.add($repoPassword).add($repoSecret).addClass('is-invalid');
                         } else {
-                            if (isAvailable) {
-                                isValid = true;
-                            } else {
-                                $gitRepoMsg.show();
-                                $repoUrl.addClass('is-invalid');
-                                isValid = false;
-                            }
+                            $gitRepoMsg.show();
                         }
-                    }, function () {
+                    }, function (resp) {
+                        isValid = false;
+                        $repoUrl.addClass('is-invalid');
+                        $gitRepoReasonMsg.text(resp.responseText).show();
                         console.log('an error has occurred while checking repository accessibility')
                     });
 
@@ -722,6 +796,10 @@ $(function () {
     }
 
     function isVCSValid() {
+        if ($('#vcsSecret').val()) {
+            return true;
+        }
+
         let $vcsLoginInputEl = $('#vcsLogin'),
             isVcsLoginValid = isFieldValid($vcsLoginInputEl, REGEX.VCS_LOGIN);
 
@@ -117,6 +117,29 @@ function buildCdPipeline() {
     return elements;
 }
 
+function loadDependencies(diagram) {
+    let ids = {};
+    $.each(JSON.parse($('#diagram').attr('codebase-attr')), function (ci, cv) {
+        ids[cv.name] = cv.id + '_codebase';
+    });
+
+    $.get(`${$('input[id="basepath"]').val()}/api/v1/edp/dependency`, function (graph) {
+        $.each(graph.edges, function (ei, ev) {
+            if (!ids[ev.from] || !ids[ev.to]) {
+                return;
+            }
+            diagram.add({
+                data: {
+                    id: 'from' + ids[ev.from] + 'to' + ids[ev.to] + '_dependency',
+                    source: ids[ev.from],
+                    target: ids[ev.to],
+                    type: 'dependency'
+                }
+            });
+        });
+    });
+}
+
 function initDiagram() {
     let diagram = cytoscape({
         container: $('#diagram'),
@@ -214,6 +237,15 @@ function initDiagram() {
                     'curve-style': 'bezier'
                 }
             },
+            //library dependency line style
+            {
+                selector: 'edge[type="dependency"]',
+                style: {
+                    'line-style': 'dotted',
+                    'line-color': '#e57373',
+                    'target-arrow-color': '#e57373'
+                }
+            },
         ],
         layout: {
             name: 'dagre',
@@ -242,6 +274,8 @@ function initDiagram() {
             diagram.filter('node').style("display", "element");
         }
     });
+
+    loadDependencies(diagram);
 }
 
 initDiagram();
@@ -1,11 +1,21 @@
 $(function () {
 
     $('.update-codebase').click(function () {
-        if (_arePatternsValid()) {
+        let arePatternsValid = _arePatternsValid(),
+            areDataSourcesValid = _areDataSourcesValid();
+        if (arePatternsValid && areDataSourcesValid) {
             $('#updateCodebase').submit();
         }
     });
 
+    function _areDataSourcesValid() {
+        let isPerfEnabled = $('#perfServer').val() !== '',
+            isValid = !isPerfEnabled || $('.dataSources input:checked').length !== 0;
+
+        $('.invalid-feedback.data-source-checkbox-error').toggle(!isValid);
+        return isValid;
+    }
+
     function _arePatternsValid() {
         let $commitMsgEl = $('#commitMessagePattern'),
             $ticketNumberEl = $('#ticketNamePattern'),
@@ -7,6 +7,17 @@ $(function () {
             let stage = getUrlParameter('stage');
             if (anchor === '#stageSuccessModal') {
                 showNotification(true, `Stage ${stage} was marked for deletion.`);
+            } else if (anchor === '#gateDecisionSuccess') {
+                showNotification(true, `Decision on ${getUrlParameter('step')} quality gate of ${stage} stage has been made.`);
+            } else if (anchor === '#gateDecisionError') {
+                showNotification(false, $('.flash-error').text().trim() ||
+                    `Couldn't make decision on ${getUrlParameter('step')} quality gate of ${stage} stage.`);
+            } else if (anchor === '#freezeWindowCreated') {
+                showNotification(true, `Freeze window has been added to ${stage} stage.`);
+            } else if (anchor === '#freezeWindowDeleted') {
+                showNotification(true, `Freeze window has been removed from ${stage} stage.`);
+            } else if (anchor === '#freezeWindowError') {
+                showNotification(false, $('.flash-error').text().trim() || `Couldn't change freeze windows of ${stage} stage.`);
             } else if (anchor === '#stageIsUsedAsSource') {
                 let $modal = $("#delete-confirmation");
                 $('.confirmation-msg').text(`Confirm Deletion of '${stage}'`);
@@ -34,6 +34,7 @@ $(function () {
             stageToEdit.find('#stageDescForm').val(stageData.stageDesc).attr('name', stageData.stageName + '-stageDesc');
             stageToEdit.find('#triggerTypeForm').val(stageData.triggerType).attr('name', stageData.stageName + '-triggerType');
             stageToEdit.find('#jobProvisioningForm').val(stageData.jobProvisioning).attr('name', stageData.stageName + '-jobProvisioning');
+            stageToEdit.find('#servicesForm').val(stageData.services.join(',')).attr('name', stageData.stageName + '-services');
             stageToEdit.find('#pipelineLibraryNameForm').val(stageData.pipelineLibraryName);
             stageToEdit.find('#pipelineLibraryBranchForm').val(stageData.pipelineLibraryBranch);
 
@@ -71,6 +72,35 @@ $(function () {
         }
     });
 
+    $('#pipelineTemplate').change(function () {
+        $('.stages-list').empty();
+        let stages = $(this).find('option:selected').data('stages');
+        if (!stages) {
+            return;
+        }
+
+        $.each(stages, function () {
+            let library = this.source.library;
+            appendStage({
+                stageName: this.name,
+                stageDesc: this.description,
+                pipelineLibraryName: library ? library.name : 'default',
+                pipelineLibraryBranch: library ? library.branch : null,
+                triggerType: this.triggerType,
+                jobProvisioning: this.jobProvisioning,
+                services: this.services || [],
+                qualityGates: $.map(this.qualityGates, function (gate) {
+                    return {
+                        qualityGateType: gate.qualityGateType,
+                        stepName: gate.stepName,
+                        autotestName: gate.autotestName,
+                        branchName: gate.branchName
+                    };
+                })
+            });
+        });
+    });
+
     $('.stage-modal-close, .cancel-edit-stage').click(function () {
         resetFields();
         toggleAdding();
@@ -166,6 +196,7 @@ function appendStage(stageData) {
         '<input id="stageDescForm" name="' + stageData.stageName + '-stageDesc" type="hidden" value="' + stageData.stageDesc + '">' +
         '<input id="triggerTypeForm" name="' + stageData.stageName + '-triggerType" type="hidden" value="' + stageData.triggerType + '">' +
         '<input id="jobProvisioningForm" name="' + stageData.stageName + '-jobProvisioning" type="hidden" value="' + stageData.jobProvisioning + '">' +
+        '<input id="servicesForm" name="' + stageData.stageName + '-services" type="hidden" value="' + stageData.services.join(',') + '">' +
         '<input id="pipelineLibraryNameForm" name="' + stageData.stageName + '-pipelineLibraryName" type="hidden" value="' + stageData.pipelineLibraryName + '">' +
         '<input id="pipelineLibraryBranchForm" name="' + stageData.stageName + '-pipelineLibraryBranch" type="hidden" value="' + stageData.pipelineLibraryBranch + '">' +
         '    </div>').appendTo($('.stages-list'));
@@ -189,6 +220,7 @@ function resetFields() {
     $('#triggerType option:first').prop('selected', true);
     $('#stage-creation input[type="text"]').val("");
     $('#pipeline-library option:first').prop('selected', true);
+    $('#stageServices').val([]);
 
     $('input.non-valid-input, select.non-valid-input').removeClass('non-valid-input');
     $('div.invalid-feedback').hide();
@@ -221,6 +253,8 @@ function fillFields(stageName) {
     $('#stageDesc').val($stageEl.find('#stageDescForm').val());
     $("#triggerType").val($stageEl.find('#triggerTypeForm').val());
     $("#jobProvisioning").val($stageEl.find('#jobProvisioningForm').val());
+    let services = $stageEl.find('#servicesForm').val();
+    $('#stageServices').val(services ? services.split(',') : []);
     $('#pipeline-library').val($stageEl.find('#pipelineLibraryNameForm').val()).change();
     $('#pipeline-library-branches').val($stageEl.find('#pipelineLibraryBranchForm').val()).change();
 
@@ -297,6 +331,7 @@ function collectStageData() {
         pipelineLibraryBranch: pipelineLibrary === 'default' ? null : $('.pipeline-library-row').find('[data-selected-pipeline-library="' + pipelineLibrary + '"]').val(),
         triggerType: $('#triggerType').val(),
         jobProvisioning: $('#jobProvisioning').val(),
+        services: $('#stageServices').val() || [],
         qualityGates: collectQualityGates(),
     };
 }
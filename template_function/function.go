@@ -25,6 +25,9 @@ func init() {
 	if err := beego.AddFuncMap("getCurrentYear", getCurrentYear); err != nil {
 		panic("couldn't register 'getCurrentYear' function to go template")
 	}
+	if err := beego.AddFuncMap("contains", util.Contains); err != nil {
+		panic("couldn't register 'contains' function to go template")
+	}
 }
 
 func getMasterBranchVersion(cb []*query.CodebaseBranch) string {
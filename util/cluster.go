@@ -4,7 +4,11 @@ import (
 	"edp-admin-console/context"
 	"edp-admin-console/util/consts"
 	edpv1alpha1 "github.com/epmd-edp/codebase-operator/v2/pkg/apis/edp/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 )
 
@@ -19,3 +23,40 @@ func GetCodebaseCR(c *rest.RESTClient, name string) (*edpv1alpha1.Codebase, erro
 	}
 	return r, nil
 }
+
+//SaveSecret sets the data in the secret or creates the secret if it doesn't exist.
+//Empty values don't replace the current ones. validate is called before the secret is created
+//to check that the data is enough for the new secret
+func SaveSecret(c *coreV1Client.CoreV1Client, name string, data map[string]string, validate func() error) error {
+	secrets := c.Secrets(context.Namespace)
+	secret, err := secrets.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "couldn't get secret %v", name)
+		}
+		if err := validate(); err != nil {
+			return err
+		}
+		_, err := secrets.Create(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			StringData: setSecretData(map[string]string{}, data),
+		})
+		return errors.Wrapf(err, "couldn't create secret %v", name)
+	}
+
+	secret.StringData = setSecretData(secret.StringData, data)
+	_, err = secrets.Update(secret)
+	return errors.Wrapf(err, "couldn't update secret %v", name)
+}
+
+func setSecretData(current, data map[string]string) map[string]string {
+	if current == nil {
+		current = map[string]string{}
+	}
+	for k, v := range data {
+		if v != "" {
+			current[k] = v
+		}
+	}
+	return current
+}
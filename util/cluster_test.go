@@ -0,0 +1,15 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetSecretDataMethod_ShouldKeepValuesForEmptyData(t *testing.T) {
+	current := map[string]string{"username": "stub-user", "password": "stub-password"}
+	res := setSecretData(current, map[string]string{"username": "new-user", "password": ""})
+	assert.Equal(t, map[string]string{"username": "new-user", "password": "stub-password"}, res)
+
+	res = setSecretData(nil, map[string]string{"username": "stub-user", "known_hosts": ""})
+	assert.Equal(t, map[string]string{"username": "stub-user"}, res)
+}
@@ -17,6 +17,17 @@ const (
 	StagePlural          = "stages"
 	CDPipelinePlural     = "cdpipelines"
 	CodebaseKind         = "Codebase"
+	GitServerPlural      = "gitservers"
+	GitServerKind        = "GitServer"
+	JiraServerPlural     = "jiraservers"
+	JiraServerKind       = "JiraServer"
+	JenkinsPlural        = "jenkins"
+	JenkinsScriptPlural  = "jenkinsscripts"
+	JenkinsScriptKind    = "JenkinsScript"
+	EDPComponentPlural   = "edpcomponents"
+	EDPComponentKind     = "EDPComponent"
+	PerfServerPlural     = "perfservers"
+	PerfServerKind       = "PerfServer"
 
 	ImportStrategy = "import"
 	LanguageJava   = "Java"
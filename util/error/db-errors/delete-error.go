@@ -37,3 +37,15 @@ type RemoveCodebaseBranchRestriction struct {
 func (e RemoveCodebaseBranchRestriction) Error() string {
 	return string(e.Status)
 }
+
+type JenkinsResourceIsUsedByCodebase struct {
+	Status    StatusReason
+	Message   string
+	Name      string
+	Codebases []string
+	Stages    []string
+}
+
+func (e JenkinsResourceIsUsedByCodebase) Error() string {
+	return string(e.Status)
+}
@@ -10,6 +10,7 @@ const (
 	StatusCDStageIsNotTheLast                    StatusReason = "StatusCDStageIsNotTheLast"
 	StatusRemoveCDPipelineRestriction            StatusReason = "RemoveCDPipelineRestriction"
 	StatusReasonCodebaseBranchIsUsedByCDPipeline StatusReason = "CodebaseBranchIsUsed"
+	StatusReasonJenkinsResourceIsUsed            StatusReason = "JenkinsResourceIsUsed"
 )
 
 func IsNotFound(err error) bool {
@@ -33,6 +34,10 @@ func CodebaseBranchErrorOccurred(err error) bool {
 	return reasonForError(err) == StatusReasonCodebaseBranchIsUsedByCDPipeline
 }
 
+func JenkinsResourceIsUsed(err error) bool {
+	return reasonForError(err) == StatusReasonJenkinsResourceIsUsed
+}
+
 func reasonForError(err error) StatusReason {
 	switch t := err.(type) {
 	case StatusError:
@@ -45,6 +50,8 @@ func reasonForError(err error) StatusReason {
 		return t.Status
 	case RemoveCodebaseBranchRestriction:
 		return t.Status
+	case JenkinsResourceIsUsedByCodebase:
+		return t.Status
 	}
 	return StatusReasonUnknown
 }
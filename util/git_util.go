@@ -17,30 +17,399 @@
 package util
 
 import (
+	"context"
+	"edp-admin-console/models/dto"
 	"edp-admin-console/service/logger"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/client"
 	"gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
 	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"io"
+	"io/ioutil"
+	"net"
+	gohttp "net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
 )
 
-var log = logger.GetLogger()
+const (
+	gitRequestTimeout = 30 * time.Second
+	// commitSearchDepth limits history fetched to look for a commit
+	commitSearchDepth = 1000
+	defaultSshUser    = "git"
+	// GitLab requires this user for token auth, GitHub accepts any non-empty one
+	defaultTokenUser = "oauth2"
+)
+
+var (
+	log = logger.GetLogger()
+
+	ErrGitTimeout = errors.New("repository didn't respond in time")
+	// ErrGitHistoryTruncated is returned if a commit isn't found in the fetched part of repository history
+	ErrGitHistoryTruncated = errors.New("commit isn't found in the recent history of repository")
+)
+
+// go-git doesn't accept context to list remote references, so connections are bounded by dial and request timeouts
+func init() {
+	c := http.NewClient(&gohttp.Client{Timeout: gitRequestTimeout})
+	client.InstallProtocol("http", c)
+	client.InstallProtocol("https", c)
+}
+
+type GitCredentials struct {
+	Username   string
+	Password   string
+	Token      string
+	PrivateKey []byte
+	Passphrase string
+	// KnownHosts is content of known_hosts file used to verify SSH host key,
+	// default known_hosts files are used if it's empty
+	KnownHosts []byte
+}
 
 func IsGitRepoAvailable(repo string, user string, pass string) bool {
-	r, _ := git.Init(memory.NewStorage(), nil)
-	remote, _ := r.CreateRemote(&config.RemoteConfig{
+	return CheckGitRepo(repo, GitCredentials{Username: user, Password: pass}).Available
+}
+
+func CheckGitRepo(repo string, creds GitCredentials) dto.RepositoryAvailability {
+	auth, err := getGitAuth(repo, creds)
+	if err != nil {
+		log.Error("couldn't create authentication to repository", zap.String("url", repo), zap.Error(err))
+		return dto.RepositoryAvailability{Reason: dto.RepositoryAuthFailed, Message: err.Error()}
+	}
+
+	rfs, err := listRemoteRefs(repo, auth)
+	if err != nil {
+		log.Error("an error has occurred during authentication to repository", zap.String("url", repo), zap.Error(err))
+		return dto.RepositoryAvailability{Reason: getGitErrorReason(err), Message: err.Error()}
+	}
+	if len(rfs) == 0 {
+		return dto.RepositoryAvailability{Reason: dto.RepositoryEmpty, Message: transport.ErrEmptyRemoteRepository.Error()}
+	}
+	return dto.RepositoryAvailability{Available: true}
+}
+
+func ListGitRefs(repo string, creds GitCredentials) (*dto.GitRefs, error) {
+	auth, err := getGitAuth(repo, creds)
+	if err != nil {
+		return nil, err
+	}
+	rfs, err := listRemoteRefs(repo, auth)
+	if err != nil && err != transport.ErrEmptyRemoteRepository {
+		return nil, err
+	}
+
+	res := &dto.GitRefs{Branches: []dto.GitRef{}, Tags: []dto.GitRef{}}
+	for _, r := range rfs {
+		ref := dto.GitRef{Name: r.Name().Short(), Hash: r.Hash().String()}
+		if r.Name().IsBranch() {
+			res.Branches = append(res.Branches, ref)
+		} else if r.Name().IsTag() {
+			res.Tags = append(res.Tags, ref)
+		}
+	}
+	sort.Slice(res.Branches, func(i, j int) bool { return res.Branches[i].Name < res.Branches[j].Name })
+	sort.Slice(res.Tags, func(i, j int) bool { return res.Tags[i].Name < res.Tags[j].Name })
+	return res, nil
+}
+
+func GetGitCommits(repo, branch string, creds GitCredentials, limit int) ([]dto.GitCommit, error) {
+	r, err := cloneGitRepo(repo, creds, &git.CloneOptions{
+		ReferenceName: plumbing.NewBranchReferenceName(branch),
+		SingleBranch:  true,
+		Depth:         limit,
+		NoCheckout:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+	it, err := r.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	res := []dto.GitCommit{}
+	for len(res) < limit {
+		c, err := it.Next()
+		// parents of the oldest commit of shallow clone are absent
+		if err == io.EOF || err == plumbing.ErrObjectNotFound {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, dto.GitCommit{
+			Hash:    c.Hash.String(),
+			Message: strings.SplitN(strings.TrimSpace(c.Message), "\n", 2)[0],
+			Author:  c.Author.Name,
+			Date:    c.Author.When,
+		})
+	}
+	return res, nil
+}
+
+// HasGitCommit looks for the commit in the recent history of repository branches, the commit can be abbreviated.
+// ErrGitHistoryTruncated is returned if it isn't found but the history is longer
+func HasGitCommit(repo, commit string, creds GitCredentials) (bool, error) {
+	r, err := cloneGitRepo(repo, creds, &git.CloneOptions{
+		Depth:      commitSearchDepth,
+		NoCheckout: true,
+		Tags:       git.NoTags,
+	})
+	if err != nil {
+		return false, err
+	}
+	found, err := findCommit(r, commit)
+	if err != nil || found {
+		return found, err
+	}
+	shallow, err := r.Storer.Shallow()
+	if err != nil {
+		return false, err
+	}
+	if len(shallow) > 0 {
+		return false, ErrGitHistoryTruncated
+	}
+	return false, nil
+}
+
+func findCommit(r *git.Repository, commit string) (bool, error) {
+	prefix := strings.ToLower(commit)
+	if len(prefix) == len(plumbing.ZeroHash.String()) {
+		_, err := r.CommitObject(plumbing.NewHash(prefix))
+		if err == plumbing.ErrObjectNotFound {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	commits, err := r.CommitObjects()
+	if err != nil {
+		return false, err
+	}
+	found := false
+	err = commits.ForEach(func(c *object.Commit) error {
+		if strings.HasPrefix(c.Hash.String(), prefix) {
+			found = true
+			return storer.ErrStop
+		}
+		return nil
+	})
+	return found, err
+}
+
+func listRemoteRefs(repo string, auth transport.AuthMethod) ([]*plumbing.Reference, error) {
+	r, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := r.CreateRemote(&config.RemoteConfig{
 		Name: "origin",
 		URLs: []string{repo},
 	})
-	rfs, err := remote.List(&git.ListOptions{
-		Auth: &http.BasicAuth{
-			Username: user,
-			Password: pass,
-		}})
 	if err != nil {
-		log.Error("an error has occurred during authentication to repository", zap.Error(err))
-		return false
+		return nil, err
+	}
+	return remote.List(&git.ListOptions{Auth: withDialTimeout(auth)})
+}
+
+func cloneGitRepo(repo string, creds GitCredentials, o *git.CloneOptions) (*git.Repository, error) {
+	auth, err := getGitAuth(repo, creds)
+	if err != nil {
+		return nil, err
+	}
+	o.URL = repo
+	o.Auth = withDialTimeout(auth)
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitRequestTimeout)
+	defer cancel()
+	r, err := git.CloneContext(ctx, memory.NewStorage(), nil, o)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return nil, ErrGitTimeout
+	}
+	return r, err
+}
+
+// sshDialTimeout bounds connection and handshake of ssh transport which doesn't use http client
+type sshDialTimeout struct {
+	ssh.AuthMethod
+}
+
+func (a sshDialTimeout) ClientConfig() (*gossh.ClientConfig, error) {
+	c, err := a.AuthMethod.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	c.Timeout = gitRequestTimeout
+	return c, nil
+}
+
+func withDialTimeout(auth transport.AuthMethod) transport.AuthMethod {
+	if a, ok := auth.(ssh.AuthMethod); ok {
+		return sshDialTimeout{a}
+	}
+	return auth
+}
+
+// IsGitTimeout reports whether repository didn't respond in time
+func IsGitTimeout(err error) bool {
+	if err == ErrGitTimeout {
+		return true
+	}
+	ne, ok := errors.Cause(err).(net.Error)
+	return ok && ne.Timeout()
+}
+
+func getGitErrorReason(err error) string {
+	if IsGitTimeout(err) {
+		return dto.RepositoryTimeout
+	}
+	switch err {
+	case transport.ErrRepositoryNotFound:
+		return dto.RepositoryNotFound
+	case transport.ErrEmptyRemoteRepository:
+		return dto.RepositoryEmpty
+	case transport.ErrAuthenticationRequired, transport.ErrAuthorizationFailed, transport.ErrInvalidAuthMethod:
+		return dto.RepositoryAuthFailed
+	}
+	// ssh errors are formatted by x/crypto and can't be checked by type
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "knownhosts:"):
+		return dto.RepositoryHostKeyRejected
+	case strings.Contains(msg, "unable to authenticate"):
+		return dto.RepositoryAuthFailed
+	}
+	return dto.RepositoryUnreachable
+}
+
+func getGitAuth(repo string, creds GitCredentials) (transport.AuthMethod, error) {
+	ep, err := transport.NewEndpoint(repo)
+	if err != nil {
+		return nil, err
+	}
+	if ep.Protocol != "ssh" {
+		if creds.Token != "" {
+			user := creds.Username
+			if user == "" {
+				user = defaultTokenUser
+			}
+			return &http.BasicAuth{Username: user, Password: creds.Token}, nil
+		}
+		return &http.BasicAuth{Username: creds.Username, Password: creds.Password}, nil
+	}
+
+	user := creds.Username
+	if user == "" {
+		user = ep.User
+	}
+	if user == "" {
+		user = defaultSshUser
+	}
+	hkc, err := getHostKeyCallback(creds.KnownHosts)
+	if err != nil {
+		return nil, err
+	}
+	if len(creds.PrivateKey) == 0 {
+		a := &ssh.Password{User: user, Password: creds.Password}
+		a.HostKeyCallback = hkc
+		return a, nil
+	}
+	a, err := ssh.NewPublicKeys(user, creds.PrivateKey, creds.Passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse private key")
+	}
+	a.HostKeyCallback = hkc
+	return a, nil
+}
+
+// GetHostKeyCallback verifies host keys by known hosts, known_hosts files of the console are used if they are empty
+func GetHostKeyCallback(knownHosts []byte) (gossh.HostKeyCallback, error) {
+	if len(knownHosts) == 0 {
+		return ssh.NewKnownHostsCallback()
+	}
+	return getHostKeyCallback(knownHosts)
+}
+
+func getHostKeyCallback(knownHosts []byte) (gossh.HostKeyCallback, error) {
+	if len(knownHosts) == 0 {
+		return nil, nil
+	}
+	// knownhosts reads hosts from files only
+	f, err := ioutil.TempFile("", "known_hosts")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := f.Write(knownHosts); err != nil {
+		return nil, err
+	}
+	cb, err := knownhosts.New(f.Name())
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse known hosts")
+	}
+	return cb, nil
+}
+
+func ReadGitFiles(repo, branch string, creds GitCredentials, accept func(path string) bool) (map[string][]byte, error) {
+	r, err := cloneGitRepo(repo, creds, &git.CloneOptions{
+		ReferenceName: plumbing.NewBranchReferenceName(branch),
+		SingleBranch:  true,
+		Depth:         1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := r.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := commit.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string][]byte{}
+	err = files.ForEach(func(f *object.File) error {
+		if !accept(f.Name) {
+			return nil
+		}
+		c, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		res[f.Name] = []byte(c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return len(rfs) != 0
+	return res, nil
 }
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"edp-admin-console/models/dto"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+	"gopkg.in/src-d/go-billy.v4/memfs"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"net"
+	gohttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckGitRepoMethod_ShouldReturnReason(t *testing.T) {
+	ts := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		if _, _, ok := r.BasicAuth(); ok && r.URL.Path == "/private.git/info/refs" {
+			w.WriteHeader(gohttp.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(gohttp.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	res := CheckGitRepo(ts.URL+"/private.git", GitCredentials{Username: "user", Password: "wrong"})
+	assert.False(t, res.Available)
+	assert.Equal(t, dto.RepositoryAuthFailed, res.Reason)
+
+	res = CheckGitRepo(ts.URL+"/missing.git", GitCredentials{})
+	assert.False(t, res.Available)
+	assert.Equal(t, dto.RepositoryNotFound, res.Reason)
+}
+
+func TestGetGitAuthMethod_ShouldSelectAuthByUrlAndCredentials(t *testing.T) {
+	auth, err := getGitAuth("https://github.com/sample/sample.git", GitCredentials{Token: "stub-token"})
+	assert.NoError(t, err)
+	assert.Equal(t, &http.BasicAuth{Username: defaultTokenUser, Password: "stub-token"}, auth)
+
+	auth, err = getGitAuth("git@git.sample.com:sample/sample.git", GitCredentials{Password: "password"})
+	assert.NoError(t, err)
+	assert.IsType(t, &ssh.Password{}, auth)
+	assert.Equal(t, defaultSshUser, auth.(*ssh.Password).User)
+
+	_, err = getGitAuth("ssh://git.sample.com/sample.git", GitCredentials{PrivateKey: []byte("stub-key")})
+	assert.Error(t, err)
+
+	_, err = getGitAuth("ssh://git.sample.com/sample.git", GitCredentials{KnownHosts: []byte("stub-host stub-key")})
+	assert.Error(t, err)
+}
+
+func TestWithDialTimeoutMethod_ShouldBoundSshConnection(t *testing.T) {
+	pass := &ssh.Password{User: "git", Password: "password"}
+	pass.HostKeyCallback = gossh.InsecureIgnoreHostKey()
+	auth := withDialTimeout(pass)
+	c, err := auth.(ssh.AuthMethod).ClientConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, gitRequestTimeout, c.Timeout)
+
+	basic := &http.BasicAuth{Username: "user", Password: "password"}
+	assert.Equal(t, basic, withDialTimeout(basic))
+}
+
+func TestIsGitTimeoutMethod_ShouldDetectTimeout(t *testing.T) {
+	_, err := net.DialTimeout("tcp", "10.255.255.1:22", time.Nanosecond)
+	assert.True(t, IsGitTimeout(err))
+	assert.True(t, IsGitTimeout(ErrGitTimeout))
+	assert.False(t, IsGitTimeout(ErrGitHistoryTruncated))
+}
+
+func TestFindCommitMethod_ShouldMatchAbbreviatedHash(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.NoError(t, err)
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	h, err := w.Commit("init", &git.CommitOptions{
+		Author: &object.Signature{Name: "fake-user", Email: "fake@example.com", When: time.Now()},
+	})
+	assert.NoError(t, err)
+
+	for _, c := range []string{h.String(), h.String()[:7], strings.ToUpper(h.String()[:10])} {
+		found, err := findCommit(r, c)
+		assert.NoError(t, err)
+		assert.True(t, found, c)
+	}
+
+	found, err := findCommit(r, strings.Repeat("0", 39)+"1")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}